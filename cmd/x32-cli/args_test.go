@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+// newTestParser builds the parser of main, without the options that only affect help and exiting.
+func newTestParser(t *testing.T, cli *CLI) *kong.Kong {
+	t.Helper()
+	parser, err := kong.New(
+		cli,
+		kong.TypeMapper(reflect.TypeOf(relativeFloat{}), relativeFloatMapper()),
+		kong.TypeMapper(reflect.TypeOf(float64(0)), unitFloatMapper()),
+		kong.Vars{"version": "test"},
+	)
+	if err != nil {
+		t.Fatalf("failed to build parser: %v", err)
+	}
+	return parser
+}
+
+func TestNegativeArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"negative integer", []string{"strip", "1", "pan", "-10"}, []string{"strip", "1", "pan", "--", "-10"}},
+		{"negative decimal", []string{"strip", "1", "eq", "2", "gain", "-90.5"}, []string{"strip", "1", "eq", "2", "gain", "--", "-90.5"}},
+		{"negative with unit", []string{"strip", "1", "eq", "2", "gain", "-6dB"}, []string{"strip", "1", "eq", "2", "gain", "--", "-6dB"}},
+		{"negative positional before flags", []string{"strip", "1", "pan", "-10", "--verbose"}, []string{"strip", "1", "pan", "--verbose", "--", "-10"}},
+		{"relative fader", []string{"strip", "1", "fader", "-10"}, []string{"strip", "1", "fader", "--", "+-10"}},
+		{"relative main fader", []string{"main", "fader", "-3dB"}, []string{"main", "fader", "--", "+-3dB"}},
+		{"relative send after the bus", []string{"strip", "1", "send", "3", "-12"}, []string{"strip", "1", "send", "3", "--", "+-12"}},
+		{"relative value before flags", []string{"strip", "1", "fader", "-10", "--fine"}, []string{"strip", "1", "fader", "--fine", "--", "+-10"}},
+		{"relative value flag", []string{"strip", "1", "send", "--buses", "1,2", "--level", "-6"}, []string{"strip", "1", "send", "--buses", "1,2", "--level=+-6"}},
+		{"long value flag", []string{"strip", "1", "gate", "set", "--threshold", "-40"}, []string{"strip", "1", "gate", "set", "--threshold=-40"}},
+		{"short value flag", []string{"-T", "-5ms", "main", "mute"}, []string{"-T-5ms", "main", "mute"}},
+		{"value flag with positive value", []string{"--host", "mixer", "main", "fader", "-3"}, []string{"--host", "mixer", "main", "fader", "--", "+-3"}},
+		{"bool flag before a negative number", []string{"strip", "1", "pan", "--verbose", "-6"}, []string{"strip", "1", "pan", "--verbose", "--", "-6"}},
+		{"short bool flag", []string{"-q", "strip", "1", "fader", "-6"}, []string{"-q", "strip", "1", "fader", "--", "+-6"}},
+		{"explicit separator keeps values absolute", []string{"strip", "1", "fader", "--", "-10"}, []string{"strip", "1", "fader", "--", "-10"}},
+		{"raw arguments stay as typed", []string{"raw", "/ch/01/mix/pan", "-0.5"}, []string{"raw", "/ch/01/mix/pan", "--", "-0.5"}},
+		{"no negative numbers", []string{"strip", "1", "mute", "true"}, []string{"strip", "1", "mute", "true"}},
+	}
+	var cli CLI
+	parser := newTestParser(t, &cli)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := negativeArgs(parser.Model, tt.args); !slices.Equal(got, tt.want) {
+				t.Errorf("negativeArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegativeArgsRelativeValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		want     float64
+		relative bool
+	}{
+		{"plain minus is relative", []string{"strip", "1", "fader", "-2"}, -2, true},
+		{"plus is relative", []string{"strip", "1", "fader", "+2"}, 2, true},
+		{"after -- is absolute", []string{"strip", "1", "fader", "--", "-10"}, -10, false},
+		{"positive is absolute", []string{"strip", "1", "fader", "0"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cli CLI
+			parser := newTestParser(t, &cli)
+			if _, err := parser.Parse(negativeArgs(parser.Model, tt.args)); err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+			got := cli.Strip.Index.Fader.Level
+			if got == nil || got.Value != tt.want || got.Relative != tt.relative {
+				t.Errorf("got level %+v, want value %g, relative %t", got, tt.want, tt.relative)
+			}
+		})
+	}
+}
+
+func TestNegativeArgsParse(t *testing.T) {
+	var cli CLI
+	parser := newTestParser(t, &cli)
+	if _, err := parser.Parse(negativeArgs(parser.Model, []string{"strip", "1", "gate", "set", "--threshold", "-40", "--range", "20"})); err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if got := cli.Strip.Index.Gate.Set.Threshold; got == nil || *got != -40 {
+		t.Errorf("got threshold %v, want -40", got)
+	}
+}
+
+func TestParseUnitFloat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"-6", -6},
+		{"-6dB", -6},
+		{"-6 db", -6},
+		{"250Hz", 250},
+		{"2kHz", 2000},
+		{"1.5KHZ", 1500},
+		{"1.5k", 1500},
+		{"20ms", 20},
+		{"75%", 75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseUnitFloat(tt.in)
+			if err != nil {
+				t.Fatalf("parseUnitFloat(%q) failed: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseUnitFloat(%q) = %g, want %g", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUnitFloatRejects(t *testing.T) {
+	for _, in := range []string{"", "dB", "loud", "2kk", "nan", "NaN", "inf", "-Inf", "infdB", "nank"} {
+		t.Run(in, func(t *testing.T) {
+			if got, err := parseUnitFloat(in); err == nil {
+				t.Errorf("parseUnitFloat(%q) = %g, want an error", in, got)
+			}
+		})
+	}
+}
+
+func TestRelativeFloatQuantize(t *testing.T) {
+	tests := []struct {
+		name      string
+		arg       relativeFloat
+		resolved  float64
+		want      float64
+		wantValue float64
+	}{
+		{"absolute rounds down", relativeFloat{Value: -10.04}, -10.04, -10.0, -10.04},
+		{"absolute rounds up", relativeFloat{Value: -10.06}, -10.06, -10.1, -10.06},
+		{"exact step is kept", relativeFloat{Value: 3.2}, 3.2, 3.2, 3.2},
+		{"relative change follows the snapped level", relativeFloat{Value: -3.26, Relative: true}, -13.26, -13.3, -3.3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arg := tt.arg
+			got := arg.quantize(tt.resolved, fineStep)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("quantize(%g) = %g, want %g", tt.resolved, got, tt.want)
+			}
+			if math.Abs(arg.Value-tt.wantValue) > 1e-9 {
+				t.Errorf("argument value is %g after quantize, want %g", arg.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestResolveToggle(t *testing.T) {
+	tests := []struct {
+		arg     string
+		current bool
+		want    bool
+		reads   bool
+	}{
+		{"toggle", true, false, true},
+		{"toggle", false, true, true},
+		{"true", false, true, false},
+		{"false", true, false, false},
+	}
+	for _, tt := range tests {
+		reads := false
+		got, err := resolveToggle(tt.arg, func() (bool, error) {
+			reads = true
+			return tt.current, nil
+		})
+		if err != nil {
+			t.Fatalf("resolveToggle(%q) failed: %v", tt.arg, err)
+		}
+		if got != tt.want || reads != tt.reads {
+			t.Errorf("resolveToggle(%q) with current %t = %t, read %t, want %t, read %t", tt.arg, tt.current, got, reads, tt.want, tt.reads)
+		}
+	}
+
+	want := errors.New("no reply")
+	if _, err := resolveToggle("toggle", func() (bool, error) { return false, want }); !errors.Is(err, want) {
+		t.Errorf("got error %v, want %v", err, want)
+	}
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
+	"text/tabwriter"
 	"time"
 
-	"github.com/alecthomas/kong"
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
 )
 
 // BusCmdGroup defines the commands related to controlling the buses of the X-Air device.
@@ -13,18 +16,33 @@ type BusCmdGroup struct {
 		Index   int           `arg:"" help:"The index of the bus. (1-based indexing)"`
 		Mute    BusMuteCmd    `       help:"Get or set the mute state of the bus." cmd:""`
 		Fader   BusFaderCmd   `     help:"Get or set the fader level of the bus." cmd:""`
+		Pan     BusPanCmd     `       help:"Get or set the pan position of the bus." cmd:""`
 		Fadein  BusFadeinCmd  `      help:"Fade in the bus over a specified duration." cmd:""`
 		Fadeout BusFadeoutCmd `     help:"Fade out the bus over a specified duration." cmd:""`
 		Name    BusNameCmd    `       help:"Get or set the name of the bus." cmd:""`
+		Inputs  BusInputsCmd  `      help:"Show the strips sending to the bus, loudest first." cmd:""`
+		Copy    BusCopyCmd    `      help:"Copy the bus from one mixer to the same bus of another." cmd:""`
+		Send    BusSendCmd    `       help:"Get or set the send level to a specific matrix." cmd:""`
 
+		Mono BusMonoCmdGroup `     help:"Commands related to the bus mono/center send." cmd:"mono"`
 		Eq   BusEqCmdGroup   `       help:"Commands related to the bus EQ." cmd:"eq"`
 		Comp BusCompCmdGroup `     help:"Commands related to the bus compressor." cmd:"comp"`
 	} `arg:"" help:"Control a specific bus by index."`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
+}
+
+// indexes returns the bus index addressed by the command, see validateIndexes.
+func (cmd *BusCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "bus <index>") {
+		return "", nil
+	}
+	return "bus", []int{cmd.Index.Index}
 }
 
 // BusMuteCmd defines the command for getting or setting the mute state of a bus.
 type BusMuteCmd struct {
-	State *string `arg:"" help:"The mute state to set (true or false). If not provided, the current mute state will be returned." optional:"" enum:"true,false"`
+	State *string `arg:"" help:"The mute state to set (true, false or toggle). If not provided, the current mute state will be returned." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the BusMuteCmd command, either retrieving the current mute state or setting it based on the provided argument.
@@ -38,16 +56,23 @@ func (cmd *BusMuteCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Bus.SetMute(bus.Index.Index, *cmd.State == "true"); err != nil {
+	state, err := resolveToggle(*cmd.State, func() (bool, error) {
+		return ctx.Client.Bus.Mute(bus.Index.Index)
+	})
+	if err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d mute state set to: %s\n", bus.Index.Index, *cmd.State)
+
+	if err := ctx.Client.Bus.SetMute(bus.Index.Index, state); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d mute state set to: %t\n", bus.Index.Index, state)
 	return nil
 }
 
 // BusFaderCmd defines the command for getting or setting the fader level of a bus.
 type BusFaderCmd struct {
-	Level *float64 `arg:"" help:"The fader level to set (in dB). If not provided, the current fader level will be returned." optional:""`
+	Level *relativeFloat `arg:"" help:"The fader level to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current fader level will be returned." optional:""`
 }
 
 // Run executes the BusFaderCmd command, either retrieving the current fader level or setting it based on the provided argument.
@@ -61,10 +86,49 @@ func (cmd *BusFaderCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Bus.SetFader(bus.Index.Index, *cmd.Level); err != nil {
+	level, err := cmd.Level.resolve(func() (float64, error) {
+		return ctx.Client.Bus.Fader(bus.Index.Index)
+	}, minLevel, maxLevel)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Client.Bus.SetFader(bus.Index.Index, level); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d fader level set to: %.2f dB\n", bus.Index.Index, *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Bus %d fader level set to: %.2f dB%s\n", bus.Index.Index, level, cmd.Level.delta("dB"))
+	return nil
+}
+
+// BusPanCmd defines the command for getting or setting the pan position of a bus.
+// For a linked pair the mixer applies the same parameter as the balance of the pair.
+type BusPanCmd struct {
+	Pan *float64 `arg:"" help:"The pan position to set (-100 left to 100 right). Acts as balance when the bus is linked." optional:""`
+}
+
+// Validate checks that the provided pan position is within the valid range (-100 to 100).
+func (cmd *BusPanCmd) Validate() error {
+	if cmd.Pan != nil && (*cmd.Pan < -100 || *cmd.Pan > 100) {
+		return fmt.Errorf("pan must be between -100 and 100")
+	}
+	return nil
+}
+
+// Run executes the BusPanCmd command, either retrieving the current pan position of the bus or setting it based on the provided argument.
+func (cmd *BusPanCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	if cmd.Pan == nil {
+		resp, err := ctx.Client.Bus.Pan(bus.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get pan position: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Bus %d pan position: %.2f\n", bus.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Bus.SetPan(bus.Index.Index, *cmd.Pan); err != nil {
+		return fmt.Errorf("failed to set pan position: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d pan position set to: %.2f\n", bus.Index.Index, *cmd.Pan)
 	return nil
 }
 
@@ -72,6 +136,7 @@ func (cmd *BusFaderCmd) Run(ctx *context, bus *BusCmdGroup) error {
 type BusFadeinCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-in effect." default:"5s"`
 	Target   float64       `        help:"The target fader level (in dB)."     default:"0.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the BusFadeinCmd command, gradually increasing the fader level of the bus from its current level to the target level over the specified duration.
@@ -89,21 +154,15 @@ func (cmd *BusFadeinCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		)
 	}
 
-	totalSteps := float64(cmd.Target - currentLevel)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel < cmd.Target {
-		currentLevel += totalSteps / float64(cmd.Duration.Seconds()*1000/stepDuration.Seconds())
-		if currentLevel > cmd.Target {
-			currentLevel = cmd.Target
-		}
-
-		if err := ctx.Client.Bus.SetFader(bus.Index.Index, currentLevel); err != nil {
-			return fmt.Errorf("failed to set fader level: %w", err)
-		}
-		time.Sleep(stepDuration)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	set := func(level float64) error { return ctx.Client.Bus.SetFader(bus.Index.Index, level) }
+	if err := rampFader(set, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set fader level: %w", err)
 	}
+	bar.done()
 
-	fmt.Fprintf(ctx.Out, "Bus %d fade-in complete. Final level: %.2f dB\n", bus.Index.Index, cmd.Target)
+	fmt.Fprintf(ctx.Confirm, "Bus %d fade-in complete. Final level: %.2f dB\n", bus.Index.Index, cmd.Target)
 	return nil
 }
 
@@ -111,6 +170,7 @@ func (cmd *BusFadeinCmd) Run(ctx *context, bus *BusCmdGroup) error {
 type BusFadeoutCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-out effect." default:"5s"`
 	Target   float64       `        help:"The target fader level (in dB)."      default:"-90.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the BusFadeoutCmd command, gradually decreasing the fader level of the bus from its current level to the target level over the specified duration.
@@ -128,31 +188,38 @@ func (cmd *BusFadeoutCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		)
 	}
 
-	totalSteps := float64(currentLevel - cmd.Target)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel > cmd.Target {
-		currentLevel -= totalSteps / float64(cmd.Duration.Seconds()*1000/stepDuration.Seconds())
-		if currentLevel < cmd.Target {
-			currentLevel = cmd.Target
-		}
-
-		if err := ctx.Client.Bus.SetFader(bus.Index.Index, currentLevel); err != nil {
-			return fmt.Errorf("failed to set fader level: %w", err)
-		}
-		time.Sleep(stepDuration)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	set := func(level float64) error { return ctx.Client.Bus.SetFader(bus.Index.Index, level) }
+	if err := rampFader(set, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set fader level: %w", err)
 	}
+	bar.done()
 
-	fmt.Fprintf(ctx.Out, "Bus %d fade-out complete. Final level: %.2f dB\n", bus.Index.Index, cmd.Target)
+	fmt.Fprintf(ctx.Confirm, "Bus %d fade-out complete. Final level: %.2f dB\n", bus.Index.Index, cmd.Target)
 	return nil
 }
 
 // BusNameCmd defines the command for getting or setting the name of a bus.
 type BusNameCmd struct {
-	Name *string `arg:"" help:"The name to set for the bus. If not provided, the current name will be returned." optional:""`
+	Name  *string `arg:"" help:"The name to set for the bus. If not provided, the current name will be returned." optional:""`
+	Clear bool    `       help:"Clear the name of the bus."`
+}
+
+// Validate checks that a name and --clear are not given together.
+func (cmd *BusNameCmd) Validate() error {
+	if cmd.Clear && cmd.Name != nil {
+		return fmt.Errorf("cannot set a name and --clear at the same time")
+	}
+	return nil
 }
 
 // Run executes the BusNameCmd command, either retrieving the current name of the bus or setting it based on the provided argument.
 func (cmd *BusNameCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	if cmd.Clear {
+		cmd.Name = new(string)
+	}
+
 	if cmd.Name == nil {
 		resp, err := ctx.Client.Bus.Name(bus.Index.Index)
 		if err != nil {
@@ -165,34 +232,231 @@ func (cmd *BusNameCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.SetName(bus.Index.Index, *cmd.Name); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d name set to: %s\n", bus.Index.Index, *cmd.Name)
+	fmt.Fprintf(ctx.Confirm, "Bus %d name set to: %s\n", bus.Index.Index, *cmd.Name)
+	return nil
+}
+
+// BusInputsCmd defines the command for printing the send from every strip into a bus, showing who feeds it.
+type BusInputsCmd struct {
+	JSON    bool `help:"Print the inputs as JSON." name:"json"`
+	Nonzero bool `help:"Hide sends that are off or all the way down."`
+}
+
+// Run executes the BusInputsCmd command, reading the send from every strip before printing them as a table or as JSON.
+func (cmd *BusInputsCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	inputs, err := ctx.Client.Bus.Inputs(bus.Index.Index)
+	if err != nil {
+		return fmt.Errorf("failed to get inputs: %w", err)
+	}
+	if cmd.Nonzero {
+		audible := inputs[:0]
+		for _, input := range inputs {
+			if !input.Off() {
+				audible = append(audible, input)
+			}
+		}
+		inputs = audible
+	}
+
+	if cmd.JSON {
+		enc := json.NewEncoder(ctx.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(inputs)
+	}
+
+	w := tabwriter.NewWriter(ctx.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STRIP\tLEVEL\tON")
+	for _, input := range inputs {
+		on := "-"
+		if input.On != nil {
+			on = fmt.Sprintf("%t", *input.On)
+		}
+		fmt.Fprintf(w, "%d\t%.2f dB\t%s\n", input.Strip, input.Level, on)
+	}
+	return w.Flush()
+}
+
+// BusSendCmd defines the command for getting or setting the send level from a bus to a specific matrix.
+type BusSendCmd struct {
+	MatrixNum int            `arg:"" help:"The matrix number to get or set the send level for. (1-6)"`
+	Level     *relativeFloat `arg:"" help:"The send level to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." optional:""`
+}
+
+// Validate checks that the provided matrix number is within the valid range (1-6).
+func (cmd *BusSendCmd) Validate() error {
+	if cmd.MatrixNum < 1 || cmd.MatrixNum > 6 {
+		return fmt.Errorf("matrix number must be between 1 and 6")
+	}
+	return nil
+}
+
+// Run executes the BusSendCmd command, either retrieving the current send level to the specified matrix or setting it based on the provided argument.
+func (cmd *BusSendCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	if cmd.Level == nil {
+		resp, err := ctx.Client.Bus.SendLevel(bus.Index.Index, cmd.MatrixNum)
+		if err != nil {
+			return fmt.Errorf("failed to get send level: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Bus %d send level for matrix %d: %.2f dB\n", bus.Index.Index, cmd.MatrixNum, resp)
+		return nil
+	}
+
+	level, err := cmd.Level.resolve(func() (float64, error) {
+		return ctx.Client.Bus.SendLevel(bus.Index.Index, cmd.MatrixNum)
+	}, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get current send level: %w", err)
+	}
+
+	if err := ctx.Client.Bus.SetSendLevel(bus.Index.Index, cmd.MatrixNum, level); err != nil {
+		return fmt.Errorf("failed to set send level: %w", err)
+	}
+	fmt.Fprintf(
+		ctx.Confirm,
+		"Bus %d send level for matrix %d set to: %.2f dB%s\n",
+		bus.Index.Index,
+		cmd.MatrixNum,
+		level,
+		cmd.Level.delta("dB"),
+	)
+	return nil
+}
+
+// BusMonoCmdGroup defines the command group for controlling the mono/center (M/C) bus send of a bus.
+// The send only has an effect while the M/C bus is active.
+type BusMonoCmdGroup struct {
+	On    BusMonoOnCmd    `help:"Get or set whether the bus is sent to the mono/center bus." cmd:""`
+	Level BusMonoLevelCmd `help:"Get or set the mono/center bus send level of the bus."     cmd:""`
+}
+
+// BusMonoOnCmd defines the command for getting or setting whether a bus is sent to the mono/center bus.
+type BusMonoOnCmd struct {
+	State *string `arg:"" help:"Whether to send the bus to the mono/center bus (true or false)." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the BusMonoOnCmd command, either retrieving the current mono/center send state of the bus or setting it based on the provided argument.
+func (cmd *BusMonoOnCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	if cmd.State == nil {
+		resp, err := ctx.Client.Bus.MonoSend(bus.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get mono send state: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Bus %d mono send state: %t\n", bus.Index.Index, resp)
+		return nil
+	}
+
+	state, err := resolveToggle(*cmd.State, func() (bool, error) {
+		return ctx.Client.Bus.MonoSend(bus.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get mono send state: %w", err)
+	}
+
+	if err := ctx.Client.Bus.SetMonoSend(bus.Index.Index, state); err != nil {
+		return fmt.Errorf("failed to set mono send state: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d mono send state set to: %t\n", bus.Index.Index, state)
+	return nil
+}
+
+// BusMonoLevelCmd defines the command for getting or setting the mono/center bus send level of a bus.
+type BusMonoLevelCmd struct {
+	Level *relativeFloat `arg:"" help:"The mono send level to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." optional:""`
+}
+
+// Run executes the BusMonoLevelCmd command, either retrieving the current mono/center send level of the bus or setting it based on the provided argument.
+func (cmd *BusMonoLevelCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	if cmd.Level == nil {
+		resp, err := ctx.Client.Bus.MonoLevel(bus.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get mono send level: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Bus %d mono send level: %.2f dB\n", bus.Index.Index, resp)
+		return nil
+	}
+
+	level, err := cmd.Level.resolve(func() (float64, error) {
+		return ctx.Client.Bus.MonoLevel(bus.Index.Index)
+	}, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get current mono send level: %w", err)
+	}
+
+	if err := ctx.Client.Bus.SetMonoLevel(bus.Index.Index, level); err != nil {
+		return fmt.Errorf("failed to set mono send level: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d mono send level set to: %.2f dB%s\n", bus.Index.Index, level, cmd.Level.delta("dB"))
 	return nil
 }
 
 // BusEqCmdGroup defines the commands related to controlling the EQ of a bus.
 type BusEqCmdGroup struct {
-	On   BusEqOnCmd   `help:"Get or set the EQ on/off state of the bus."              cmd:"on"`
-	Mode BusEqModeCmd `help:"Get or set the EQ mode of the bus (peq, geq or teq)."    cmd:"mode"`
+	On   BusEqOnCmd      `help:"Get or set the EQ on/off state of the bus."           cmd:"on"`
+	Mode BusEqModeCmd    `help:"Get or set the EQ mode of the bus (peq, geq or teq)." cmd:"mode"`
+	AB   BusEqABCmdGroup `help:"Compare two EQ settings of the bus."                 cmd:"ab"`
 	Band struct {
-		Band int              `arg:"" help:"The EQ band number."`
-		Gain BusEqBandGainCmd `help:"Get or set the gain of the EQ band." cmd:"gain"`
-		Freq BusEqBandFreqCmd `help:"Get or set the frequency of the EQ band." cmd:"freq"`
-		Q    BusEqBandQCmd    `help:"Get or set the Q factor of the EQ band." cmd:"q"`
-		Type BusEqBandTypeCmd `help:"Get or set the type of the EQ band (lcut, lshv, peq, veq, hshv, hcut)." cmd:"type"`
+		Band     int                  `arg:"" help:"The EQ band number."`
+		Gain     BusEqBandGainCmd     `help:"Get or set the gain of the EQ band." cmd:"gain"`
+		Freq     BusEqBandFreqCmd     `help:"Get or set the frequency of the EQ band." cmd:"freq"`
+		Q        BusEqBandQCmd        `help:"Get or set the Q factor of the EQ band." cmd:"q"`
+		Type     BusEqBandTypeCmd     `help:"Get or set the type of the EQ band (lcut, lshv, peq, veq, hshv, hcut)." cmd:"type"`
+		Audition BusEqBandAuditionCmd `help:"Boost the EQ band for a while to hear it, then restore its gain." cmd:"audition"`
 	} `help:"Commands for controlling a specific EQ band of the bus."            arg:""`
 }
 
-// Validate checks that the provided EQ band number is within the valid range (1-6).
-func (cmd *BusEqCmdGroup) Validate(ctx kong.Context) error {
-	if cmd.Band.Band < 1 || cmd.Band.Band > 6 {
-		return fmt.Errorf("EQ band number must be between 1 and 6")
+// indexes returns the EQ band addressed by the command, see validateIndexes.
+// Commands that do not address a band, such as eq on, leave the band unset and are not checked.
+func (cmd *BusEqCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "bus <index> eq <band>") {
+		return "", nil
 	}
+	return "bus EQ band", []int{cmd.Band.Band}
+}
+
+// BusEqABCmdGroup defines the commands for comparing two EQ settings of a bus, stored as A and B.
+type BusEqABCmdGroup struct {
+	Store  BusEqABStoreCmd  `help:"Store the current EQ as setting A, the changes that follow make up setting B." cmd:""`
+	Toggle BusEqABToggleCmd `help:"Switch the EQ between settings A and B."                                     cmd:""`
+}
+
+// BusEqABStoreCmd defines the command for starting an A/B comparison of the EQ of a bus.
+type BusEqABStoreCmd struct{}
+
+// Run executes the BusEqABStoreCmd command, storing the current EQ of the bus as setting A.
+func (cmd *BusEqABStoreCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	snap, err := ctx.Client.Bus.Eq.Snapshot(bus.Index.Index, ctx.Client.EqBandCount("bus"))
+	if err != nil {
+		return fmt.Errorf("failed to read EQ: %w", err)
+	}
+	if err := storeEqAB(fmt.Sprintf("bus %d", bus.Index.Index), snap); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ stored as A, changes from now on make up B\n", bus.Index.Index)
+	return nil
+}
+
+// BusEqABToggleCmd defines the command for switching the EQ of a bus between the settings of its A/B comparison.
+type BusEqABToggleCmd struct{}
+
+// Run executes the BusEqABToggleCmd command, keeping the current EQ as the setting the bus is on before applying the other.
+func (cmd *BusEqABToggleCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	snap, err := ctx.Client.Bus.Eq.Snapshot(bus.Index.Index, ctx.Client.EqBandCount("bus"))
+	if err != nil {
+		return fmt.Errorf("failed to read EQ: %w", err)
+	}
+	active, err := toggleEqAB(fmt.Sprintf("bus %d", bus.Index.Index), snap, func(next xair.EqSnapshot) error {
+		return ctx.Client.Bus.Eq.ApplySnapshot(bus.Index.Index, next)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ switched to %s\n", bus.Index.Index, active)
 	return nil
 }
 
 // BusCompCmdGroup defines the commands related to controlling the compressor of a bus.
 type BusEqOnCmd struct {
-	State *string `arg:"" help:"The EQ on/off state to set (true or false). If not provided, the current EQ state will be returned." optional:"" enum:"true,false"`
+	State *string `arg:"" help:"The EQ on/off state to set (true, false or toggle). If not provided, the current EQ state will be returned." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the BusEqOnCmd command, either retrieving the current EQ on/off state of the bus or setting it based on the provided argument.
@@ -206,10 +470,17 @@ func (cmd *BusEqOnCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Bus.Eq.SetOn(bus.Index.Index, *cmd.State == "true"); err != nil {
+	state, err := resolveToggle(*cmd.State, func() (bool, error) {
+		return ctx.Client.Bus.Eq.On(bus.Index.Index)
+	})
+	if err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d EQ on state set to: %s\n", bus.Index.Index, *cmd.State)
+
+	if err := ctx.Client.Bus.Eq.SetOn(bus.Index.Index, state); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ on state set to: %t\n", bus.Index.Index, state)
 	return nil
 }
 
@@ -232,7 +503,7 @@ func (cmd *BusEqModeCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.Eq.SetMode(bus.Index.Index, *cmd.Mode); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d EQ mode set to: %s\n", bus.Index.Index, *cmd.Mode)
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ mode set to: %s\n", bus.Index.Index, *cmd.Mode)
 	return nil
 }
 
@@ -255,7 +526,27 @@ func (cmd *BusEqBandGainCmd) Run(ctx *context, bus *BusCmdGroup, busEq *BusEqCmd
 	if err := ctx.Client.Bus.Eq.SetGain(bus.Index.Index, busEq.Band.Band, *cmd.Gain); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d EQ band %d gain set to: %.2f dB\n", bus.Index.Index, busEq.Band.Band, *cmd.Gain)
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ band %d gain set to: %.2f dB\n", bus.Index.Index, busEq.Band.Band, *cmd.Gain)
+	return nil
+}
+
+// BusEqBandAuditionCmd defines the command for temporarily boosting a specific EQ band of a bus, so its effect can be heard.
+type BusEqBandAuditionCmd struct {
+	Gain float64       `help:"The gain to hold the EQ band at (in dB)."     default:"12"`
+	Hold time.Duration `help:"How long to hold the gain before restoring." default:"3s"`
+}
+
+// Run executes the BusEqBandAuditionCmd command, restoring the gain of the band when the hold ends or is interrupted.
+func (cmd *BusEqBandAuditionCmd) Run(ctx *context, bus *BusCmdGroup, busEq *BusEqCmdGroup) error {
+	restored, err := auditionBand(
+		func() (float64, error) { return ctx.Client.Bus.Eq.Gain(bus.Index.Index, busEq.Band.Band) },
+		func(gain float64) error { return ctx.Client.Bus.Eq.SetGain(bus.Index.Index, busEq.Band.Band, gain) },
+		cmd.Gain, cmd.Hold,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to audition EQ band: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ band %d gain restored to: %.2f dB\n", bus.Index.Index, busEq.Band.Band, restored)
 	return nil
 }
 
@@ -278,7 +569,7 @@ func (cmd *BusEqBandFreqCmd) Run(ctx *context, bus *BusCmdGroup, busEq *BusEqCmd
 	if err := ctx.Client.Bus.Eq.SetFrequency(bus.Index.Index, busEq.Band.Band, *cmd.Freq); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d EQ band %d frequency set to: %.2f Hz\n", bus.Index.Index, busEq.Band.Band, *cmd.Freq)
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ band %d frequency set to: %.2f Hz\n", bus.Index.Index, busEq.Band.Band, *cmd.Freq)
 	return nil
 }
 
@@ -301,7 +592,7 @@ func (cmd *BusEqBandQCmd) Run(ctx *context, bus *BusCmdGroup, busEq *BusEqCmdGro
 	if err := ctx.Client.Bus.Eq.SetQ(bus.Index.Index, busEq.Band.Band, *cmd.Q); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d EQ band %d Q factor set to: %.2f\n", bus.Index.Index, busEq.Band.Band, *cmd.Q)
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ band %d Q factor set to: %.2f\n", bus.Index.Index, busEq.Band.Band, *cmd.Q)
 	return nil
 }
 
@@ -324,7 +615,7 @@ func (cmd *BusEqBandTypeCmd) Run(ctx *context, bus *BusCmdGroup, busEq *BusEqCmd
 	if err := ctx.Client.Bus.Eq.SetType(bus.Index.Index, busEq.Band.Band, *cmd.Type); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d EQ band %d type set to: %s\n", bus.Index.Index, busEq.Band.Band, *cmd.Type)
+	fmt.Fprintf(ctx.Confirm, "Bus %d EQ band %d type set to: %s\n", bus.Index.Index, busEq.Band.Band, *cmd.Type)
 	return nil
 }
 
@@ -339,11 +630,22 @@ type BusCompCmdGroup struct {
 	Attack    BusCompAttackCmd    `help:"Get or set the compressor attack time of the bus (in ms)."  cmd:"attack"`
 	Hold      BusCompHoldCmd      `help:"Get or set the compressor hold time of the bus (in ms)."    cmd:"hold"`
 	Release   BusCompReleaseCmd   `help:"Get or set the compressor release time of the bus (in ms)." cmd:"release"`
+	Set       BusCompSetCmd       `help:"Set several compressor parameters of the bus at once."      cmd:""`
+}
+
+// BusCompSetCmd defines the command for setting several compressor parameters of the bus in one go, leaving any omitted parameters untouched.
+type BusCompSetCmd struct {
+	CompSetFlags `embed:""`
+}
+
+// Run executes the BusCompSetCmd command, applying all provided compressor parameters to the bus.
+func (cmd *BusCompSetCmd) Run(ctx *context, bus *BusCmdGroup) error {
+	return cmd.apply(ctx.Confirm, ctx.Client.Bus.Comp, bus.Index.Index, fmt.Sprintf("Bus %d", bus.Index.Index))
 }
 
 // BusCompOnCmd defines the command for getting or setting the compressor on/off state of a bus.
 type BusCompOnCmd struct {
-	State *string `arg:"" help:"The compressor on/off state to set (true or false). If not provided, the current compressor state will be returned." optional:"" enum:"true,false"`
+	State *string `arg:"" help:"The compressor on/off state to set (true, false or toggle). If not provided, the current compressor state will be returned." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the BusCompOnCmd command, either retrieving the current compressor on/off state of the bus or setting it based on the provided argument.
@@ -357,10 +659,17 @@ func (cmd *BusCompOnCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Bus.Comp.SetOn(bus.Index.Index, *cmd.State == "true"); err != nil {
+	state, err := resolveToggle(*cmd.State, func() (bool, error) {
+		return ctx.Client.Bus.Comp.On(bus.Index.Index)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Client.Bus.Comp.SetOn(bus.Index.Index, state); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor on state set to: %s\n", bus.Index.Index, *cmd.State)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor on state set to: %t\n", bus.Index.Index, state)
 	return nil
 }
 
@@ -383,13 +692,13 @@ func (cmd *BusCompModeCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.Comp.SetMode(bus.Index.Index, *cmd.Mode); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor mode set to: %s\n", bus.Index.Index, *cmd.Mode)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor mode set to: %s\n", bus.Index.Index, *cmd.Mode)
 	return nil
 }
 
 // BusCompThresholdCmd defines the command for getting or setting the compressor threshold of a bus.
 type BusCompThresholdCmd struct {
-	Threshold *float64 `arg:"" help:"The compressor threshold to set (in dB). If not provided, the current compressor threshold will be returned." optional:""`
+	Threshold *relativeFloat `arg:"" help:"The compressor threshold to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current compressor threshold will be returned." optional:""`
 }
 
 // Run executes the BusCompThresholdCmd command, either retrieving the current compressor threshold of the bus or setting it based on the provided argument.
@@ -403,16 +712,24 @@ func (cmd *BusCompThresholdCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Bus.Comp.SetThreshold(bus.Index.Index, *cmd.Threshold); err != nil {
+	threshold, err := cmd.Threshold.resolve(func() (float64, error) {
+		return ctx.Client.Bus.Comp.Threshold(bus.Index.Index)
+	}, minCompThreshold, maxCompThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to get current compressor threshold: %w", err)
+	}
+
+	if err := ctx.Client.Bus.Comp.SetThreshold(bus.Index.Index, threshold); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor threshold set to: %.2f dB\n", bus.Index.Index, *cmd.Threshold)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor threshold set to: %.2f dB%s\n", bus.Index.Index, threshold, cmd.Threshold.delta("dB"))
 	return nil
 }
 
 // BusCompRatioCmd defines the command for getting or setting the compressor ratio of a bus.
 type BusCompRatioCmd struct {
 	Ratio *float64 `arg:"" help:"The compressor ratio to set. If not provided, the current compressor ratio will be returned." optional:""`
+	Exact bool     `       help:"Reject a ratio that is not one of the valid values instead of using the nearest one."`
 }
 
 // Run executes the BusCompRatioCmd command, either retrieving the current compressor ratio of the bus or setting it based on the provided argument.
@@ -426,10 +743,11 @@ func (cmd *BusCompRatioCmd) Run(ctx *context, bus *BusCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Bus.Comp.SetRatio(bus.Index.Index, *cmd.Ratio); err != nil {
+	ratio := ratioArg(*cmd.Ratio, cmd.Exact)
+	if err := ctx.Client.Bus.Comp.SetRatio(bus.Index.Index, ratio); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor ratio set to: %.2f\n", bus.Index.Index, *cmd.Ratio)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor ratio set to: %.2f\n", bus.Index.Index, ratio)
 	return nil
 }
 
@@ -452,7 +770,7 @@ func (cmd *BusCompMixCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.Comp.SetMix(bus.Index.Index, *cmd.Mix); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor mix level set to: %.2f%%\n", bus.Index.Index, *cmd.Mix)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor mix level set to: %.2f%%\n", bus.Index.Index, *cmd.Mix)
 	return nil
 }
 
@@ -475,7 +793,7 @@ func (cmd *BusCompMakeupCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.Comp.SetMakeup(bus.Index.Index, *cmd.Makeup); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor makeup gain set to: %.2f dB\n", bus.Index.Index, *cmd.Makeup)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor makeup gain set to: %.2f dB\n", bus.Index.Index, *cmd.Makeup)
 	return nil
 }
 
@@ -498,7 +816,7 @@ func (cmd *BusCompAttackCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.Comp.SetAttack(bus.Index.Index, *cmd.Attack); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor attack time set to: %.2f ms\n", bus.Index.Index, *cmd.Attack)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor attack time set to: %.2f ms\n", bus.Index.Index, *cmd.Attack)
 	return nil
 }
 
@@ -521,7 +839,7 @@ func (cmd *BusCompHoldCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.Comp.SetHold(bus.Index.Index, *cmd.Hold); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor hold time set to: %.2f ms\n", bus.Index.Index, *cmd.Hold)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor hold time set to: %.2f ms\n", bus.Index.Index, *cmd.Hold)
 	return nil
 }
 
@@ -544,6 +862,6 @@ func (cmd *BusCompReleaseCmd) Run(ctx *context, bus *BusCmdGroup) error {
 	if err := ctx.Client.Bus.Comp.SetRelease(bus.Index.Index, *cmd.Release); err != nil {
 		return err
 	}
-	fmt.Fprintf(ctx.Out, "Bus %d compressor release time set to: %.2f ms\n", bus.Index.Index, *cmd.Release)
+	fmt.Fprintf(ctx.Confirm, "Bus %d compressor release time set to: %.2f ms\n", bus.Index.Index, *cmd.Release)
 	return nil
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+func TestBusSendSetsMatrixLevel(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "bus", "2", "send", "3", "--", "-10"); err != nil {
+		t.Fatalf("bus send failed: %v", err)
+	}
+	flush(t, client)
+	if mixer.Value("/bus/02/mix/03/level") == nil {
+		t.Fatal("/bus/02/mix/03/level was not written")
+	}
+
+	out, err := runCommand(t, client, "bus", "2", "send", "3")
+	if err != nil {
+		t.Fatalf("failed to read the send level: %v", err)
+	}
+	if want := "Bus 2 send level for matrix 3: -10.00 dB"; !strings.Contains(out, want) {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestBusSendRejectsMatrixIndex(t *testing.T) {
+	client, _ := newTestClient(t, "X32")
+	for _, matrix := range []string{"0", "7"} {
+		if _, err := runCommand(t, client, "bus", "2", "send", matrix, "0"); err == nil {
+			t.Errorf("bus send to matrix %s succeeded, want an error", matrix)
+		}
+	}
+}
+
+func TestBusInputsNonzeroJSON(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	for i := 1; i <= client.StripCount(); i++ {
+		mixer.Set(fmt.Sprintf("/ch/%02d/mix/02/level", i), float32(0))
+		mixer.Set(fmt.Sprintf("/ch/%02d/mix/02/on", i), int32(1))
+	}
+	mixer.Set("/ch/04/mix/02/level", float32(0.5))
+	mixer.Set("/ch/02/mix/02/level", float32(0.75))
+
+	out, err := runCommand(t, client, "bus", "2", "inputs", "--nonzero", "--json")
+	if err != nil {
+		t.Fatalf("bus inputs failed: %v", err)
+	}
+	var inputs []xair.SendFrom
+	if err := json.Unmarshal([]byte(out), &inputs); err != nil {
+		t.Fatalf("failed to decode %q: %v", out, err)
+	}
+	if len(inputs) != 2 || inputs[0].Strip != 2 || inputs[1].Strip != 4 {
+		t.Errorf("got inputs %+v, want strips 2 and 4, loudest first", inputs)
+	}
+}
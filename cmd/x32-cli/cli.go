@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"reflect"
 	"runtime/debug"
 	"strings"
 	"time"
@@ -12,6 +15,7 @@ import (
 	"github.com/charmbracelet/log"
 	kongcompletion "github.com/jotaen/kong-completion"
 	"github.com/onyx-and-iris/xair-cli/internal/xair"
+	"github.com/posener/complete"
 )
 
 var version string // Version of the CLI, set at build time.
@@ -28,15 +32,28 @@ func (v VersionFlag) BeforeApply(app *kong.Kong, vars kong.Vars) error { // noli
 }
 
 type context struct {
-	Client *xair.X32Client
-	Out    io.Writer
+	Client   *xair.X32Client
+	Out      io.Writer
+	Confirm  io.Writer // confirmations printed after a change, io.Discard with --quiet
+	Progress io.Writer // progress of fades and dumps, nil when stderr is not a terminal or with --quiet
 }
 
 type Config struct {
-	Host     string        `default:"mixer.local" help:"The host of the X32 device." env:"X32_CLI_HOST"     short:"H"`
-	Port     int           `default:"10023"       help:"The port of the X32 device." env:"X32_CLI_PORT"     short:"P"`
-	Timeout  time.Duration `default:"100ms"       help:"Timeout for OSC operations." env:"X32_CLI_TIMEOUT"  short:"T"`
-	Loglevel string        `default:"warn"        help:"Log level for the CLI."      env:"X32_CLI_LOGLEVEL" short:"L" enum:"debug,info,warn,error,fatal"`
+	Host          string        `default:"mixer.local" help:"The host of the X32 device."                        env:"X32_CLI_HOST"            short:"H"`
+	Port          int           `default:"10023"       help:"The port of the X32 device."                        env:"X32_CLI_PORT"            short:"P"`
+	Timeout       time.Duration `default:"100ms"       help:"Timeout for OSC operations."                        env:"X32_CLI_TIMEOUT"         short:"T"`
+	Loglevel      string        `default:"warn"        help:"Log level for the CLI."                             env:"X32_CLI_LOGLEVEL"        short:"L" enum:"debug,info,warn,error,fatal"`
+	Verbose       bool          `default:"false"       help:"Log OSC traffic to stderr."                         env:"X32_CLI_VERBOSE"         short:"V"`
+	TrackUndo     bool          `default:"false"       help:"Record changes for undo."                           env:"X32_CLI_TRACK_UNDO"`
+	OnlyIfChanged bool          `default:"false"       help:"Skip sets that would not change the current value." env:"X32_CLI_ONLY_IF_CHANGED"`
+	Quiet         bool          `default:"false"       help:"Do not print confirmations of changes."             env:"X32_CLI_QUIET"           short:"q"`
+	RawValues     bool          `default:"false"       help:"Print the raw values read from the mixer."          env:"X32_CLI_RAW"             name:"raw"`
+	Mirror        bool          `default:"false"       help:"Repeat sets on the linked strip or bus."            env:"X32_CLI_MIRROR"`
+	Settle        time.Duration `default:"0s"          help:"Pause after each set, for mixers that drop sets."   env:"X32_CLI_SETTLE"`
+	Profile       string        `                      help:"Connect to the host and port of a saved profile."   env:"X32_CLI_PROFILE"         completion-predictor:"profile"`
+	Save          string        `                      help:"Save the host and port as a named profile."         placeholder:"NAME"`
+	Every         time.Duration `default:"0s"          help:"Run the command again at this interval until interrupted."`
+	Count         int           `default:"0"           help:"Stop after this many runs of a command repeated with --every."`
 }
 
 // CLI is the main struct for the command-line interface.
@@ -50,22 +67,41 @@ type CLI struct {
 
 	Raw      RawCmd           `help:"Send raw OSC messages to the mixer."   cmd:"" group:"Raw"`
 	Main     MainCmdGroup     `help:"Control the Main L/R output"           cmd:"" group:"Main"`
-	Mainmono MainMonoCmdGroup `help:"Control the Main Mono output"          cmd:"" group:"MainMono"`
+	Mainmono MainMonoCmdGroup `help:"Control the mono/center (M/C) bus."    cmd:"" group:"MainMono"`
 	Matrix   MatrixCmdGroup   `help:"Control the matrix outputs."           cmd:"" group:"Matrix"`
 	Strip    StripCmdGroup    `help:"Control the strips."                   cmd:"" group:"Strip"`
 	Bus      BusCmdGroup      `help:"Control the buses."                    cmd:"" group:"Bus"`
 	Headamp  HeadampCmdGroup  `help:"Control input gain and phantom power." cmd:"" group:"Headamp"`
 	Snapshot SnapshotCmdGroup `help:"Save and load mixer states."           cmd:"" group:"Snapshot"`
+	Link     LinkCmdGroup     `help:"Show strip and bus linking."           cmd:"" group:"Link"`
+	Monitor  MonitorCmdGroup  `help:"Control the monitor bus."              cmd:"" group:"Monitor"`
+	Solo     SoloCmdGroup     `help:"Control the solo bus."                 cmd:"" group:"Solo"`
+	Undo     UndoCmd          `help:"Revert the most recent changes."       cmd:"" group:"Undo"`
+	Fade     FadeCmd          `help:"Run several fades at the same time."   cmd:"" group:"Fade"`
+	Dump     DumpCmd          `help:"Print the mixer state as JSON."        cmd:"" group:"Dump"`
+	Sync     SyncCmd          `help:"Hold the mixer to a dumped state."     cmd:"" group:"Sync"`
+	Ping     PingCmd          `help:"Check that the mixer replies."         cmd:"" group:"Ping"`
+	Preset   PresetCmdGroup   `help:"Save and apply strip presets."         cmd:"" group:"Preset"`
+	Profiles ConfigCmdGroup   `help:"Manage saved connection profiles."     cmd:"" group:"Config" name:"config"`
 }
 
 func main() {
 	var cli CLI
-	kongcompletion.Register(kong.Must(&cli))
-	ctx := kong.Parse(
+	kongcompletion.Register(
+		kong.Must(
+			&cli,
+			kong.TypeMapper(reflect.TypeOf(relativeFloat{}), relativeFloatMapper()),
+			kong.TypeMapper(reflect.TypeOf(float64(0)), unitFloatMapper()),
+		),
+		kongcompletion.WithPredictor("profile", complete.PredictFunc(predictProfiles)),
+	)
+	parser := kong.Must(
 		&cli,
 		kong.Name("x32-cli"),
 		kong.Description("A CLI to control Behringer X32 mixers."),
 		kong.UsageOnError(),
+		kong.TypeMapper(reflect.TypeOf(relativeFloat{}), relativeFloatMapper()),
+		kong.TypeMapper(reflect.TypeOf(float64(0)), unitFloatMapper()),
 		kong.ConfigureHelp(kong.HelpOptions{
 			Compact: true,
 		}),
@@ -82,10 +118,32 @@ func main() {
 			}(),
 		},
 	)
+	ctx, err := parser.Parse(negativeArgs(parser.Model, os.Args[1:]))
+	if err != nil {
+		parser.FatalIfErrorf(exitError{err, exitUsage})
+	}
 
 	ctx.FatalIfErrorf(run(ctx, cli.Config))
 }
 
+// Exit codes, so scripts can tell a bad command line or an unreachable mixer from a failed command.
+// Errors without an exit code attached exit with exitFailure.
+const (
+	exitFailure    = 1
+	exitUsage      = 2
+	exitConnection = 3
+)
+
+// exitError attaches an exit code to an error, kong's FatalIfErrorf exits with it.
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e exitError) Error() string { return e.err.Error() }
+func (e exitError) Unwrap() error { return e.err }
+func (e exitError) ExitCode() int { return e.code }
+
 // run is the main entry point for the CLI.
 // It connects to the X32 device, retrieves mixer info, and then runs the command.
 func run(ctx *kong.Context, config Config) error {
@@ -95,33 +153,189 @@ func run(ctx *kong.Context, config Config) error {
 	}
 	log.SetLevel(loglevel)
 
-	client, err := connect(config)
+	// examples only prints text, it must work without a mixer on the network.
+	if _, ok := ctx.Selected().Target.Addr().Interface().(*ExamplesCmd); ok {
+		return ctx.Run()
+	}
+
+	confirm := io.Writer(os.Stdout)
+	if config.Quiet {
+		confirm = io.Discard
+	}
+
+	// config only edits the config file, it must work without a mixer on the network.
+	if strings.HasPrefix(ctx.Command(), "config") {
+		ctx.Bind(&context{Out: os.Stdout, Confirm: confirm})
+		return ctx.Run()
+	}
+
+	// copying between mixers connects to the addresses given to the command instead.
+	if cmd, ok := ctx.Selected().Target.Addr().Interface().(mixerPairCmd); ok {
+		return runMixerPair(ctx, config, cmd, confirm)
+	}
+
+	if config.Every < 0 {
+		return exitError{fmt.Errorf("--every must not be negative"), exitUsage}
+	}
+	if config.Count != 0 && config.Every == 0 {
+		return exitError{fmt.Errorf("--count requires --every"), exitUsage}
+	}
+
+	// The default profile only applies when no address was given at all.
+	if config.Profile == "" && !addressGiven(ctx, "X32_CLI") {
+		if config.Profile, err = defaultProfile(); err != nil {
+			return err
+		}
+	}
+	if config.Profile != "" {
+		p, err := lookupProfile(config.Profile)
+		if err != nil {
+			return exitError{err, exitUsage}
+		}
+		config.Host, config.Port = p.Host, p.Port
+	}
+
+	// undo replays previous values, recording them again would make it revert itself.
+	if strings.HasPrefix(ctx.Command(), "undo") {
+		config.TrackUndo = false
+	}
+	undo, err := openUndoLog(config)
+	if err != nil {
+		return err
+	}
+
+	client, err := connect(config, undo)
 	if err != nil {
-		return fmt.Errorf("failed to connect to X32 device: %w", err)
+		return exitError{fmt.Errorf("failed to connect to X32 device: %w", err), exitConnection}
 	}
 	defer client.Close()
 
 	client.StartListening()
 	resp, err := client.RequestInfo()
 	if err != nil {
-		return err
+		return exitError{fmt.Errorf("no reply from X32 device at %s: %w", config.Host, err), exitConnection}
 	}
 	log.Infof("Received mixer info: %+v", resp)
 
+	if err := validateIndexes(ctx, &client.Client); err != nil {
+		return exitError{err, exitUsage}
+	}
+
+	if config.Save != "" {
+		if err := saveProfile(config.Save, profile{Host: config.Host, Port: config.Port}); err != nil {
+			return err
+		}
+		fmt.Fprintf(confirm, "Profile %q saved as %s:%d\n", config.Save, config.Host, config.Port)
+	}
+
 	ctx.Bind(&context{
-		Client: client,
-		Out:    os.Stdout,
+		Client:   client,
+		Out:      os.Stdout,
+		Confirm:  confirm,
+		Progress: progressWriter(config.Quiet),
 	})
 
-	return ctx.Run()
+	// Each run is one entry in the undo log, however many sets it made.
+	runOnce := func() error {
+		defer undo.commit(client.Addr())
+		return ctx.Run()
+	}
+	if config.Every > 0 {
+		return withExitCode(repeat(runOnce, config.Every, config.Count))
+	}
+	return withExitCode(runOnce())
+}
+
+// withExitCode attaches exitConnection to errors caused by the mixer not replying or not being reachable.
+func withExitCode(err error) error {
+	var exit exitError
+	if err == nil || errors.As(err, &exit) {
+		return err
+	}
+	if errors.Is(err, xair.ErrTimeout) || errors.Is(err, xair.ErrNotConnected) {
+		return exitError{err, exitConnection}
+	}
+	return err
+}
+
+// addressGiven reports whether the host or port was set on the command line or in the environment.
+func addressGiven(ctx *kong.Context, envPrefix string) bool {
+	for _, p := range ctx.Path {
+		if p.Flag != nil && (p.Flag.Name == "host" || p.Flag.Name == "port") {
+			return true
+		}
+	}
+	return os.Getenv(envPrefix+"_HOST") != "" || os.Getenv(envPrefix+"_PORT") != ""
+}
+
+// repeat calls run every interval until it has run count times, it fails or the user interrupts it.
+// A count of 0 repeats until interrupted.
+func repeat(run func() error, every time.Duration, count int) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for n := 1; ; n++ {
+		if err := run(); err != nil {
+			return err
+		}
+		if count > 0 && n >= count {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-interrupt:
+			log.Infof("Interrupted after %d runs", n)
+			return nil
+		}
+	}
+}
+
+// openUndoLog returns the undo log when --track-undo is set, and nil otherwise.
+func openUndoLog(config Config) (*undoLog, error) {
+	if !config.TrackUndo {
+		return nil, nil
+	}
+	return newUndoLog()
 }
 
 // connect creates a new X32 client based on the provided configuration.
-func connect(config Config) (*xair.X32Client, error) {
+func connect(config Config, undo *undoLog) (*xair.X32Client, error) {
+	opts := []xair.EngineOption{xair.WithTimeout(config.Timeout)}
+	if config.Verbose {
+		opts = append(opts, xair.WithVerbose(os.Stderr))
+	}
+	if undo != nil {
+		opts = append(opts, xair.WithChangeTracking(undo.record))
+	}
+	if config.OnlyIfChanged {
+		opts = append(opts, xair.WithSkipUnchanged(func(address string) {
+			if !config.Quiet {
+				fmt.Fprintf(os.Stdout, "%s unchanged, skipping set\n", address)
+			}
+		}))
+	}
+
+	if config.Mirror {
+		opts = append(opts, xair.WithLinkMirroring())
+	}
+	if config.Settle > 0 {
+		opts = append(opts, xair.WithSettle(config.Settle))
+	}
+	if config.RawValues {
+		opts = append(opts, xair.WithRawValues(func(address string, args []any) {
+			fmt.Fprintf(os.Stdout, "%s raw value: %v\n", address, args)
+		}))
+	}
+
 	client, err := xair.NewX32Client(
 		config.Host,
 		config.Port,
-		xair.WithTimeout(config.Timeout),
+		opts...,
 	)
 	if err != nil {
 		return nil, err
@@ -129,3 +343,30 @@ func connect(config Config) (*xair.X32Client, error) {
 
 	return client, nil
 }
+
+// indexedCmd is implemented by commands that address numbered strips, buses, matrices or EQ bands.
+// The valid range depends on the mixer model, so indexes can only be checked once the mixer has replied.
+type indexedCmd interface {
+	indexes(command string) (kind string, indexes []int)
+}
+
+// validateIndexes checks the indexes of every command on the selected path against the connected mixer.
+func validateIndexes(ctx *kong.Context, client *xair.Client) error {
+	for _, p := range ctx.Path {
+		if p.Command == nil {
+			continue
+		}
+		cmd, ok := p.Command.Target.Addr().Interface().(indexedCmd)
+		if !ok {
+			continue
+		}
+
+		kind, indexes := cmd.indexes(ctx.Command())
+		for _, index := range indexes {
+			if err := client.ValidateIndex(kind, index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
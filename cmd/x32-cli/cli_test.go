@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+// newTestClient connects a client to a fake mixer reporting model.
+func newTestClient(t *testing.T, model string) (*xair.X32Client, *xairtest.Mixer) {
+	t.Helper()
+	mixer := xairtest.NewMixer(t, model)
+	client, err := xair.NewX32Client(mixer.Host(), mixer.Port(), xair.WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	client.StartListening()
+	if _, err := client.RequestInfo(); err != nil {
+		t.Fatalf("failed to request mixer info: %v", err)
+	}
+	return client, mixer
+}
+
+// runCommand parses args like main does and runs the command against client, without connecting anywhere.
+// It returns everything the command printed.
+func runCommand(t *testing.T, client *xair.X32Client, args ...string) (string, error) {
+	t.Helper()
+	var cli CLI
+	parser := newTestParser(t, &cli)
+	ctx, err := parser.Parse(negativeArgs(parser.Model, args))
+	if err != nil {
+		return "", exitError{err, exitUsage}
+	}
+	if err := validateIndexes(ctx, &client.Client); err != nil {
+		return "", exitError{err, exitUsage}
+	}
+
+	var out bytes.Buffer
+	ctx.Bind(&context{Client: client, Out: &out, Confirm: &out})
+	err = ctx.Run()
+	return out.String(), err
+}
+
+// flush waits until the mixer has handled every message sent before it, it answers in order.
+func flush(t *testing.T, client *xair.X32Client) {
+	t.Helper()
+	if _, err := client.RequestInfo(); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+}
+
+func TestValidateIndexesUsesModelCounts(t *testing.T) {
+	client, _ := newTestClient(t, "X32")
+	tests := []struct {
+		args []string
+		want string // the expected error, empty when the command is valid
+	}{
+		{[]string{"strip", "32", "fader"}, ""},
+		{[]string{"strip", "33", "fader"}, "strip index 33 out of range for X32 (1-32)"},
+		{[]string{"bus", "17", "fader"}, "bus index 17 out of range for X32 (1-16)"},
+		{[]string{"matrix", "7", "fader"}, "matrix index 7 out of range for X32 (1-6)"},
+	}
+	for _, tt := range tests {
+		_, err := runCommand(t, client, tt.args...)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("%v failed: %v", tt.args, err)
+			}
+			continue
+		}
+		var exit exitError
+		if !errors.As(err, &exit) || exit.code != exitUsage || err.Error() != tt.want {
+			t.Errorf("%v returned %v, want usage error %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+func TestExitCodeForUnreachableMixer(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	// a port nothing listens on, the request for the mixer info times out
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	conn.Close()
+
+	var cli CLI
+	parser := newTestParser(t, &cli)
+	ctx, err := parser.Parse([]string{"--host", "127.0.0.1", "--port", strconv.Itoa(port), "--timeout", "50ms", "strip", "1", "mute"})
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	err = run(ctx, cli.Config)
+
+	var exit exitError
+	if !errors.As(err, &exit) || exit.ExitCode() != exitConnection {
+		t.Errorf("got error %v, want exit code %d", err, exitConnection)
+	}
+}
+
+func TestWithExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"timeout", fmt.Errorf("failed to get fader level: %w", xair.ErrTimeout), exitConnection},
+		{"not connected", fmt.Errorf("failed to set fader level: %w", xair.ErrNotConnected), exitConnection},
+		{"usage", exitError{errors.New("bad index"), exitUsage}, exitUsage},
+		{"other failure", errors.New("nothing to undo"), exitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := exitFailure
+			var exit exitError
+			if errors.As(withExitCode(tt.err), &exit) {
+				code = exit.ExitCode()
+			}
+			if code != tt.want {
+				t.Errorf("got exit code %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+// runMain runs args through run like main does, against mixer, and returns what was printed to stdout.
+func runMain(t *testing.T, mixer *xairtest.Mixer, args ...string) string {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	args = append([]string{"--host", mixer.Host(), "--port", strconv.Itoa(mixer.Port()), "--timeout", "1s"}, args...)
+	out, err := runArgs(t, args...)
+	if err != nil {
+		t.Fatalf("%v failed: %v", args, err)
+	}
+	return out
+}
+
+// runArgs runs args through run like main does and returns what was printed to stdout.
+func runArgs(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var cli CLI
+	parser := newTestParser(t, &cli)
+	ctx, err := parser.Parse(negativeArgs(parser.Model, args))
+	if err != nil {
+		return "", exitError{err, exitUsage}
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to capture stdout: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	err = run(ctx, cli.Config)
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out), err
+}
+
+func TestQuietSuppressesConfirmations(t *testing.T) {
+	mixer := xairtest.NewMixer(t, "X32")
+	tests := []struct {
+		args []string
+		want string // the expected output, empty when nothing must be printed
+	}{
+		{[]string{"strip", "1", "mute", "true"}, "Strip 1 mute state set to: true"},
+		{[]string{"--quiet", "strip", "1", "mute", "false"}, ""},
+		{[]string{"--quiet", "strip", "1", "mute"}, "Strip 1 mute state: false"},
+	}
+	for _, tt := range tests {
+		out := runMain(t, mixer, tt.args...)
+		if (tt.want == "" && out != "") || !strings.Contains(out, tt.want) {
+			t.Errorf("%v printed %q, want %q", tt.args, out, tt.want)
+		}
+	}
+}
+
+func TestRepeatStopsAfterCount(t *testing.T) {
+	runs := 0
+	err := repeat(func() error {
+		runs++
+		return nil
+	}, time.Millisecond, 3)
+	if err != nil {
+		t.Fatalf("repeat failed: %v", err)
+	}
+	if runs != 3 {
+		t.Errorf("got %d runs, want 3", runs)
+	}
+}
+
+func TestRepeatStopsOnError(t *testing.T) {
+	runs := 0
+	errStop := errors.New("stop")
+	err := repeat(func() error {
+		runs++
+		if runs == 2 {
+			return errStop
+		}
+		return nil
+	}, time.Millisecond, 5)
+	if !errors.Is(err, errStop) {
+		t.Errorf("got error %v, want %v", err, errStop)
+	}
+	if runs != 2 {
+		t.Errorf("got %d runs, want 2", runs)
+	}
+}
+
+func TestEveryRepeatsTheCommand(t *testing.T) {
+	mixer := xairtest.NewMixer(t, "X32")
+	out := runMain(t, mixer, "--every", "1ms", "--count", "3", "strip", "1", "mute", "true")
+	if got := strings.Count(out, "Strip 1 mute state set to: true"); got != 3 {
+		t.Errorf("got %d confirmations in %q, want 3", got, out)
+	}
+}
+
+func TestRawPrintsRawAndConvertedValues(t *testing.T) {
+	mixer := xairtest.NewMixer(t, "X32")
+	mixer.Set("/ch/01/mix/fader", float32(0.5))
+	out := runMain(t, mixer, "--raw", "strip", "1", "fader")
+	for _, want := range []string{"/ch/01/mix/fader raw value: [0.5]", "Strip 1 fader level: -10.00 dB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    profile
+		wantErr bool
+	}{
+		{"192.168.1.20", profile{"192.168.1.20", 10023}, false},
+		{"192.168.1.20:10000", profile{"192.168.1.20", 10000}, false},
+		{"[::1]:10000", profile{"::1", 10000}, false},
+		{"", profile{}, true},
+		{"192.168.1.20:0", profile{}, true},
+		{"192.168.1.20:port", profile{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseAddress(tt.address)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseAddress(%q) = %v, %v, want %v (error %t)", tt.address, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSaveProfileRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	want := profile{Host: "192.168.1.20", Port: 10000}
+	if err := saveProfile("venue", want); err != nil {
+		t.Fatalf("failed to save profile: %v", err)
+	}
+	got, err := lookupProfile("venue")
+	if err != nil {
+		t.Fatalf("failed to look up profile: %v", err)
+	}
+	if got != want {
+		t.Errorf("got profile %v, want %v", got, want)
+	}
+	if _, err := lookupProfile("studio"); err == nil {
+		t.Error("looking up a missing profile succeeded, want an error")
+	}
+}
+
+func TestSaveFlagPersistsConnection(t *testing.T) {
+	mixer := xairtest.NewMixer(t, "X32")
+	out := runMain(t, mixer, "--save", "venue", "strip", "1", "mute")
+	if want := "Profile \"venue\" saved as"; !strings.Contains(out, want) {
+		t.Errorf("output %q does not contain %q", out, want)
+	}
+
+	got, err := lookupProfile("venue")
+	if err != nil {
+		t.Fatalf("failed to look up profile: %v", err)
+	}
+	if want := (profile{Host: mixer.Host(), Port: mixer.Port()}); got != want {
+		t.Errorf("got profile %v, want %v", got, want)
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	steps := []struct {
+		args    []string
+		want    string // text the output must contain
+		wantErr bool
+	}{
+		{[]string{"config", "list"}, "No saved profiles", false},
+		{[]string{"config", "add", "venue", "--address", "192.168.1.20"}, "Profile \"venue\" saved", false},
+		{[]string{"config", "save-profile", "studio", "--address", "10.0.0.5:10000"}, "Profile \"studio\" saved", false},
+		{[]string{"config", "use", "venue"}, "Default profile set to: venue", false},
+		{[]string{"config", "list"}, "venue   192.168.1.20:", false},
+		{[]string{"config", "use", "club"}, "", true},
+		{[]string{"config", "use"}, "", true},
+		{[]string{"config", "remove", "venue"}, "Profile \"venue\" removed", false},
+		{[]string{"config", "remove", "venue"}, "", true},
+	}
+	for _, step := range steps {
+		out, err := runArgs(t, step.args...)
+		if (err != nil) != step.wantErr {
+			t.Fatalf("%v error = %v, want error %t", step.args, err, step.wantErr)
+		}
+		if !strings.Contains(out, step.want) {
+			t.Errorf("%v printed %q, want it to contain %q", step.args, out, step.want)
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if _, ok := cfg.Profiles["studio"]; !ok || len(cfg.Profiles) != 1 {
+		t.Errorf("got profiles %v, want only studio", cfg.Profiles)
+	}
+	if cfg.Default != "" {
+		t.Errorf("got default %q, want it cleared with the removed profile", cfg.Default)
+	}
+}
+
+func TestDefaultProfileIsUsedWithoutHost(t *testing.T) {
+	mixer := xairtest.NewMixer(t, "X32")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := saveProfile("venue", profile{Host: mixer.Host(), Port: mixer.Port()}); err != nil {
+		t.Fatalf("failed to save profile: %v", err)
+	}
+	if _, err := runArgs(t, "config", "use", "venue"); err != nil {
+		t.Fatalf("config use failed: %v", err)
+	}
+
+	mixer.Set("/ch/01/config/name", "Kick")
+	out, err := runArgs(t, "--timeout", "1s", "strip", "1", "name")
+	if err != nil {
+		t.Fatalf("strip name through the default profile failed: %v", err)
+	}
+	if want := "Kick"; !strings.Contains(out, want) {
+		t.Errorf("output %q does not contain %q", out, want)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+func TestStripCopyBetweenMixers(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	from, to := xairtest.NewMixer(t, "X32"), xairtest.NewMixer(t, "X32")
+	from.Set("/ch/05/config/name", "Vox")
+	from.Set("/ch/05/mix/fader", float32(0.5))
+	from.Set("/ch/05/eq/2/g", float32(0.7))
+	address := func(m *xairtest.Mixer) string {
+		return net.JoinHostPort(m.Host(), strconv.Itoa(m.Port()))
+	}
+
+	if _, err := runArgs(t, "--timeout", "200ms", "strip", "5", "copy", "--from-address", address(from), "--to-address", address(to)); err != nil {
+		t.Fatalf("strip copy failed: %v", err)
+	}
+
+	// a new client reads back after every set of the copy, the mixer answers in order.
+	// The X32 snapshot waits out the timeout on /node, which the fake mixer leaves unanswered.
+	client, err := xair.NewX32Client(to.Host(), to.Port(), xair.WithTimeout(200*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	client.StartListening()
+	got, err := client.Strip.Snapshot(5)
+	if err != nil {
+		t.Fatalf("failed to read the copied strip: %v", err)
+	}
+	if got.Name != "Vox" || got.Fader != -10 {
+		t.Errorf("got strip name %q at %g dB, want %q at -10 dB", got.Name, got.Fader, "Vox")
+	}
+	if eq := to.Value("/ch/05/eq/2/g"); len(eq) != 1 || eq[0] != float32(0.7) {
+		t.Errorf("got /ch/05/eq/2/g %v, want [0.7]", eq)
+	}
+	if name := from.Value("/ch/05/config/name"); len(name) != 1 || name[0] != "Vox" {
+		t.Errorf("got source name %v, want the source mixer untouched", name)
+	}
+	if other := to.Value("/ch/04/config/name"); other != nil {
+		t.Errorf("/ch/04/config/name written %v, want only strip 5 copied", other)
+	}
+}
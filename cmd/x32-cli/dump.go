@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// mixerState is the structure printed by the dump command, sections that were not requested are omitted.
+type mixerState struct {
+	Main     *xair.MainSnapshot   `json:"main,omitempty"`
+	MainMono *xair.MainSnapshot   `json:"mainmono,omitempty"`
+	Strips   []xair.StripSnapshot `json:"strips,omitempty"`
+	Buses    []xair.BusSnapshot   `json:"buses,omitempty"`
+}
+
+// DumpCmd defines the command for printing the state of the mixer as JSON.
+type DumpCmd struct {
+	Sections    []string `help:"The sections to include."                            default:"main,mainmono,strips,buses" enum:"main,mainmono,strips,buses" sep:","`
+	Concurrency int      `help:"Read up to this many strips or buses at once (1-8)." default:"1"`
+	ChangedOnly bool     `help:"Only print values that differ from the built-in defaults. Values without a default, such as fader levels, are left out."`
+}
+
+// maxDumpConcurrency caps --concurrency, more requests in flight risk overrunning the UDP buffer of the mixer.
+const maxDumpConcurrency = 8
+
+// Validate checks that --concurrency is within range.
+func (cmd *DumpCmd) Validate() error {
+	if cmd.Concurrency < 1 || cmd.Concurrency > maxDumpConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", maxDumpConcurrency)
+	}
+	return nil
+}
+
+// Run executes the DumpCmd command, reading every requested section before printing anything.
+// With --concurrency above 1 the strips and buses are read by several workers sharing the client.
+func (cmd *DumpCmd) Run(ctx *context) error {
+	var state mixerState
+	var jobs []func() error
+	for _, section := range cmd.Sections {
+		switch section {
+		case "main":
+			jobs = append(jobs, func() error {
+				snap, err := ctx.Client.Main.Snapshot()
+				if err != nil {
+					return fmt.Errorf("failed to read Main L/R: %w", err)
+				}
+				state.Main = &snap
+				return nil
+			})
+		case "mainmono":
+			jobs = append(jobs, func() error {
+				snap, err := ctx.Client.MainMono.Snapshot()
+				if err != nil {
+					return fmt.Errorf("failed to read Main Mono: %w", err)
+				}
+				state.MainMono = &snap
+				return nil
+			})
+		case "strips":
+			state.Strips = make([]xair.StripSnapshot, ctx.Client.StripCount())
+			for i := range state.Strips {
+				jobs = append(jobs, func() error {
+					snap, err := ctx.Client.Strip.Snapshot(i + 1)
+					if err != nil {
+						return fmt.Errorf("failed to read strip %d: %w", i+1, err)
+					}
+					state.Strips[i] = snap
+					return nil
+				})
+			}
+		case "buses":
+			state.Buses = make([]xair.BusSnapshot, ctx.Client.BusCount())
+			for i := range state.Buses {
+				jobs = append(jobs, func() error {
+					snap, err := ctx.Client.Bus.Snapshot(i + 1)
+					if err != nil {
+						return fmt.Errorf("failed to read bus %d: %w", i+1, err)
+					}
+					state.Buses[i] = snap
+					return nil
+				})
+			}
+		}
+	}
+
+	bar := newProgress(ctx.Progress, "Reading")
+	err := runJobs(jobs, cmd.Concurrency, bar)
+	bar.done()
+	if err != nil {
+		return err
+	}
+
+	var out any = state
+	if cmd.ChangedOnly {
+		if out, err = changedValues(state, ctx.Client.EqBandCount); err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(ctx.Out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// defaultState returns the built-in defaults of a dump as decoded JSON, with strips and buses entries of each
+// and as many EQ bands per target as eqBands reports.
+// Only parameters with a known default are present: names, mute states, the gate on/off state,
+// flat EQ gains as left by an EQ reset and the compressor factory settings used by comp reset.
+func defaultState(strips, buses int, eqBands func(target string) int) (map[string]any, error) {
+	var comp any
+	if err := jsonRoundTrip(xair.DefaultCompSnapshot(), &comp); err != nil {
+		return nil, err
+	}
+	eq := func(bands int) map[string]any {
+		flat := make([]any, bands)
+		for i := range flat {
+			flat[i] = map[string]any{"gain": 0.0}
+		}
+		return map[string]any{"bands": flat}
+	}
+	repeat := func(n int, v map[string]any) []any {
+		list := make([]any, n)
+		for i := range list {
+			list[i] = v
+		}
+		return list
+	}
+
+	main := map[string]any{"mute": false, "eq": eq(eqBands("main")), "comp": comp}
+	return map[string]any{
+		"main":     main,
+		"mainmono": main,
+		"strips": repeat(strips, map[string]any{
+			"name": "", "mute": false, "gate": map[string]any{"on": false}, "eq": eq(eqBands("strip")), "comp": comp,
+		}),
+		"buses": repeat(buses, map[string]any{"name": "", "mute": false, "eq": eq(eqBands("bus")), "comp": comp}),
+	}, nil
+}
+
+// changedValues returns the values of state that differ from defaultState.
+// Lists become objects keyed by 1-based position, so a changed strip keeps its number.
+func changedValues(state mixerState, eqBands func(target string) int) (any, error) {
+	var current any
+	if err := jsonRoundTrip(state, &current); err != nil {
+		return nil, err
+	}
+	defaults, err := defaultState(len(state.Strips), len(state.Buses), eqBands)
+	if err != nil {
+		return nil, err
+	}
+	changed, ok := diffValue(current, defaults)
+	if !ok {
+		return map[string]any{}, nil
+	}
+	return changed, nil
+}
+
+// diffValue returns the parts of value that differ from def and whether there are any.
+// Keys missing from def have no default and are left out.
+func diffValue(value, def any) (any, bool) {
+	switch def := def.(type) {
+	case map[string]any:
+		value, _ := value.(map[string]any)
+		out := map[string]any{}
+		for key, v := range value {
+			if d, ok := def[key]; ok {
+				if changed, ok := diffValue(v, d); ok {
+					out[key] = changed
+				}
+			}
+		}
+		return out, len(out) > 0
+	case []any:
+		value, _ := value.([]any)
+		out := map[string]any{}
+		for i, v := range value {
+			if i >= len(def) {
+				break
+			}
+			if changed, ok := diffValue(v, def[i]); ok {
+				out[strconv.Itoa(i+1)] = changed
+			}
+		}
+		return out, len(out) > 0
+	case float64:
+		// values read back through the mixer's scaling only match the default to the printed precision
+		v, ok := value.(float64)
+		return value, !ok || math.Abs(v-def) >= 0.005
+	default:
+		return value, value != def
+	}
+}
+
+// jsonRoundTrip encodes v as JSON and decodes it into out, turning structs into maps and slices of any.
+func jsonRoundTrip(v any, out any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// runJobs runs jobs on up to workers goroutines and returns the error of the first failed job, in job order.
+// Once a job has failed the jobs not yet started are skipped. Every finished job is reported to bar.
+func runJobs(jobs []func() error, workers int, bar *progress) error {
+	errs := make([]error, len(jobs))
+	next := make(chan int)
+	var failed atomic.Bool
+	var finished atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if failed.Load() {
+					continue
+				}
+				if errs[i] = jobs[i](); errs[i] != nil {
+					failed.Store(true)
+				}
+				bar.update(float64(finished.Add(1)) / float64(len(jobs)))
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+func TestDumpConcurrencyMatchesSequential(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	// X32 snapshots read the config and mix of strips and buses through /node.
+	for i := 1; i <= client.StripCount(); i++ {
+		mixer.SetNode(fmt.Sprintf("ch/%02d/config", i), fmt.Sprintf(`/ch/%02d/config "Strip %d" 1 YE 1`, i, i))
+		mixer.SetNode(fmt.Sprintf("ch/%02d/mix", i), fmt.Sprintf("/ch/%02d/mix ON -%d.0 ON 0 OFF -oo", i, i))
+	}
+	for i := 1; i <= client.BusCount(); i++ {
+		mixer.SetNode(fmt.Sprintf("bus/%02d/config", i), fmt.Sprintf(`/bus/%02d/config "Bus %d" 1 OFF`, i, i))
+		mixer.SetNode(fmt.Sprintf("bus/%02d/mix", i), fmt.Sprintf("/bus/%02d/mix OFF -oo ON 0 OFF -oo", i))
+	}
+
+	dump := func(concurrency int) string {
+		var out bytes.Buffer
+		cmd := DumpCmd{Sections: []string{"main", "strips", "buses"}, Concurrency: concurrency}
+		if err := cmd.Run(&context{Client: client, Out: &out, Confirm: io.Discard}); err != nil {
+			t.Fatalf("dump with concurrency %d failed: %v", concurrency, err)
+		}
+		return out.String()
+	}
+
+	sequential := dump(1)
+	if !strings.Contains(sequential, `"Strip 32"`) || !strings.Contains(sequential, `"Bus 16"`) {
+		t.Fatalf("dump is missing the names set on the mixer:\n%s", sequential)
+	}
+	if parallel := dump(maxDumpConcurrency); parallel != sequential {
+		t.Errorf("dump with concurrency %d differs from the sequential dump\nsequential:\n%s\nparallel:\n%s",
+			maxDumpConcurrency, sequential, parallel)
+	}
+}
+
+func TestDumpChangedOnly(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	// bring every strip to the built-in defaults, the fake mixer starts with mid-scale compressors.
+	// Names and mute states are read with /node, which the fake mixer only answers when told to.
+	for i := 1; i <= client.StripCount(); i++ {
+		name := ""
+		if i == 3 {
+			name = "Kick"
+		}
+		mixer.SetNode(fmt.Sprintf("ch/%02d/config", i), fmt.Sprintf(`/ch/%02d/config "%s" 1 OFF 1`, i, name))
+		mixer.SetNode(fmt.Sprintf("ch/%02d/mix", i), fmt.Sprintf("/ch/%02d/mix ON -oo ON +0 OFF -oo", i))
+		if err := client.Strip.Comp.ApplySnapshot(i, xair.DefaultCompSnapshot()); err != nil {
+			t.Fatalf("failed to reset the compressor of strip %d: %v", i, err)
+		}
+		flush(t, client)
+	}
+	mixer.Set("/ch/05/eq/2/g", float32(0.7)) // +6 dB
+	mixer.Set("/ch/07/mix/fader", float32(0.25))
+
+	out, err := runCommand(t, client, "dump", "--sections", "strips", "--changed-only")
+	if err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	var got struct {
+		Strips map[string]struct {
+			Name *string `json:"name"`
+			Eq   *struct {
+				Bands map[string]struct {
+					Gain float64 `json:"gain"`
+				} `json:"bands"`
+			} `json:"eq"`
+		} `json:"strips"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("failed to decode %q: %v", out, err)
+	}
+	if len(got.Strips) != 2 {
+		t.Fatalf("got changes to %d strips, want strips 3 and 5 only:\n%s", len(got.Strips), out)
+	}
+	if s := got.Strips["3"]; s.Name == nil || *s.Name != "Kick" || s.Eq != nil {
+		t.Errorf("got strip 3 changes %s, want only the name Kick", out)
+	}
+	if s := got.Strips["5"]; s.Name != nil || s.Eq == nil || len(s.Eq.Bands) != 1 || math.Abs(s.Eq.Bands["2"].Gain-6) > 0.01 {
+		t.Errorf("got strip 5 changes %s, want only EQ band 2 at +6 dB", out)
+	}
+}
@@ -14,12 +14,14 @@ type HeadampCmdGroup struct {
 		Gain    HeadampGainCmd    `help:"Get or set the gain of the headamp."                cmd:""`
 		Phantom HeadampPhantomCmd `help:"Get or set the phantom power state of the headamp." cmd:""`
 	} `arg:"" help:"Control a specific headamp by index."`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
 }
 
 // HeadampGainCmd defines the command for getting or setting the gain of a headamp, allowing users to specify the gain in dB and an optional duration for a gradual fade when setting the gain.
 type HeadampGainCmd struct {
-	Duration time.Duration `help:"The duration of the fade in/out when setting the gain." default:"5s"`
-	Gain     *float64      `help:"The gain of the headamp in dB."                                      arg:"" optional:""`
+	Duration time.Duration  `help:"The duration of the fade in/out when setting the gain." default:"5s"`
+	Gain     *relativeFloat `help:"The gain of the headamp in dB. Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." arg:"" optional:""`
 }
 
 // Run executes the HeadampGainCmd command, either retrieving the current gain of the headamp or setting it based on the provided argument, with an optional fade duration for smooth transitions.
@@ -38,10 +40,17 @@ func (cmd *HeadampGainCmd) Run(ctx *context, headamp *HeadampCmdGroup) error {
 		return fmt.Errorf("failed to get current headamp gain: %w", err)
 	}
 
-	if err := gradualGainAdjust(ctx, headamp.Index.Index, currentGain, *cmd.Gain, cmd.Duration); err != nil {
+	gain, err := cmd.Gain.resolve(func() (float64, error) {
+		return currentGain, nil
+	}, minGain, maxGain)
+	if err != nil {
+		return err
+	}
+
+	if err := gradualGainAdjust(ctx, headamp.Index.Index, currentGain, gain, cmd.Duration); err != nil {
 		return fmt.Errorf("failed to set headamp gain: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Headamp %d gain set to: %.2f dB\n", headamp.Index.Index, *cmd.Gain)
+	fmt.Fprintf(ctx.Confirm, "Headamp %d gain set to: %.2f dB%s\n", headamp.Index.Index, gain, cmd.Gain.delta("dB"))
 	return nil
 }
 
@@ -125,6 +134,6 @@ func (cmd *HeadampPhantomCmd) Run(ctx *context, headamp *HeadampCmdGroup) error
 	if err := ctx.Client.HeadAmp.SetPhantomPower(headamp.Index.Index, *cmd.State == "true"); err != nil {
 		return fmt.Errorf("failed to set headamp phantom power state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Headamp %d phantom power set to: %s\n", headamp.Index.Index, *cmd.State)
+	fmt.Fprintf(ctx.Confirm, "Headamp %d phantom power set to: %s\n", headamp.Index.Index, *cmd.State)
 	return nil
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LinkCmdGroup defines the commands related to channel and bus linking.
+type LinkCmdGroup struct {
+	Show LinkShowCmd `help:"Show which strip and bus pairs are linked." cmd:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
+}
+
+// LinkShowCmd defines the command for printing the currently linked strip and bus pairs.
+type LinkShowCmd struct{}
+
+// Run executes the LinkShowCmd command, reading the link state of every strip and bus pair.
+func (cmd *LinkShowCmd) Run(ctx *context) error {
+	strips, err := ctx.Client.Link.StripPairs()
+	if err != nil {
+		return fmt.Errorf("failed to get strip links: %w", err)
+	}
+	buses, err := ctx.Client.Link.BusPairs()
+	if err != nil {
+		return fmt.Errorf("failed to get bus links: %w", err)
+	}
+
+	fmt.Fprintf(ctx.Out, "Linked strips: %s\n", formatPairs(strips))
+	fmt.Fprintf(ctx.Out, "Linked buses: %s\n", formatPairs(buses))
+	return nil
+}
+
+// formatPairs renders linked pairs as a comma separated list such as "1-2, 5-6".
+func formatPairs(pairs [][2]int) string {
+	if len(pairs) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, len(pairs))
+	for i, p := range pairs {
+		parts[i] = fmt.Sprintf("%d-%d", p[0], p[1])
+	}
+	return strings.Join(parts, ", ")
+}
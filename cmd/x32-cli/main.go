@@ -2,9 +2,8 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
-
-	"github.com/alecthomas/kong"
 )
 
 // MainCmdGroup defines the command group for controlling the Main L/R output, including commands for mute state, fader level, and fade-in/fade-out times.
@@ -15,13 +14,21 @@ type MainCmdGroup struct {
 	Fadein  MainFadeinCmd  `help:"Fade in the Main L/R output over a specified duration."  cmd:""`
 	Fadeout MainFadeoutCmd `help:"Fade out the Main L/R output over a specified duration." cmd:""`
 
+	Dim      MainDimCmd      `help:"Get or set the dim state of the Main L/R output."       cmd:""`
+	DimLevel MainDimLevelCmd `help:"Get or set the dim attenuation of the Main L/R output." cmd:"dim-level"`
+
 	Eq   MainEqCmdGroup   `help:"Commands for controlling the equalizer settings of the Main L/R output."  cmd:"eq"`
 	Comp MainCompCmdGroup `help:"Commands for controlling the compressor settings of the Main L/R output." cmd:"comp"`
+
+	Reset MainResetCmd `help:"Reset the EQ and compressor of the Main L/R output." cmd:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
 }
 
 // MainMuteCmd defines the command for getting or setting the mute state of the Main L/R output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MainMuteCmd struct {
-	Mute *string `arg:"" help:"The mute state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Mute *string       `arg:"" help:"The mute state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
+	Fade time.Duration `       help:"Ramp the fader around the mute over this duration, to avoid a click."`
 }
 
 // Run executes the MainMuteCmd command, either retrieving the current mute state of the Main L/R output or setting it based on the provided argument.
@@ -35,16 +42,32 @@ func (cmd *MainMuteCmd) Run(ctx *context) error {
 		return nil
 	}
 
-	if err := ctx.Client.Main.SetMute(*cmd.Mute == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Mute, func() (bool, error) {
+		return ctx.Client.Main.Mute()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Main L/R mute state: %w", err)
+	}
+
+	if cmd.Fade > 0 {
+		target := fadeTarget{label: "Main L/R", get: ctx.Client.Main.Fader, set: ctx.Client.Main.SetFader}
+		bar := newProgress(ctx.Progress, "Fading")
+		defer bar.done()
+		err := fadeMute(target, ctx.Client.Main.SetMute, state, cmd.Fade, bar)
+		bar.done()
+		if err != nil {
+			return fmt.Errorf("failed to set Main L/R mute state: %w", err)
+		}
+	} else if err := ctx.Client.Main.SetMute(state); err != nil {
 		return fmt.Errorf("failed to set Main L/R mute state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R mute state set to: %s\n", *cmd.Mute)
+	fmt.Fprintf(ctx.Confirm, "Main L/R mute state set to: %t\n", state)
 	return nil
 }
 
 // MainFaderCmd defines the command for getting or setting the fader level of the Main L/R output, allowing users to specify the desired level in dB.
 type MainFaderCmd struct {
-	Level *float64 `arg:"" help:"The fader level to set. If not provided, the current level will be printed." optional:""`
+	Level *relativeFloat `arg:"" help:"The fader level to set. Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current level will be printed." optional:""`
 }
 
 // Run executes the MainFaderCmd command, either retrieving the current fader level of the Main L/R output or setting it based on the provided argument.
@@ -58,10 +81,15 @@ func (cmd *MainFaderCmd) Run(ctx *context) error {
 		return nil
 	}
 
-	if err := ctx.Client.Main.SetFader(*cmd.Level); err != nil {
+	level, err := cmd.Level.resolve(ctx.Client.Main.Fader, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get Main L/R fader level: %w", err)
+	}
+
+	if err := ctx.Client.Main.SetFader(level); err != nil {
 		return fmt.Errorf("failed to set Main L/R fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R fader level set to: %.2f\n", *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Main L/R fader level set to: %.2f%s\n", level, cmd.Level.delta("dB"))
 	return nil
 }
 
@@ -69,6 +97,7 @@ func (cmd *MainFaderCmd) Run(ctx *context) error {
 type MainFadeinCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-in. (in seconds.)"                                                   default:"5s"`
 	Target   float64       `        help:"The target level for the fade-in. If not provided, the current target level will be printed." default:"0.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the MainFadeinCmd command, either retrieving the current fade-in time of the Main L/R output or setting it based on the provided argument, with an optional target level for the fade-in effect.
@@ -86,16 +115,13 @@ func (cmd *MainFadeinCmd) Run(ctx *context) error {
 		)
 	}
 
-	totalSteps := float64(cmd.Target - currentLevel)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel < cmd.Target {
-		currentLevel++
-		if err := ctx.Client.Main.SetFader(currentLevel); err != nil {
-			return fmt.Errorf("failed to set Main L/R fader level: %w", err)
-		}
-		time.Sleep(stepDuration)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	if err := rampFader(ctx.Client.Main.SetFader, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set Main L/R fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R fade-in completed. Final level: %.2f\n", currentLevel)
+	bar.done()
+	fmt.Fprintf(ctx.Confirm, "Main L/R fade-in completed. Final level: %.2f\n", cmd.Target)
 	return nil
 }
 
@@ -103,6 +129,7 @@ func (cmd *MainFadeinCmd) Run(ctx *context) error {
 type MainFadeoutCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-out. (in seconds.)"                                                   default:"5s"`
 	Target   float64       `        help:"The target level for the fade-out. If not provided, the current target level will be printed." default:"-90.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the MainFadeoutCmd command, either retrieving the current fade-out time of the Main L/R output or setting it based on the provided argument, with an optional target level for the fade-out effect.
@@ -120,23 +147,97 @@ func (cmd *MainFadeoutCmd) Run(ctx *context) error {
 		)
 	}
 
-	totalSteps := float64(currentLevel - cmd.Target)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel > cmd.Target {
-		currentLevel--
-		if err := ctx.Client.Main.SetFader(currentLevel); err != nil {
-			return fmt.Errorf("failed to set Main L/R fader level: %w", err)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	if err := rampFader(ctx.Client.Main.SetFader, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set Main L/R fader level: %w", err)
+	}
+	bar.done()
+	fmt.Fprintf(ctx.Confirm, "Main L/R fade-out completed. Final level: %.2f\n", cmd.Target)
+	return nil
+}
+
+// MainDimCmd defines the command for getting or setting the dim state of the Main L/R output, allowing users to quickly attenuate the output without moving the fader.
+type MainDimCmd struct {
+	Dim *string `arg:"" help:"The dim state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the MainDimCmd command, either retrieving the current dim state of the Main L/R output or setting it based on the provided argument.
+func (cmd *MainDimCmd) Run(ctx *context) error {
+	if cmd.Dim == nil {
+		resp, err := ctx.Client.Main.Dim()
+		if err != nil {
+			return fmt.Errorf("failed to get Main L/R dim state: %w", err)
 		}
-		time.Sleep(stepDuration)
+		fmt.Fprintf(ctx.Out, "Main L/R dim state: %t\n", resp)
+		return nil
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R fade-out completed. Final level: %.2f\n", currentLevel)
+
+	state, err := resolveToggle(*cmd.Dim, func() (bool, error) {
+		return ctx.Client.Main.Dim()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Main L/R dim state: %w", err)
+	}
+
+	if err := ctx.Client.Main.SetDim(state); err != nil {
+		return fmt.Errorf("failed to set Main L/R dim state: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Main L/R dim state set to: %t\n", state)
+	return nil
+}
+
+// MainDimLevelCmd defines the command for getting or setting the attenuation applied to the Main L/R output when dim is engaged, allowing users to specify the desired level in dB.
+type MainDimLevelCmd struct {
+	Level *float64 `arg:"" help:"The dim attenuation to set (-40 to 0 dB). If not provided, the current level will be printed." optional:""`
+}
+
+// Run executes the MainDimLevelCmd command, either retrieving the current dim attenuation of the Main L/R output or setting it based on the provided argument.
+func (cmd *MainDimLevelCmd) Run(ctx *context) error {
+	if cmd.Level == nil {
+		resp, err := ctx.Client.Main.DimLevel()
+		if err != nil {
+			return fmt.Errorf("failed to get Main L/R dim level: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Main L/R dim level: %.2f dB\n", resp)
+		return nil
+	}
+
+	if err := ctx.Client.Main.SetDimLevel(*cmd.Level); err != nil {
+		return fmt.Errorf("failed to set Main L/R dim level: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Main L/R dim level set to: %.2f dB\n", *cmd.Level)
+	return nil
+}
+
+// MainResetCmd defines the command for resetting the Main L/R output, allowing users to restrict the reset to specific sections.
+type MainResetCmd struct {
+	Section []string `help:"The sections to reset." default:"eq,comp" enum:"eq,comp" sep:","`
+}
+
+// Run executes the MainResetCmd command, flattening the EQ and restoring the compressor defaults of the Main L/R output.
+func (cmd *MainResetCmd) Run(ctx *context) error {
+	for _, section := range cmd.Section {
+		switch section {
+		case "eq":
+			if err := ctx.Client.Main.Eq.Flatten(0, ctx.Client.EqBandCount("main")); err != nil {
+				return fmt.Errorf("failed to reset Main L/R EQ: %w", err)
+			}
+		case "comp":
+			if err := ctx.Client.Main.Comp.Reset(0); err != nil {
+				return fmt.Errorf("failed to reset Main L/R compressor: %w", err)
+			}
+		}
+	}
+	fmt.Fprintf(ctx.Confirm, "Main L/R reset: %s\n", strings.Join(cmd.Section, ", "))
 	return nil
 }
 
 // MainEqCmdGroup defines the command group for controlling the equalizer settings of the Main L/R output, including commands for getting or setting the EQ parameters.
 type MainEqCmdGroup struct {
-	On   MainEqOnCmd `help:"Get or set the EQ on/off state of the Main L/R output."               cmd:"on"`
-	Band struct {
+	On    MainEqOnCmd    `help:"Get or set the EQ on/off state of the Main L/R output." cmd:"on"`
+	Reset MainEqResetCmd `help:"Flatten the EQ of the Main L/R output."                 cmd:"reset"`
+	Band  struct {
 		Band int               `arg:"" help:"The EQ band number."`
 		Gain MainEqBandGainCmd `help:"Get or set the gain of the specified EQ band." cmd:"gain"`
 		Freq MainEqBandFreqCmd `help:"Get or set the frequency of the specified EQ band." cmd:"freq"`
@@ -145,17 +246,18 @@ type MainEqCmdGroup struct {
 	} `help:"Commands for controlling individual EQ bands of the Main L/R output."          arg:""`
 }
 
-// Validate checks if the provided EQ band number is within the valid range (1-6) for the Main L/R output.
-func (cmd *MainEqCmdGroup) Validate(ctx kong.Context) error {
-	if cmd.Band.Band < 1 || cmd.Band.Band > 6 {
-		return fmt.Errorf("invalid EQ band number: %d. Valid range is 1-6", cmd.Band.Band)
+// indexes returns the EQ band addressed by the command, see validateIndexes.
+// Commands that do not address a band, such as eq on, leave the band unset and are not checked.
+func (cmd *MainEqCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "main eq <band>") {
+		return "", nil
 	}
-	return nil
+	return "main EQ band", []int{cmd.Band.Band}
 }
 
 // MainEqOnCmd defines the command for getting or setting the EQ on/off state of the Main L/R output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MainEqOnCmd struct {
-	Enable *string `arg:"" help:"The EQ on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"The EQ on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MainEqOnCmd command, either retrieving the current EQ on/off state of the Main L/R output or setting it based on the provided argument.
@@ -169,10 +271,29 @@ func (cmd *MainEqOnCmd) Run(ctx *context, main *MainCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Main.Eq.SetOn(0, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Main.Eq.On(0)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Main L/R EQ on/off state: %w", err)
+	}
+
+	if err := ctx.Client.Main.Eq.SetOn(0, state); err != nil {
 		return fmt.Errorf("failed to set Main L/R EQ on/off state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R EQ on/off state set to: %t\n", *cmd.Enable == "true")
+	fmt.Fprintf(ctx.Confirm, "Main L/R EQ on/off state set to: %t\n", state)
+	return nil
+}
+
+// MainEqResetCmd defines the command for flattening the EQ of the Main L/R output.
+type MainEqResetCmd struct{}
+
+// Run executes the MainEqResetCmd command, setting the gain of every EQ band of the Main L/R output to 0 dB.
+func (cmd *MainEqResetCmd) Run(ctx *context) error {
+	if err := ctx.Client.Main.Eq.Flatten(0, ctx.Client.EqBandCount("main")); err != nil {
+		return fmt.Errorf("failed to reset Main L/R EQ: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Main L/R EQ flattened\n")
 	return nil
 }
 
@@ -195,7 +316,7 @@ func (cmd *MainEqBandGainCmd) Run(ctx *context, main *MainCmdGroup, mainEq *Main
 	if err := ctx.Client.Main.Eq.SetGain(0, mainEq.Band.Band, *cmd.Level); err != nil {
 		return fmt.Errorf("failed to set Main L/R EQ band %d gain: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R EQ band %d gain set to: %.2f dB\n", mainEq.Band.Band, *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Main L/R EQ band %d gain set to: %.2f dB\n", mainEq.Band.Band, *cmd.Level)
 	return nil
 }
 
@@ -218,7 +339,7 @@ func (cmd *MainEqBandFreqCmd) Run(ctx *context, main *MainCmdGroup, mainEq *Main
 	if err := ctx.Client.Main.Eq.SetFrequency(0, mainEq.Band.Band, *cmd.Frequency); err != nil {
 		return fmt.Errorf("failed to set Main L/R EQ band %d frequency: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R EQ band %d frequency set to: %.2f Hz\n", mainEq.Band.Band, *cmd.Frequency)
+	fmt.Fprintf(ctx.Confirm, "Main L/R EQ band %d frequency set to: %.2f Hz\n", mainEq.Band.Band, *cmd.Frequency)
 	return nil
 }
 
@@ -241,13 +362,14 @@ func (cmd *MainEqBandQCmd) Run(ctx *context, main *MainCmdGroup, mainEq *MainEqC
 	if err := ctx.Client.Main.Eq.SetQ(0, mainEq.Band.Band, *cmd.Q); err != nil {
 		return fmt.Errorf("failed to set Main L/R EQ band %d Q factor: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R EQ band %d Q factor set to: %.2f\n", mainEq.Band.Band, *cmd.Q)
+	fmt.Fprintf(ctx.Confirm, "Main L/R EQ band %d Q factor set to: %.2f\n", mainEq.Band.Band, *cmd.Q)
 	return nil
 }
 
-// MainEqBandTypeCmd defines the command for getting or setting the type of a specific EQ band on the Main L/R output, allowing users to specify the desired type as "peaking", "low_shelf", "high_shelf", "low_pass", or "high_pass".
+// MainEqBandTypeCmd defines the command for getting or setting the type of a specific EQ band on the Main L/R output, allowing users to choose from the same EQ types as strips and buses: low cut (lcut), low shelf (lshv), parametric (peq), variable Q (veq), high shelf (hshv), or high cut (hcut).
+// The descriptive names peaking, low_shelf, high_shelf, low_pass and high_pass are still accepted.
 type MainEqBandTypeCmd struct {
-	Type *string `arg:"" help:"The type to set for the specified EQ band. If not provided, the current type will be printed." optional:"" enum:"peaking,low_shelf,high_shelf,low_pass,high_pass"`
+	Type *string `arg:"" help:"The type to set for the specified EQ band. If not provided, the current type will be printed." optional:"" enum:"lcut,lshv,peq,veq,hshv,hcut,peaking,low_shelf,high_shelf,low_pass,high_pass"`
 }
 
 // Run executes the MainEqBandTypeCmd command, either retrieving the current type of a specific EQ band on the Main L/R output or setting it based on the provided argument.
@@ -264,26 +386,37 @@ func (cmd *MainEqBandTypeCmd) Run(ctx *context, main *MainCmdGroup, mainEq *Main
 	if err := ctx.Client.Main.Eq.SetType(0, mainEq.Band.Band, *cmd.Type); err != nil {
 		return fmt.Errorf("failed to set Main L/R EQ band %d type: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R EQ band %d type set to: %s\n", mainEq.Band.Band, *cmd.Type)
+	fmt.Fprintf(ctx.Confirm, "Main L/R EQ band %d type set to: %s\n", mainEq.Band.Band, *cmd.Type)
 	return nil
 }
 
 // MainCompCmdGroup defines the command group for controlling the compressor settings of the Main L/R output, including commands for getting or setting the compressor parameters.
 type MainCompCmdGroup struct {
-	On        MainCompOnCmd        `help:"Get or set the compressor on/off state of the Main L/R output." cmd:"on"`
-	Mode      MainCompModeCmd      `help:"Get or set the compressor mode of the Main L/R output."         cmd:"mode"`
-	Threshold MainCompThresholdCmd `help:"Get or set the compressor threshold of the Main L/R output."    cmd:"threshold"`
-	Ratio     MainCompRatioCmd     `help:"Get or set the compressor ratio of the Main L/R output."        cmd:"ratio"`
-	Mix       MainCompMixCmd       `help:"Get or set the compressor mix level of the Main L/R output."    cmd:"mix"`
-	Makeup    MainCompMakeupCmd    `help:"Get or set the compressor makeup gain of the Main L/R output."  cmd:"makeup"`
-	Attack    MainCompAttackCmd    `help:"Get or set the compressor attack time of the Main L/R output."  cmd:"attack"`
-	Hold      MainCompHoldCmd      `help:"Get or set the compressor hold time of the Main L/R output."    cmd:"hold"`
-	Release   MainCompReleaseCmd   `help:"Get or set the compressor release time of the Main L/R output." cmd:"release"`
+	On        MainCompOnCmd        `help:"Get or set the compressor on/off state of the Main L/R output."    cmd:"on"`
+	Mode      MainCompModeCmd      `help:"Get or set the compressor mode of the Main L/R output."            cmd:"mode"`
+	Threshold MainCompThresholdCmd `help:"Get or set the compressor threshold of the Main L/R output."       cmd:"threshold"`
+	Ratio     MainCompRatioCmd     `help:"Get or set the compressor ratio of the Main L/R output."           cmd:"ratio"`
+	Mix       MainCompMixCmd       `help:"Get or set the compressor mix level of the Main L/R output."       cmd:"mix"`
+	Makeup    MainCompMakeupCmd    `help:"Get or set the compressor makeup gain of the Main L/R output."     cmd:"makeup"`
+	Attack    MainCompAttackCmd    `help:"Get or set the compressor attack time of the Main L/R output."     cmd:"attack"`
+	Hold      MainCompHoldCmd      `help:"Get or set the compressor hold time of the Main L/R output."       cmd:"hold"`
+	Release   MainCompReleaseCmd   `help:"Get or set the compressor release time of the Main L/R output."    cmd:"release"`
+	Set       MainCompSetCmd       `help:"Set several compressor parameters of the Main L/R output at once." cmd:""`
+}
+
+// MainCompSetCmd defines the command for setting several compressor parameters of the Main L/R output in one go, leaving any omitted parameters untouched.
+type MainCompSetCmd struct {
+	CompSetFlags `embed:""`
+}
+
+// Run executes the MainCompSetCmd command, applying all provided compressor parameters to the Main L/R output.
+func (cmd *MainCompSetCmd) Run(ctx *context) error {
+	return cmd.apply(ctx.Confirm, ctx.Client.Main.Comp, 0, "Main L/R")
 }
 
 // MainCompOnCmd defines the command for getting or setting the compressor on/off state of the Main L/R output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MainCompOnCmd struct {
-	Enable *string `arg:"" help:"The compressor on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"The compressor on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MainCompOnCmd command, either retrieving the current compressor on/off state of the Main L/R output or setting it based on the provided argument.
@@ -297,10 +430,17 @@ func (cmd *MainCompOnCmd) Run(ctx *context, main *MainCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Main.Comp.SetOn(0, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Main.Comp.On(0)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Main L/R compressor on/off state: %w", err)
+	}
+
+	if err := ctx.Client.Main.Comp.SetOn(0, state); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor on/off state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor on/off state set to: %t\n", *cmd.Enable == "true")
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor on/off state set to: %t\n", state)
 	return nil
 }
 
@@ -323,13 +463,13 @@ func (cmd *MainCompModeCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.Main.Comp.SetMode(0, *cmd.Mode); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor mode: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor mode set to: %s\n", *cmd.Mode)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor mode set to: %s\n", *cmd.Mode)
 	return nil
 }
 
 // MainCompThresholdCmd defines the command for getting or setting the compressor threshold of the Main L/R output, allowing users to specify the desired threshold in dB.
 type MainCompThresholdCmd struct {
-	Threshold *float64 `arg:"" help:"The compressor threshold to set. If not provided, the current threshold will be printed." optional:""`
+	Threshold *relativeFloat `arg:"" help:"The compressor threshold to set. Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current threshold will be printed." optional:""`
 }
 
 // Run executes the MainCompThresholdCmd command, either retrieving the current compressor threshold of the Main L/R output or setting it based on the provided argument.
@@ -343,16 +483,24 @@ func (cmd *MainCompThresholdCmd) Run(ctx *context, main *MainCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Main.Comp.SetThreshold(0, *cmd.Threshold); err != nil {
+	threshold, err := cmd.Threshold.resolve(func() (float64, error) {
+		return ctx.Client.Main.Comp.Threshold(0)
+	}, minCompThreshold, maxCompThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to get current Main L/R compressor threshold: %w", err)
+	}
+
+	if err := ctx.Client.Main.Comp.SetThreshold(0, threshold); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor threshold: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor threshold set to: %.2f dB\n", *cmd.Threshold)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor threshold set to: %.2f dB%s\n", threshold, cmd.Threshold.delta("dB"))
 	return nil
 }
 
 // MainCompRatioCmd defines the command for getting or setting the compressor ratio of the Main L/R output, allowing users to specify the desired ratio.
 type MainCompRatioCmd struct {
 	Ratio *float64 `arg:"" help:"The compressor ratio to set. If not provided, the current ratio will be printed." optional:""`
+	Exact bool     `       help:"Reject a ratio that is not one of the valid values instead of using the nearest one."`
 }
 
 // Run executes the MainCompRatioCmd command, either retrieving the current compressor ratio of the Main L/R output or setting it based on the provided argument.
@@ -366,10 +514,11 @@ func (cmd *MainCompRatioCmd) Run(ctx *context, main *MainCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Main.Comp.SetRatio(0, *cmd.Ratio); err != nil {
+	ratio := ratioArg(*cmd.Ratio, cmd.Exact)
+	if err := ctx.Client.Main.Comp.SetRatio(0, ratio); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor ratio: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor ratio set to: %.2f\n", *cmd.Ratio)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor ratio set to: %.2f\n", ratio)
 	return nil
 }
 
@@ -392,7 +541,7 @@ func (cmd *MainCompMixCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.Main.Comp.SetMix(0, *cmd.Mix); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor mix level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor mix level set to: %.2f%%\n", *cmd.Mix)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor mix level set to: %.2f%%\n", *cmd.Mix)
 	return nil
 }
 
@@ -415,7 +564,7 @@ func (cmd *MainCompMakeupCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.Main.Comp.SetMakeup(0, *cmd.Makeup); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor makeup gain: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor makeup gain set to: %.2f dB\n", *cmd.Makeup)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor makeup gain set to: %.2f dB\n", *cmd.Makeup)
 	return nil
 }
 
@@ -438,7 +587,7 @@ func (cmd *MainCompAttackCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.Main.Comp.SetAttack(0, *cmd.Attack); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor attack time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor attack time set to: %.2f ms\n", *cmd.Attack)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor attack time set to: %.2f ms\n", *cmd.Attack)
 	return nil
 }
 
@@ -461,7 +610,7 @@ func (cmd *MainCompHoldCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.Main.Comp.SetHold(0, *cmd.Hold); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor hold time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor hold time set to: %.2f ms\n", *cmd.Hold)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor hold time set to: %.2f ms\n", *cmd.Hold)
 	return nil
 }
 
@@ -484,6 +633,6 @@ func (cmd *MainCompReleaseCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.Main.Comp.SetRelease(0, *cmd.Release); err != nil {
 		return fmt.Errorf("failed to set Main L/R compressor release time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main L/R compressor release time set to: %.2f ms\n", *cmd.Release)
+	fmt.Fprintf(ctx.Confirm, "Main L/R compressor release time set to: %.2f ms\n", *cmd.Release)
 	return nil
 }
@@ -2,12 +2,12 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
-
-	"github.com/alecthomas/kong"
 )
 
 // MainMonoCmdGroup defines the command group for controlling the Main Mono output, including commands for mute state, fader level, and fade-in/fade-out times.
+// The Main Mono output is the mono/center (M/C) bus at /main/m, the X32 has no separate center bus. Strips feed it with strip mono.
 type MainMonoCmdGroup struct {
 	Mute MainMonoMuteCmd `help:"Get or set the mute state of the Main Mono output." cmd:""`
 
@@ -17,11 +17,15 @@ type MainMonoCmdGroup struct {
 
 	Eq   MainMonoEqCmdGroup   `help:"Commands for controlling the equalizer settings of the Main Mono output."  cmd:"eq"`
 	Comp MainMonoCompCmdGroup `help:"Commands for controlling the compressor settings of the Main Mono output." cmd:"comp"`
+
+	Reset MainMonoResetCmd `help:"Reset the EQ and compressor of the Main Mono output." cmd:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
 }
 
 // MainMonoMuteCmd defines the command for getting or setting the mute state of the Main Mono output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MainMonoMuteCmd struct {
-	Mute *string `arg:"" help:"The mute state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Mute *string `arg:"" help:"The mute state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MainMonoMuteCmd command, either retrieving the current mute state of the Main Mono output or setting it based on the provided argument.
@@ -35,16 +39,23 @@ func (cmd *MainMonoMuteCmd) Run(ctx *context) error {
 		return nil
 	}
 
-	if err := ctx.Client.MainMono.SetMute(*cmd.Mute == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Mute, func() (bool, error) {
+		return ctx.Client.MainMono.Mute()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Main Mono mute state: %w", err)
+	}
+
+	if err := ctx.Client.MainMono.SetMute(state); err != nil {
 		return fmt.Errorf("failed to set Main Mono mute state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono mute state set to: %s\n", *cmd.Mute)
+	fmt.Fprintf(ctx.Confirm, "Main Mono mute state set to: %t\n", state)
 	return nil
 }
 
 // MainMonoFaderCmd defines the command for getting or setting the fader level of the Main Mono output, allowing users to specify the desired level in dB.
 type MainMonoFaderCmd struct {
-	Level *float64 `arg:"" help:"The fader level to set. If not provided, the current level will be printed." optional:""`
+	Level *relativeFloat `arg:"" help:"The fader level to set. Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current level will be printed." optional:""`
 }
 
 // Run executes the MainMonoFaderCmd command, either retrieving the current fader level of the Main Mono output or setting it based on the provided argument.
@@ -58,10 +69,15 @@ func (cmd *MainMonoFaderCmd) Run(ctx *context) error {
 		return nil
 	}
 
-	if err := ctx.Client.MainMono.SetFader(*cmd.Level); err != nil {
+	level, err := cmd.Level.resolve(ctx.Client.MainMono.Fader, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get Main Mono fader level: %w", err)
+	}
+
+	if err := ctx.Client.MainMono.SetFader(level); err != nil {
 		return fmt.Errorf("failed to set Main Mono fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono fader level set to: %.2f\n", *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Main Mono fader level set to: %.2f%s\n", level, cmd.Level.delta("dB"))
 	return nil
 }
 
@@ -69,6 +85,7 @@ func (cmd *MainMonoFaderCmd) Run(ctx *context) error {
 type MainMonoFadeinCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-in. (in seconds.)"                                                   default:"5s"`
 	Target   float64       `        help:"The target level for the fade-in. If not provided, the current target level will be printed." default:"0.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the MainMonoFadeinCmd command, either retrieving the current fade-in time of the Main Mono output or setting it based on the provided argument, with an optional target level for the fade-in effect.
@@ -86,16 +103,13 @@ func (cmd *MainMonoFadeinCmd) Run(ctx *context) error {
 		)
 	}
 
-	totalSteps := float64(cmd.Target - currentLevel)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel < cmd.Target {
-		currentLevel++
-		if err := ctx.Client.MainMono.SetFader(currentLevel); err != nil {
-			return fmt.Errorf("failed to set Main Mono fader level: %w", err)
-		}
-		time.Sleep(stepDuration)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	if err := rampFader(ctx.Client.MainMono.SetFader, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set Main Mono fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono fade-in completed. Final level: %.2f\n", currentLevel)
+	bar.done()
+	fmt.Fprintf(ctx.Confirm, "Main Mono fade-in completed. Final level: %.2f\n", cmd.Target)
 	return nil
 }
 
@@ -103,6 +117,7 @@ func (cmd *MainMonoFadeinCmd) Run(ctx *context) error {
 type MainMonoFadeoutCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-out. (in seconds.)"                                                   default:"5s"`
 	Target   float64       `        help:"The target level for the fade-out. If not provided, the current target level will be printed." default:"-90.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the MainMonoFadeoutCmd command, either retrieving the current fade-out time of the Main Mono output or setting it based on the provided argument, with an optional target level for the fade-out effect.
@@ -120,23 +135,44 @@ func (cmd *MainMonoFadeoutCmd) Run(ctx *context) error {
 		)
 	}
 
-	totalSteps := float64(currentLevel - cmd.Target)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel > cmd.Target {
-		currentLevel--
-		if err := ctx.Client.MainMono.SetFader(currentLevel); err != nil {
-			return fmt.Errorf("failed to set Main Mono fader level: %w", err)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	if err := rampFader(ctx.Client.MainMono.SetFader, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set Main Mono fader level: %w", err)
+	}
+	bar.done()
+	fmt.Fprintf(ctx.Confirm, "Main Mono fade-out completed. Final level: %.2f\n", cmd.Target)
+	return nil
+}
+
+// MainMonoResetCmd defines the command for resetting the Main Mono output, allowing users to restrict the reset to specific sections.
+type MainMonoResetCmd struct {
+	Section []string `help:"The sections to reset." default:"eq,comp" enum:"eq,comp" sep:","`
+}
+
+// Run executes the MainMonoResetCmd command, flattening the EQ and restoring the compressor defaults of the Main Mono output.
+func (cmd *MainMonoResetCmd) Run(ctx *context) error {
+	for _, section := range cmd.Section {
+		switch section {
+		case "eq":
+			if err := ctx.Client.MainMono.Eq.Flatten(0, ctx.Client.EqBandCount("mainmono")); err != nil {
+				return fmt.Errorf("failed to reset Main Mono EQ: %w", err)
+			}
+		case "comp":
+			if err := ctx.Client.MainMono.Comp.Reset(0); err != nil {
+				return fmt.Errorf("failed to reset Main Mono compressor: %w", err)
+			}
 		}
-		time.Sleep(stepDuration)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono fade-out completed. Final level: %.2f\n", currentLevel)
+	fmt.Fprintf(ctx.Confirm, "Main Mono reset: %s\n", strings.Join(cmd.Section, ", "))
 	return nil
 }
 
 // MainMonoEqCmdGroup defines the command group for controlling the equalizer settings of the Main Mono output, including commands for getting or setting the EQ parameters.
 type MainMonoEqCmdGroup struct {
-	On   MainMonoEqOnCmd `help:"Get or set the EQ on/off state of the Main Mono output."               cmd:"on"`
-	Band struct {
+	On    MainMonoEqOnCmd    `help:"Get or set the EQ on/off state of the Main Mono output." cmd:"on"`
+	Reset MainMonoEqResetCmd `help:"Flatten the EQ of the Main Mono output."                 cmd:"reset"`
+	Band  struct {
 		Band int                   `arg:"" help:"The EQ band number."`
 		Gain MainMonoEqBandGainCmd `help:"Get or set the gain of the specified EQ band." cmd:"gain"`
 		Freq MainMonoEqBandFreqCmd `help:"Get or set the frequency of the specified EQ band." cmd:"freq"`
@@ -145,21 +181,22 @@ type MainMonoEqCmdGroup struct {
 	} `help:"Commands for controlling individual EQ bands of the Main Mono output."          arg:""`
 }
 
-// Validate checks if the provided EQ band number is within the valid range (1-6) for the Main Mono output.
-func (cmd *MainMonoEqCmdGroup) Validate(ctx kong.Context) error {
-	if cmd.Band.Band < 1 || cmd.Band.Band > 6 {
-		return fmt.Errorf("invalid EQ band number: %d. Valid range is 1-6", cmd.Band.Band)
+// indexes returns the EQ band addressed by the command, see validateIndexes.
+// Commands that do not address a band, such as eq on, leave the band unset and are not checked.
+func (cmd *MainMonoEqCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "mainmono eq <band>") {
+		return "", nil
 	}
-	return nil
+	return "mainmono EQ band", []int{cmd.Band.Band}
 }
 
 // MainMonoEqOnCmd defines the command for getting or setting the EQ on/off state of the Main Mono output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MainMonoEqOnCmd struct {
-	Enable *string `arg:"" help:"The EQ on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"The EQ on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MainMonoEqOnCmd command, either retrieving the current EQ on/off state of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoEqOnCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoEqOnCmd) Run(ctx *context) error {
 	if cmd.Enable == nil {
 		resp, err := ctx.Client.MainMono.Eq.On(0)
 		if err != nil {
@@ -169,10 +206,29 @@ func (cmd *MainMonoEqOnCmd) Run(ctx *context, main *MainCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.MainMono.Eq.SetOn(0, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.MainMono.Eq.On(0)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Main Mono EQ on/off state: %w", err)
+	}
+
+	if err := ctx.Client.MainMono.Eq.SetOn(0, state); err != nil {
 		return fmt.Errorf("failed to set Main Mono EQ on/off state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono EQ on/off state set to: %t\n", *cmd.Enable == "true")
+	fmt.Fprintf(ctx.Confirm, "Main Mono EQ on/off state set to: %t\n", state)
+	return nil
+}
+
+// MainMonoEqResetCmd defines the command for flattening the EQ of the Main Mono output.
+type MainMonoEqResetCmd struct{}
+
+// Run executes the MainMonoEqResetCmd command, setting the gain of every EQ band of the Main Mono output to 0 dB.
+func (cmd *MainMonoEqResetCmd) Run(ctx *context) error {
+	if err := ctx.Client.MainMono.Eq.Flatten(0, ctx.Client.EqBandCount("mainmono")); err != nil {
+		return fmt.Errorf("failed to reset Main Mono EQ: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Main Mono EQ flattened\n")
 	return nil
 }
 
@@ -182,7 +238,7 @@ type MainMonoEqBandGainCmd struct {
 }
 
 // Run executes the MainMonoEqBandGainCmd command, either retrieving the current gain of a specific EQ band on the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoEqBandGainCmd) Run(ctx *context, main *MainCmdGroup, mainEq *MainMonoEqCmdGroup) error {
+func (cmd *MainMonoEqBandGainCmd) Run(ctx *context, mainEq *MainMonoEqCmdGroup) error {
 	if cmd.Level == nil {
 		resp, err := ctx.Client.MainMono.Eq.Gain(0, mainEq.Band.Band)
 		if err != nil {
@@ -195,7 +251,7 @@ func (cmd *MainMonoEqBandGainCmd) Run(ctx *context, main *MainCmdGroup, mainEq *
 	if err := ctx.Client.MainMono.Eq.SetGain(0, mainEq.Band.Band, *cmd.Level); err != nil {
 		return fmt.Errorf("failed to set Main Mono EQ band %d gain: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono EQ band %d gain set to: %.2f dB\n", mainEq.Band.Band, *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Main Mono EQ band %d gain set to: %.2f dB\n", mainEq.Band.Band, *cmd.Level)
 	return nil
 }
 
@@ -205,7 +261,7 @@ type MainMonoEqBandFreqCmd struct {
 }
 
 // Run executes the MainMonoEqBandFreqCmd command, either retrieving the current frequency of a specific EQ band on the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoEqBandFreqCmd) Run(ctx *context, main *MainCmdGroup, mainEq *MainMonoEqCmdGroup) error {
+func (cmd *MainMonoEqBandFreqCmd) Run(ctx *context, mainEq *MainMonoEqCmdGroup) error {
 	if cmd.Frequency == nil {
 		resp, err := ctx.Client.MainMono.Eq.Frequency(0, mainEq.Band.Band)
 		if err != nil {
@@ -218,7 +274,7 @@ func (cmd *MainMonoEqBandFreqCmd) Run(ctx *context, main *MainCmdGroup, mainEq *
 	if err := ctx.Client.MainMono.Eq.SetFrequency(0, mainEq.Band.Band, *cmd.Frequency); err != nil {
 		return fmt.Errorf("failed to set Main Mono EQ band %d frequency: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono EQ band %d frequency set to: %.2f Hz\n", mainEq.Band.Band, *cmd.Frequency)
+	fmt.Fprintf(ctx.Confirm, "Main Mono EQ band %d frequency set to: %.2f Hz\n", mainEq.Band.Band, *cmd.Frequency)
 	return nil
 }
 
@@ -228,7 +284,7 @@ type MainMonoEqBandQCmd struct {
 }
 
 // Run executes the MainMonoEqBandQCmd command, either retrieving the current Q factor of a specific EQ band on the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoEqBandQCmd) Run(ctx *context, main *MainCmdGroup, mainEq *MainMonoEqCmdGroup) error {
+func (cmd *MainMonoEqBandQCmd) Run(ctx *context, mainEq *MainMonoEqCmdGroup) error {
 	if cmd.Q == nil {
 		resp, err := ctx.Client.MainMono.Eq.Q(0, mainEq.Band.Band)
 		if err != nil {
@@ -241,17 +297,18 @@ func (cmd *MainMonoEqBandQCmd) Run(ctx *context, main *MainCmdGroup, mainEq *Mai
 	if err := ctx.Client.MainMono.Eq.SetQ(0, mainEq.Band.Band, *cmd.Q); err != nil {
 		return fmt.Errorf("failed to set Main Mono EQ band %d Q factor: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono EQ band %d Q factor set to: %.2f\n", mainEq.Band.Band, *cmd.Q)
+	fmt.Fprintf(ctx.Confirm, "Main Mono EQ band %d Q factor set to: %.2f\n", mainEq.Band.Band, *cmd.Q)
 	return nil
 }
 
-// MainMonoEqBandTypeCmd defines the command for getting or setting the type of a specific EQ band on the Main Mono output, allowing users to specify the desired type as "peaking", "low_shelf", "high_shelf", "low_pass", or "high_pass".
+// MainMonoEqBandTypeCmd defines the command for getting or setting the type of a specific EQ band on the Main Mono output, allowing users to choose from the same EQ types as strips and buses: low cut (lcut), low shelf (lshv), parametric (peq), variable Q (veq), high shelf (hshv), or high cut (hcut).
+// The descriptive names peaking, low_shelf, high_shelf, low_pass and high_pass are still accepted.
 type MainMonoEqBandTypeCmd struct {
-	Type *string `arg:"" help:"The type to set for the specified EQ band. If not provided, the current type will be printed." optional:"" enum:"peaking,low_shelf,high_shelf,low_pass,high_pass"`
+	Type *string `arg:"" help:"The type to set for the specified EQ band. If not provided, the current type will be printed." optional:"" enum:"lcut,lshv,peq,veq,hshv,hcut,peaking,low_shelf,high_shelf,low_pass,high_pass"`
 }
 
 // Run executes the MainMonoEqBandTypeCmd command, either retrieving the current type of a specific EQ band on the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoEqBandTypeCmd) Run(ctx *context, main *MainCmdGroup, mainEq *MainMonoEqCmdGroup) error {
+func (cmd *MainMonoEqBandTypeCmd) Run(ctx *context, mainEq *MainMonoEqCmdGroup) error {
 	if cmd.Type == nil {
 		resp, err := ctx.Client.MainMono.Eq.Type(0, mainEq.Band.Band)
 		if err != nil {
@@ -264,30 +321,41 @@ func (cmd *MainMonoEqBandTypeCmd) Run(ctx *context, main *MainCmdGroup, mainEq *
 	if err := ctx.Client.MainMono.Eq.SetType(0, mainEq.Band.Band, *cmd.Type); err != nil {
 		return fmt.Errorf("failed to set Main Mono EQ band %d type: %w", mainEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono EQ band %d type set to: %s\n", mainEq.Band.Band, *cmd.Type)
+	fmt.Fprintf(ctx.Confirm, "Main Mono EQ band %d type set to: %s\n", mainEq.Band.Band, *cmd.Type)
 	return nil
 }
 
 // MainMonoCompCmdGroup defines the command group for controlling the compressor settings of the Main Mono output, including commands for getting or setting the compressor parameters.
 type MainMonoCompCmdGroup struct {
-	On        MainMonoCompOnCmd        `help:"Get or set the compressor on/off state of the Main Mono output." cmd:"on"`
-	Mode      MainMonoCompModeCmd      `help:"Get or set the compressor mode of the Main Mono output."         cmd:"mode"`
-	Threshold MainMonoCompThresholdCmd `help:"Get or set the compressor threshold of the Main Mono output."    cmd:"threshold"`
-	Ratio     MainMonoCompRatioCmd     `help:"Get or set the compressor ratio of the Main Mono output."        cmd:"ratio"`
-	Mix       MainMonoCompMixCmd       `help:"Get or set the compressor mix level of the Main Mono output."    cmd:"mix"`
-	Makeup    MainMonoCompMakeupCmd    `help:"Get or set the compressor makeup gain of the Main Mono output."  cmd:"makeup"`
-	Attack    MainMonoCompAttackCmd    `help:"Get or set the compressor attack time of the Main Mono output."  cmd:"attack"`
-	Hold      MainMonoCompHoldCmd      `help:"Get or set the compressor hold time of the Main Mono output."    cmd:"hold"`
-	Release   MainMonoCompReleaseCmd   `help:"Get or set the compressor release time of the Main Mono output." cmd:"release"`
+	On        MainMonoCompOnCmd        `help:"Get or set the compressor on/off state of the Main Mono output."    cmd:"on"`
+	Mode      MainMonoCompModeCmd      `help:"Get or set the compressor mode of the Main Mono output."            cmd:"mode"`
+	Threshold MainMonoCompThresholdCmd `help:"Get or set the compressor threshold of the Main Mono output."       cmd:"threshold"`
+	Ratio     MainMonoCompRatioCmd     `help:"Get or set the compressor ratio of the Main Mono output."           cmd:"ratio"`
+	Mix       MainMonoCompMixCmd       `help:"Get or set the compressor mix level of the Main Mono output."       cmd:"mix"`
+	Makeup    MainMonoCompMakeupCmd    `help:"Get or set the compressor makeup gain of the Main Mono output."     cmd:"makeup"`
+	Attack    MainMonoCompAttackCmd    `help:"Get or set the compressor attack time of the Main Mono output."     cmd:"attack"`
+	Hold      MainMonoCompHoldCmd      `help:"Get or set the compressor hold time of the Main Mono output."       cmd:"hold"`
+	Release   MainMonoCompReleaseCmd   `help:"Get or set the compressor release time of the Main Mono output."    cmd:"release"`
+	Set       MainMonoCompSetCmd       `help:"Set several compressor parameters of the Main Mono output at once." cmd:""`
+}
+
+// MainMonoCompSetCmd defines the command for setting several compressor parameters of the Main Mono output in one go, leaving any omitted parameters untouched.
+type MainMonoCompSetCmd struct {
+	CompSetFlags `embed:""`
+}
+
+// Run executes the MainMonoCompSetCmd command, applying all provided compressor parameters to the Main Mono output.
+func (cmd *MainMonoCompSetCmd) Run(ctx *context) error {
+	return cmd.apply(ctx.Confirm, ctx.Client.MainMono.Comp, 0, "Main Mono")
 }
 
 // MainMonoCompOnCmd defines the command for getting or setting the compressor on/off state of the Main Mono output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MainMonoCompOnCmd struct {
-	Enable *string `arg:"" help:"The compressor on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"The compressor on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MainMonoCompOnCmd command, either retrieving the current compressor on/off state of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompOnCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompOnCmd) Run(ctx *context) error {
 	if cmd.Enable == nil {
 		resp, err := ctx.Client.MainMono.Comp.On(0)
 		if err != nil {
@@ -297,10 +365,17 @@ func (cmd *MainMonoCompOnCmd) Run(ctx *context, main *MainCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.MainMono.Comp.SetOn(0, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.MainMono.Comp.On(0)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Main Mono compressor on/off state: %w", err)
+	}
+
+	if err := ctx.Client.MainMono.Comp.SetOn(0, state); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor on/off state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor on/off state set to: %t\n", *cmd.Enable == "true")
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor on/off state set to: %t\n", state)
 	return nil
 }
 
@@ -310,7 +385,7 @@ type MainMonoCompModeCmd struct {
 }
 
 // Run executes the MainMonoCompModeCmd command, either retrieving the current compressor mode of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompModeCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompModeCmd) Run(ctx *context) error {
 	if cmd.Mode == nil {
 		resp, err := ctx.Client.MainMono.Comp.Mode(0)
 		if err != nil {
@@ -323,17 +398,17 @@ func (cmd *MainMonoCompModeCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.MainMono.Comp.SetMode(0, *cmd.Mode); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor mode: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor mode set to: %s\n", *cmd.Mode)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor mode set to: %s\n", *cmd.Mode)
 	return nil
 }
 
 // MainMonoCompThresholdCmd defines the command for getting or setting the compressor threshold of the Main Mono output, allowing users to specify the desired threshold in dB.
 type MainMonoCompThresholdCmd struct {
-	Threshold *float64 `arg:"" help:"The compressor threshold to set. If not provided, the current threshold will be printed." optional:""`
+	Threshold *relativeFloat `arg:"" help:"The compressor threshold to set. Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current threshold will be printed." optional:""`
 }
 
 // Run executes the MainMonoCompThresholdCmd command, either retrieving the current compressor threshold of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompThresholdCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompThresholdCmd) Run(ctx *context) error {
 	if cmd.Threshold == nil {
 		resp, err := ctx.Client.MainMono.Comp.Threshold(0)
 		if err != nil {
@@ -343,20 +418,28 @@ func (cmd *MainMonoCompThresholdCmd) Run(ctx *context, main *MainCmdGroup) error
 		return nil
 	}
 
-	if err := ctx.Client.MainMono.Comp.SetThreshold(0, *cmd.Threshold); err != nil {
+	threshold, err := cmd.Threshold.resolve(func() (float64, error) {
+		return ctx.Client.MainMono.Comp.Threshold(0)
+	}, minCompThreshold, maxCompThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to get current Main Mono compressor threshold: %w", err)
+	}
+
+	if err := ctx.Client.MainMono.Comp.SetThreshold(0, threshold); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor threshold: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor threshold set to: %.2f dB\n", *cmd.Threshold)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor threshold set to: %.2f dB%s\n", threshold, cmd.Threshold.delta("dB"))
 	return nil
 }
 
 // MainMonoCompRatioCmd defines the command for getting or setting the compressor ratio of the Main Mono output, allowing users to specify the desired ratio.
 type MainMonoCompRatioCmd struct {
 	Ratio *float64 `arg:"" help:"The compressor ratio to set. If not provided, the current ratio will be printed." optional:""`
+	Exact bool     `       help:"Reject a ratio that is not one of the valid values instead of using the nearest one."`
 }
 
 // Run executes the MainMonoCompRatioCmd command, either retrieving the current compressor ratio of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompRatioCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompRatioCmd) Run(ctx *context) error {
 	if cmd.Ratio == nil {
 		resp, err := ctx.Client.MainMono.Comp.Ratio(0)
 		if err != nil {
@@ -366,10 +449,11 @@ func (cmd *MainMonoCompRatioCmd) Run(ctx *context, main *MainCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.MainMono.Comp.SetRatio(0, *cmd.Ratio); err != nil {
+	ratio := ratioArg(*cmd.Ratio, cmd.Exact)
+	if err := ctx.Client.MainMono.Comp.SetRatio(0, ratio); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor ratio: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor ratio set to: %.2f\n", *cmd.Ratio)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor ratio set to: %.2f\n", ratio)
 	return nil
 }
 
@@ -379,7 +463,7 @@ type MainMonoCompMixCmd struct {
 }
 
 // Run executes the MainMonoCompMixCmd command, either retrieving the current compressor mix level of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompMixCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompMixCmd) Run(ctx *context) error {
 	if cmd.Mix == nil {
 		resp, err := ctx.Client.MainMono.Comp.Mix(0)
 		if err != nil {
@@ -392,7 +476,7 @@ func (cmd *MainMonoCompMixCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.MainMono.Comp.SetMix(0, *cmd.Mix); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor mix level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor mix level set to: %.2f%%\n", *cmd.Mix)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor mix level set to: %.2f%%\n", *cmd.Mix)
 	return nil
 }
 
@@ -402,7 +486,7 @@ type MainMonoCompMakeupCmd struct {
 }
 
 // Run executes the MainMonoCompMakeupCmd command, either retrieving the current compressor makeup gain of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompMakeupCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompMakeupCmd) Run(ctx *context) error {
 	if cmd.Makeup == nil {
 		resp, err := ctx.Client.MainMono.Comp.Makeup(0)
 		if err != nil {
@@ -415,7 +499,7 @@ func (cmd *MainMonoCompMakeupCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.MainMono.Comp.SetMakeup(0, *cmd.Makeup); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor makeup gain: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor makeup gain set to: %.2f dB\n", *cmd.Makeup)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor makeup gain set to: %.2f dB\n", *cmd.Makeup)
 	return nil
 }
 
@@ -425,7 +509,7 @@ type MainMonoCompAttackCmd struct {
 }
 
 // Run executes the MainMonoCompAttackCmd command, either retrieving the current compressor attack time of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompAttackCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompAttackCmd) Run(ctx *context) error {
 	if cmd.Attack == nil {
 		resp, err := ctx.Client.MainMono.Comp.Attack(0)
 		if err != nil {
@@ -438,7 +522,7 @@ func (cmd *MainMonoCompAttackCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.MainMono.Comp.SetAttack(0, *cmd.Attack); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor attack time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor attack time set to: %.2f ms\n", *cmd.Attack)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor attack time set to: %.2f ms\n", *cmd.Attack)
 	return nil
 }
 
@@ -448,7 +532,7 @@ type MainMonoCompHoldCmd struct {
 }
 
 // Run executes the MainMonoCompHoldCmd command, either retrieving the current compressor hold time of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompHoldCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompHoldCmd) Run(ctx *context) error {
 	if cmd.Hold == nil {
 		resp, err := ctx.Client.MainMono.Comp.Hold(0)
 		if err != nil {
@@ -461,7 +545,7 @@ func (cmd *MainMonoCompHoldCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.MainMono.Comp.SetHold(0, *cmd.Hold); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor hold time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor hold time set to: %.2f ms\n", *cmd.Hold)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor hold time set to: %.2f ms\n", *cmd.Hold)
 	return nil
 }
 
@@ -471,7 +555,7 @@ type MainMonoCompReleaseCmd struct {
 }
 
 // Run executes the MainMonoCompReleaseCmd command, either retrieving the current compressor release time of the Main Mono output or setting it based on the provided argument.
-func (cmd *MainMonoCompReleaseCmd) Run(ctx *context, main *MainCmdGroup) error {
+func (cmd *MainMonoCompReleaseCmd) Run(ctx *context) error {
 	if cmd.Release == nil {
 		resp, err := ctx.Client.MainMono.Comp.Release(0)
 		if err != nil {
@@ -484,6 +568,6 @@ func (cmd *MainMonoCompReleaseCmd) Run(ctx *context, main *MainCmdGroup) error {
 	if err := ctx.Client.MainMono.Comp.SetRelease(0, *cmd.Release); err != nil {
 		return fmt.Errorf("failed to set Main Mono compressor release time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Main Mono compressor release time set to: %.2f ms\n", *cmd.Release)
+	fmt.Fprintf(ctx.Confirm, "Main Mono compressor release time set to: %.2f ms\n", *cmd.Release)
 	return nil
 }
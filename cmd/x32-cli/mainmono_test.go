@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMainMonoEqBandTargetsMonoOutput(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "mainmono", "eq", "3", "gain", "--", "-6"); err != nil {
+		t.Fatalf("mainmono eq gain failed: %v", err)
+	}
+	if _, err := runCommand(t, client, "mainmono", "comp", "threshold", "--", "-20"); err != nil {
+		t.Fatalf("mainmono comp threshold failed: %v", err)
+	}
+	flush(t, client)
+
+	for address, written := range map[string]bool{
+		"/main/m/eq/3/g":   true,
+		"/main/m/dyn/thr":  true,
+		"/main/st/eq/3/g":  false,
+		"/main/st/dyn/thr": false,
+		"/main/m/eq/0/g":   false,
+	} {
+		if got := mixer.Value(address) != nil; got != written {
+			t.Errorf("%s written: %t, want %t", address, got, written)
+		}
+	}
+}
+
+func TestMainMonoFaderAndMuteTargetMonoBus(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "mainmono", "fader", "--", "-10"); err != nil {
+		t.Fatalf("mainmono fader failed: %v", err)
+	}
+	if _, err := runCommand(t, client, "mainmono", "mute", "true"); err != nil {
+		t.Fatalf("mainmono mute failed: %v", err)
+	}
+	flush(t, client)
+
+	if got := mixer.Value("/main/m/mix/fader"); len(got) != 1 || got[0] != float32(0.5) {
+		t.Errorf("got /main/m/mix/fader %v, want [0.5]", got)
+	}
+	if got := mixer.Value("/main/m/mix/on"); len(got) != 1 || got[0] != int32(0) {
+		t.Errorf("got /main/m/mix/on %v, want [0]", got)
+	}
+	for _, address := range []string{"/main/st/mix/fader", "/main/st/mix/on"} {
+		if mixer.Value(address) != nil {
+			t.Errorf("%s was written, want only the Main Mono address", address)
+		}
+	}
+}
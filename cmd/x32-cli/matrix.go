@@ -2,9 +2,8 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
-
-	"github.com/alecthomas/kong"
 )
 
 // MatrixCmdGroup defines the command group for controlling the Matrix outputs, including commands for mute state, fader level, and fade-in/fade-out times.
@@ -12,26 +11,31 @@ type MatrixCmdGroup struct {
 	Index struct {
 		Index int           `arg:"" help:"The index of the Matrix output (1-6)."`
 		Mute  MatrixMuteCmd `help:"Get or set the mute state of the Matrix output." cmd:""`
+		Name  MatrixNameCmd `help:"Get or set the name of the Matrix output."       cmd:""`
 
 		Fader   MatrixFaderCmd   `help:"Get or set the fader level of the Matrix output."      cmd:""`
 		Fadein  MatrixFadeinCmd  `help:"Fade in the Matrix output over a specified duration."  cmd:""`
 		Fadeout MatrixFadeoutCmd `help:"Fade out the Matrix output over a specified duration." cmd:""`
+		Source  MatrixSourceCmd  `help:"Get or set the level of a Matrix input source." cmd:""`
 
 		Eq   MatrixEqCmdGroup   `help:"Commands for controlling the equalizer settings of the Matrix output."  cmd:"eq"`
 		Comp MatrixCompCmdGroup `help:"Commands for controlling the compressor settings of the Matrix output." cmd:"comp"`
 	} `help:"Commands for controlling individual Matrix outputs." arg:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
 }
 
-func (cmd *MatrixCmdGroup) Validate(ctx kong.Context) error {
-	if cmd.Index.Index < 1 || cmd.Index.Index > 6 {
-		return fmt.Errorf("invalid Matrix output index: %d. Valid range is 1-6", cmd.Index.Index)
+// indexes returns the matrix index addressed by the command, see validateIndexes.
+func (cmd *MatrixCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "matrix <index>") {
+		return "", nil
 	}
-	return nil
+	return "matrix", []int{cmd.Index.Index}
 }
 
 // MatrixMuteCmd defines the command for getting or setting the mute state of the Matrix output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MatrixMuteCmd struct {
-	Mute *string `arg:"" help:"The mute state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Mute *string `arg:"" help:"The mute state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MatrixMuteCmd command, either retrieving the current mute state of the Matrix output or setting it based on the provided argument.
@@ -45,16 +49,59 @@ func (cmd *MatrixMuteCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Matrix.SetMute(matrix.Index.Index, *cmd.Mute == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Mute, func() (bool, error) {
+		return ctx.Client.Matrix.Mute(matrix.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Matrix mute state: %w", err)
+	}
+
+	if err := ctx.Client.Matrix.SetMute(matrix.Index.Index, state); err != nil {
 		return fmt.Errorf("failed to set Matrix mute state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix mute state set to: %s\n", *cmd.Mute)
+	fmt.Fprintf(ctx.Confirm, "Matrix mute state set to: %t\n", state)
+	return nil
+}
+
+// MatrixNameCmd defines the command for getting or setting the name of the Matrix output.
+type MatrixNameCmd struct {
+	Name  *string `arg:"" help:"The name to set for the Matrix output. If not provided, the current name will be returned." optional:""`
+	Clear bool    `       help:"Clear the name of the Matrix output."`
+}
+
+// Validate checks that a name and --clear are not given together.
+func (cmd *MatrixNameCmd) Validate() error {
+	if cmd.Clear && cmd.Name != nil {
+		return fmt.Errorf("cannot set a name and --clear at the same time")
+	}
+	return nil
+}
+
+// Run executes the MatrixNameCmd command, either retrieving the current name of the Matrix output or setting it based on the provided argument.
+func (cmd *MatrixNameCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
+	if cmd.Clear {
+		cmd.Name = new(string)
+	}
+
+	if cmd.Name == nil {
+		resp, err := ctx.Client.Matrix.Name(matrix.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get Matrix name: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Matrix %d name: %s\n", matrix.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Matrix.SetName(matrix.Index.Index, *cmd.Name); err != nil {
+		return fmt.Errorf("failed to set Matrix name: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Matrix %d name set to: %s\n", matrix.Index.Index, *cmd.Name)
 	return nil
 }
 
 // MatrixFaderCmd defines the command for getting or setting the fader level of the Matrix output, allowing users to specify the desired level in dB.
 type MatrixFaderCmd struct {
-	Level *float64 `arg:"" help:"The fader level to set. If not provided, the current level will be printed." optional:""`
+	Level *relativeFloat `arg:"" help:"The fader level to set. Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current level will be printed." optional:""`
 }
 
 // Run executes the MatrixFaderCmd command, either retrieving the current fader level of the Matrix output or setting it based on the provided argument.
@@ -68,10 +115,56 @@ func (cmd *MatrixFaderCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Matrix.SetFader(matrix.Index.Index, *cmd.Level); err != nil {
+	level, err := cmd.Level.resolve(func() (float64, error) {
+		return ctx.Client.Matrix.Fader(matrix.Index.Index)
+	}, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get Matrix fader level: %w", err)
+	}
+
+	if err := ctx.Client.Matrix.SetFader(matrix.Index.Index, level); err != nil {
 		return fmt.Errorf("failed to set Matrix fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix fader level set to: %.2f\n", *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Matrix fader level set to: %.2f%s\n", level, cmd.Level.delta("dB"))
+	return nil
+}
+
+// MatrixSourceCmd defines the command for getting or setting the level at which a bus or main output feeds the Matrix output.
+type MatrixSourceCmd struct {
+	Source int            `arg:"" help:"The source to get or set the level for. (1-16 for buses, 17 for Main L/R, 18 for Main Mono)"`
+	Level  *relativeFloat `arg:"" help:"The source level to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current level will be printed." optional:""`
+}
+
+// Validate checks that the provided source is within the valid range (1-18).
+func (cmd *MatrixSourceCmd) Validate() error {
+	if cmd.Source < 1 || cmd.Source > 18 {
+		return fmt.Errorf("matrix source must be between 1 and 18")
+	}
+	return nil
+}
+
+// Run executes the MatrixSourceCmd command, either retrieving the current level of the Matrix input source or setting it based on the provided argument.
+func (cmd *MatrixSourceCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
+	if cmd.Level == nil {
+		resp, err := ctx.Client.Matrix.SourceLevel(matrix.Index.Index, cmd.Source)
+		if err != nil {
+			return fmt.Errorf("failed to get Matrix source level: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Matrix %d source %d level: %.2f dB\n", matrix.Index.Index, cmd.Source, resp)
+		return nil
+	}
+
+	level, err := cmd.Level.resolve(func() (float64, error) {
+		return ctx.Client.Matrix.SourceLevel(matrix.Index.Index, cmd.Source)
+	}, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get Matrix source level: %w", err)
+	}
+
+	if err := ctx.Client.Matrix.SetSourceLevel(matrix.Index.Index, cmd.Source, level); err != nil {
+		return fmt.Errorf("failed to set Matrix source level: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Matrix %d source %d level set to: %.2f dB%s\n", matrix.Index.Index, cmd.Source, level, cmd.Level.delta("dB"))
 	return nil
 }
 
@@ -79,6 +172,7 @@ func (cmd *MatrixFaderCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 type MatrixFadeinCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-in. (in seconds.)"                                                   default:"5s"`
 	Target   float64       `        help:"The target level for the fade-in. If not provided, the current target level will be printed." default:"0.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the MatrixFadeinCmd command, either retrieving the current fade-in time of the Matrix output or setting it based on the provided argument, with an optional target level for the fade-in effect.
@@ -96,16 +190,14 @@ func (cmd *MatrixFadeinCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 		)
 	}
 
-	totalSteps := float64(cmd.Target - currentLevel)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel < cmd.Target {
-		currentLevel++
-		if err := ctx.Client.Matrix.SetFader(matrix.Index.Index, currentLevel); err != nil {
-			return fmt.Errorf("failed to set Matrix fader level: %w", err)
-		}
-		time.Sleep(stepDuration)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	set := func(level float64) error { return ctx.Client.Matrix.SetFader(matrix.Index.Index, level) }
+	if err := rampFader(set, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set Matrix fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix fade-in completed. Final level: %.2f\n", currentLevel)
+	bar.done()
+	fmt.Fprintf(ctx.Confirm, "Matrix fade-in completed. Final level: %.2f\n", cmd.Target)
 	return nil
 }
 
@@ -113,6 +205,7 @@ func (cmd *MatrixFadeinCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 type MatrixFadeoutCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-out. (in seconds.)"                                                   default:"5s"`
 	Target   float64       `        help:"The target level for the fade-out. If not provided, the current target level will be printed." default:"-90.0" arg:""`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the MatrixFadeoutCmd command, either retrieving the current fade-out time of the Matrix output or setting it based on the provided argument, with an optional target level for the fade-out effect.
@@ -130,16 +223,14 @@ func (cmd *MatrixFadeoutCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 		)
 	}
 
-	totalSteps := float64(currentLevel - cmd.Target)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel > cmd.Target {
-		currentLevel--
-		if err := ctx.Client.Matrix.SetFader(matrix.Index.Index, currentLevel); err != nil {
-			return fmt.Errorf("failed to set Matrix fader level: %w", err)
-		}
-		time.Sleep(stepDuration)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	set := func(level float64) error { return ctx.Client.Matrix.SetFader(matrix.Index.Index, level) }
+	if err := rampFader(set, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set Matrix fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix fade-out completed. Final level: %.2f\n", currentLevel)
+	bar.done()
+	fmt.Fprintf(ctx.Confirm, "Matrix fade-out completed. Final level: %.2f\n", cmd.Target)
 	return nil
 }
 
@@ -155,17 +246,18 @@ type MatrixEqCmdGroup struct {
 	} `help:"Commands for controlling individual EQ bands of the Matrix output."          arg:""`
 }
 
-// Validate checks if the provided EQ band number is within the valid range (1-6) for the Matrix output.
-func (cmd *MatrixEqCmdGroup) Validate(ctx kong.Context) error {
-	if cmd.Band.Band < 1 || cmd.Band.Band > 6 {
-		return fmt.Errorf("invalid EQ band number: %d. Valid range is 1-6", cmd.Band.Band)
+// indexes returns the EQ band addressed by the command, see validateIndexes.
+// Commands that do not address a band, such as eq on, leave the band unset and are not checked.
+func (cmd *MatrixEqCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "matrix <index> eq <band>") {
+		return "", nil
 	}
-	return nil
+	return "matrix EQ band", []int{cmd.Band.Band}
 }
 
 // MatrixEqOnCmd defines the command for getting or setting the EQ on/off state of the Matrix output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MatrixEqOnCmd struct {
-	Enable *string `arg:"" help:"The EQ on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"The EQ on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MatrixEqOnCmd command, either retrieving the current EQ on/off state of the Matrix output or setting it based on the provided argument.
@@ -179,10 +271,17 @@ func (cmd *MatrixEqOnCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Matrix.Eq.SetOn(matrix.Index.Index, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Matrix.Eq.On(matrix.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Matrix EQ on/off state: %w", err)
+	}
+
+	if err := ctx.Client.Matrix.Eq.SetOn(matrix.Index.Index, state); err != nil {
 		return fmt.Errorf("failed to set Matrix EQ on/off state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix EQ on/off state set to: %t\n", *cmd.Enable == "true")
+	fmt.Fprintf(ctx.Confirm, "Matrix EQ on/off state set to: %t\n", state)
 	return nil
 }
 
@@ -205,7 +304,7 @@ func (cmd *MatrixEqBandGainCmd) Run(ctx *context, matrix *MatrixCmdGroup, matrix
 	if err := ctx.Client.Matrix.Eq.SetGain(matrix.Index.Index, matrixEq.Band.Band, *cmd.Level); err != nil {
 		return fmt.Errorf("failed to set Matrix EQ band %d gain: %w", matrixEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix EQ band %d gain set to: %.2f dB\n", matrixEq.Band.Band, *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Matrix EQ band %d gain set to: %.2f dB\n", matrixEq.Band.Band, *cmd.Level)
 	return nil
 }
 
@@ -228,7 +327,7 @@ func (cmd *MatrixEqBandFreqCmd) Run(ctx *context, matrix *MatrixCmdGroup, matrix
 	if err := ctx.Client.Matrix.Eq.SetFrequency(matrix.Index.Index, matrixEq.Band.Band, *cmd.Frequency); err != nil {
 		return fmt.Errorf("failed to set Matrix EQ band %d frequency: %w", matrixEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix EQ band %d frequency set to: %.2f Hz\n", matrixEq.Band.Band, *cmd.Frequency)
+	fmt.Fprintf(ctx.Confirm, "Matrix EQ band %d frequency set to: %.2f Hz\n", matrixEq.Band.Band, *cmd.Frequency)
 	return nil
 }
 
@@ -251,13 +350,14 @@ func (cmd *MatrixEqBandQCmd) Run(ctx *context, matrix *MatrixCmdGroup, matrixEq
 	if err := ctx.Client.Matrix.Eq.SetQ(matrix.Index.Index, matrixEq.Band.Band, *cmd.Q); err != nil {
 		return fmt.Errorf("failed to set Matrix EQ band %d Q factor: %w", matrixEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix EQ band %d Q factor set to: %.2f\n", matrixEq.Band.Band, *cmd.Q)
+	fmt.Fprintf(ctx.Confirm, "Matrix EQ band %d Q factor set to: %.2f\n", matrixEq.Band.Band, *cmd.Q)
 	return nil
 }
 
-// MatrixEqBandTypeCmd defines the command for getting or setting the type of a specific EQ band on the Matrix output, allowing users to specify the desired type as "peaking", "low_shelf", "high_shelf", "low_pass", or "high_pass".
+// MatrixEqBandTypeCmd defines the command for getting or setting the type of a specific EQ band on the Matrix output, allowing users to choose from the same EQ types as strips and buses: low cut (lcut), low shelf (lshv), parametric (peq), variable Q (veq), high shelf (hshv), or high cut (hcut).
+// The descriptive names peaking, low_shelf, high_shelf, low_pass and high_pass are still accepted.
 type MatrixEqBandTypeCmd struct {
-	Type *string `arg:"" help:"The type to set for the specified EQ band. If not provided, the current type will be printed." optional:"" enum:"peaking,low_shelf,high_shelf,low_pass,high_pass"`
+	Type *string `arg:"" help:"The type to set for the specified EQ band. If not provided, the current type will be printed." optional:"" enum:"lcut,lshv,peq,veq,hshv,hcut,peaking,low_shelf,high_shelf,low_pass,high_pass"`
 }
 
 // Run executes the MatrixEqBandTypeCmd command, either retrieving the current type of a specific EQ band on the Matrix output or setting it based on the provided argument.
@@ -274,26 +374,37 @@ func (cmd *MatrixEqBandTypeCmd) Run(ctx *context, matrix *MatrixCmdGroup, matrix
 	if err := ctx.Client.Matrix.Eq.SetType(matrix.Index.Index, matrixEq.Band.Band, *cmd.Type); err != nil {
 		return fmt.Errorf("failed to set Matrix EQ band %d type: %w", matrixEq.Band.Band, err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix EQ band %d type set to: %s\n", matrixEq.Band.Band, *cmd.Type)
+	fmt.Fprintf(ctx.Confirm, "Matrix EQ band %d type set to: %s\n", matrixEq.Band.Band, *cmd.Type)
 	return nil
 }
 
 // MatrixCompCmdGroup defines the command group for controlling the compressor settings of the Matrix output, including commands for getting or setting the compressor parameters.
 type MatrixCompCmdGroup struct {
-	On        MatrixCompOnCmd        `help:"Get or set the compressor on/off state of the Matrix output." cmd:"on"`
-	Mode      MatrixCompModeCmd      `help:"Get or set the compressor mode of the Matrix output."         cmd:"mode"`
-	Threshold MatrixCompThresholdCmd `help:"Get or set the compressor threshold of the Matrix output."    cmd:"threshold"`
-	Ratio     MatrixCompRatioCmd     `help:"Get or set the compressor ratio of the Matrix output."        cmd:"ratio"`
-	Mix       MatrixCompMixCmd       `help:"Get or set the compressor mix level of the Matrix output."    cmd:"mix"`
-	Makeup    MatrixCompMakeupCmd    `help:"Get or set the compressor makeup gain of the Matrix output."  cmd:"makeup"`
-	Attack    MatrixCompAttackCmd    `help:"Get or set the compressor attack time of the Matrix output."  cmd:"attack"`
-	Hold      MatrixCompHoldCmd      `help:"Get or set the compressor hold time of the Matrix output."    cmd:"hold"`
-	Release   MatrixCompReleaseCmd   `help:"Get or set the compressor release time of the Matrix output." cmd:"release"`
+	On        MatrixCompOnCmd        `help:"Get or set the compressor on/off state of the Matrix output."    cmd:"on"`
+	Mode      MatrixCompModeCmd      `help:"Get or set the compressor mode of the Matrix output."            cmd:"mode"`
+	Threshold MatrixCompThresholdCmd `help:"Get or set the compressor threshold of the Matrix output."       cmd:"threshold"`
+	Ratio     MatrixCompRatioCmd     `help:"Get or set the compressor ratio of the Matrix output."           cmd:"ratio"`
+	Mix       MatrixCompMixCmd       `help:"Get or set the compressor mix level of the Matrix output."       cmd:"mix"`
+	Makeup    MatrixCompMakeupCmd    `help:"Get or set the compressor makeup gain of the Matrix output."     cmd:"makeup"`
+	Attack    MatrixCompAttackCmd    `help:"Get or set the compressor attack time of the Matrix output."     cmd:"attack"`
+	Hold      MatrixCompHoldCmd      `help:"Get or set the compressor hold time of the Matrix output."       cmd:"hold"`
+	Release   MatrixCompReleaseCmd   `help:"Get or set the compressor release time of the Matrix output."    cmd:"release"`
+	Set       MatrixCompSetCmd       `help:"Set several compressor parameters of the Matrix output at once." cmd:""`
+}
+
+// MatrixCompSetCmd defines the command for setting several compressor parameters of the Matrix output in one go, leaving any omitted parameters untouched.
+type MatrixCompSetCmd struct {
+	CompSetFlags `embed:""`
+}
+
+// Run executes the MatrixCompSetCmd command, applying all provided compressor parameters to the Matrix output.
+func (cmd *MatrixCompSetCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
+	return cmd.apply(ctx.Confirm, ctx.Client.Matrix.Comp, matrix.Index.Index, fmt.Sprintf("Matrix %d", matrix.Index.Index))
 }
 
 // MatrixCompOnCmd defines the command for getting or setting the compressor on/off state of the Matrix output, allowing users to specify the desired state as "true"/"on" or "false"/"off".
 type MatrixCompOnCmd struct {
-	Enable *string `arg:"" help:"The compressor on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"The compressor on/off state to set. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the MatrixCompOnCmd command, either retrieving the current compressor on/off state of the Matrix output or setting it based on the provided argument.
@@ -307,10 +418,17 @@ func (cmd *MatrixCompOnCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Matrix.Comp.SetOn(matrix.Index.Index, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Matrix.Comp.On(matrix.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Matrix compressor on/off state: %w", err)
+	}
+
+	if err := ctx.Client.Matrix.Comp.SetOn(matrix.Index.Index, state); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor on/off state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor on/off state set to: %t\n", *cmd.Enable == "true")
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor on/off state set to: %t\n", state)
 	return nil
 }
 
@@ -333,13 +451,13 @@ func (cmd *MatrixCompModeCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 	if err := ctx.Client.Matrix.Comp.SetMode(matrix.Index.Index, *cmd.Mode); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor mode: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor mode set to: %s\n", *cmd.Mode)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor mode set to: %s\n", *cmd.Mode)
 	return nil
 }
 
 // MatrixCompThresholdCmd defines the command for getting or setting the compressor threshold of the Matrix output, allowing users to specify the desired threshold in dB.
 type MatrixCompThresholdCmd struct {
-	Threshold *float64 `arg:"" help:"The compressor threshold to set. If not provided, the current threshold will be printed." optional:""`
+	Threshold *relativeFloat `arg:"" help:"The compressor threshold to set. Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10. If not provided, the current threshold will be printed." optional:""`
 }
 
 // Run executes the MatrixCompThresholdCmd command, either retrieving the current compressor threshold of the Matrix output or setting it based on the provided argument.
@@ -353,16 +471,24 @@ func (cmd *MatrixCompThresholdCmd) Run(ctx *context, matrix *MatrixCmdGroup) err
 		return nil
 	}
 
-	if err := ctx.Client.Matrix.Comp.SetThreshold(matrix.Index.Index, *cmd.Threshold); err != nil {
+	threshold, err := cmd.Threshold.resolve(func() (float64, error) {
+		return ctx.Client.Matrix.Comp.Threshold(matrix.Index.Index)
+	}, minCompThreshold, maxCompThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to get current Matrix compressor threshold: %w", err)
+	}
+
+	if err := ctx.Client.Matrix.Comp.SetThreshold(matrix.Index.Index, threshold); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor threshold: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor threshold set to: %.2f dB\n", *cmd.Threshold)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor threshold set to: %.2f dB%s\n", threshold, cmd.Threshold.delta("dB"))
 	return nil
 }
 
 // MatrixCompRatioCmd defines the command for getting or setting the compressor ratio of the Matrix output, allowing users to specify the desired ratio.
 type MatrixCompRatioCmd struct {
 	Ratio *float64 `arg:"" help:"The compressor ratio to set. If not provided, the current ratio will be printed." optional:""`
+	Exact bool     `       help:"Reject a ratio that is not one of the valid values instead of using the nearest one."`
 }
 
 // Run executes the MatrixCompRatioCmd command, either retrieving the current compressor ratio of the Matrix output or setting it based on the provided argument.
@@ -376,10 +502,11 @@ func (cmd *MatrixCompRatioCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Matrix.Comp.SetRatio(matrix.Index.Index, *cmd.Ratio); err != nil {
+	ratio := ratioArg(*cmd.Ratio, cmd.Exact)
+	if err := ctx.Client.Matrix.Comp.SetRatio(matrix.Index.Index, ratio); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor ratio: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor ratio set to: %.2f\n", *cmd.Ratio)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor ratio set to: %.2f\n", ratio)
 	return nil
 }
 
@@ -402,7 +529,7 @@ func (cmd *MatrixCompMixCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 	if err := ctx.Client.Matrix.Comp.SetMix(matrix.Index.Index, *cmd.Mix); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor mix level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor mix level set to: %.2f%%\n", *cmd.Mix)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor mix level set to: %.2f%%\n", *cmd.Mix)
 	return nil
 }
 
@@ -425,7 +552,7 @@ func (cmd *MatrixCompMakeupCmd) Run(ctx *context, matrix *MatrixCmdGroup) error
 	if err := ctx.Client.Matrix.Comp.SetMakeup(matrix.Index.Index, *cmd.Makeup); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor makeup gain: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor makeup gain set to: %.2f dB\n", *cmd.Makeup)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor makeup gain set to: %.2f dB\n", *cmd.Makeup)
 	return nil
 }
 
@@ -448,7 +575,7 @@ func (cmd *MatrixCompAttackCmd) Run(ctx *context, matrix *MatrixCmdGroup) error
 	if err := ctx.Client.Matrix.Comp.SetAttack(matrix.Index.Index, *cmd.Attack); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor attack time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor attack time set to: %.2f ms\n", *cmd.Attack)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor attack time set to: %.2f ms\n", *cmd.Attack)
 	return nil
 }
 
@@ -471,7 +598,7 @@ func (cmd *MatrixCompHoldCmd) Run(ctx *context, matrix *MatrixCmdGroup) error {
 	if err := ctx.Client.Matrix.Comp.SetHold(matrix.Index.Index, *cmd.Hold); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor hold time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor hold time set to: %.2f ms\n", *cmd.Hold)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor hold time set to: %.2f ms\n", *cmd.Hold)
 	return nil
 }
 
@@ -494,6 +621,6 @@ func (cmd *MatrixCompReleaseCmd) Run(ctx *context, matrix *MatrixCmdGroup) error
 	if err := ctx.Client.Matrix.Comp.SetRelease(matrix.Index.Index, *cmd.Release); err != nil {
 		return fmt.Errorf("failed to set Matrix compressor release time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Matrix compressor release time set to: %.2f ms\n", *cmd.Release)
+	fmt.Fprintf(ctx.Confirm, "Matrix compressor release time set to: %.2f ms\n", *cmd.Release)
 	return nil
 }
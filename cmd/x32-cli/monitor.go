@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// MonitorCmdGroup defines the commands related to monitoring, the monitor (solo) bus and monitor mixes.
+type MonitorCmdGroup struct {
+	Mono   MonitorMonoCmd   `help:"Get or set whether the monitor bus is summed to mono."              cmd:""`
+	Source MonitorSourceCmd `help:"Get or set the source of the monitor bus."                          cmd:""`
+	Mirror MonitorMirrorCmd `help:"Copy the main mix into the sends of a bus, e.g. for a monitor mix." cmd:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
+}
+
+// MonitorMonoCmd defines the command for getting or setting the mono sum of the monitor bus, used to check mono compatibility.
+type MonitorMonoCmd struct {
+	Enable *string `arg:"" help:"Whether to sum the monitor bus to mono. If not provided, the current state will be printed." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the MonitorMonoCmd command, either retrieving the current mono sum state or setting it based on the provided argument.
+func (cmd *MonitorMonoCmd) Run(ctx *context) error {
+	if cmd.Enable == nil {
+		resp, err := ctx.Client.MonitorMono()
+		if err != nil {
+			return fmt.Errorf("failed to get monitor mono state: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Monitor mono state: %t\n", resp)
+		return nil
+	}
+
+	state, err := resolveToggle(*cmd.Enable, ctx.Client.MonitorMono)
+	if err != nil {
+		return fmt.Errorf("failed to get monitor mono state: %w", err)
+	}
+
+	if err := ctx.Client.SetMonitorMono(state); err != nil {
+		return fmt.Errorf("failed to set monitor mono state: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Monitor mono state set to: %t\n", state)
+	return nil
+}
+
+// MonitorSourceCmd defines the command for getting or setting the source of the monitor (solo) bus, which feeds the phones output.
+type MonitorSourceCmd struct {
+	Source *string `arg:"" help:"The source to set (off, lr, lr+c, lrpfl, lrafl, aux56, aux78). If not provided, the current source will be printed." optional:""`
+}
+
+// Run executes the MonitorSourceCmd command, either retrieving the current monitor source or setting it based on the provided argument.
+func (cmd *MonitorSourceCmd) Run(ctx *context) error {
+	if cmd.Source == nil {
+		resp, err := ctx.Client.MonitorSource()
+		if err != nil {
+			return fmt.Errorf("failed to get monitor source: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Monitor source: %s\n", resp)
+		return nil
+	}
+
+	if err := ctx.Client.SetMonitorSource(*cmd.Source); err != nil {
+		return fmt.Errorf("failed to set monitor source: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Monitor source set to: %s\n", *cmd.Source)
+	return nil
+}
+
+// MonitorMirrorCmd defines the command for building a monitor mix from the main mix.
+// Each strip's send to the bus is set to its fader level plus the offset, muted strips are sent at -inf.
+// Sends to a linked stereo bus pair take the strip's pan as well. The sends must tap the strip before
+// its fader, otherwise the fader would be applied twice, so post-fader sends are refused.
+type MonitorMirrorCmd struct {
+	Bus    int     `arg:""      help:"The bus to copy the main mix into. (1-based indexing)"`
+	Offset float64 `default:"0" help:"Level in dB added to every copied send, e.g. -6 to leave headroom."`
+}
+
+func (cmd *MonitorMirrorCmd) indexes(command string) (string, []int) {
+	return "bus", []int{cmd.Bus}
+}
+
+// Run executes the MonitorMirrorCmd command, reading every strip before writing any send.
+func (cmd *MonitorMirrorCmd) Run(ctx *context) error {
+	stereo, err := ctx.Client.Link.BusLinked(cmd.Bus)
+	if err != nil {
+		return fmt.Errorf("failed to get bus %d link state: %w", cmd.Bus, err)
+	}
+
+	levels := make([]float64, ctx.Client.StripCount())
+	pans := make([]float64, len(levels))
+	for i := range levels {
+		strip := i + 1
+		tap, err := ctx.Client.Strip.SendTap(strip, cmd.Bus)
+		if err != nil {
+			return fmt.Errorf("failed to get strip %d send tap: %w", strip, err)
+		}
+		if tap == "post" || tap == "grp" {
+			return fmt.Errorf("strip %d sends to bus %d after its fader (%s), switch the send to a pre-fader tap so the fader is not applied twice", strip, cmd.Bus, tap)
+		}
+		fader, err := ctx.Client.Strip.Fader(strip)
+		if err != nil {
+			return fmt.Errorf("failed to get strip %d fader level: %w", strip, err)
+		}
+		muted, err := ctx.Client.Strip.Mute(strip)
+		if err != nil {
+			return fmt.Errorf("failed to get strip %d mute state: %w", strip, err)
+		}
+		levels[i] = mirrorLevel(fader, muted, cmd.Offset)
+		if stereo {
+			if pans[i], err = ctx.Client.Strip.Pan(strip); err != nil {
+				return fmt.Errorf("failed to get strip %d pan: %w", strip, err)
+			}
+		}
+	}
+
+	for i, level := range levels {
+		strip := i + 1
+		if err := ctx.Client.Strip.SetSendLevel(strip, cmd.Bus, level); err != nil {
+			return fmt.Errorf("failed to set strip %d send level: %w", strip, err)
+		}
+		if !stereo {
+			fmt.Fprintf(ctx.Confirm, "Strip %d send level for bus %d set to: %.2f dB\n", strip, cmd.Bus, level)
+			continue
+		}
+		if err := ctx.Client.Strip.SetSendPan(strip, cmd.Bus, pans[i]); err != nil {
+			return fmt.Errorf("failed to set strip %d send pan: %w", strip, err)
+		}
+		fmt.Fprintf(ctx.Confirm, "Strip %d send for bus %d set to: %.2f dB, pan %.0f\n", strip, cmd.Bus, level, pans[i])
+	}
+	return nil
+}
+
+// mirrorLevel returns the send level that reproduces a strip's contribution to the main mix.
+// A strip that is muted or pulled to -inf stays silent whatever the offset, otherwise the level is
+// kept within the -90 to +10 dB range of a send.
+func mirrorLevel(fader float64, muted bool, offset float64) float64 {
+	if muted || fader <= -90 {
+		return -90
+	}
+	return math.Max(-90, math.Min(10, fader+offset))
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PingCmd defines the command for checking that the mixer replies and measuring the round trip time.
+// Combine it with --every and --count to ping repeatedly.
+type PingCmd struct {
+	JSON bool `help:"Print the result as JSON." name:"json"`
+}
+
+// pingResult is the structure printed by the ping command with --json.
+type pingResult struct {
+	Address string  `json:"address"`
+	Latency float64 `json:"latency_ms"`
+}
+
+// Run executes the PingCmd command, timing a single /xinfo request.
+func (cmd *PingCmd) Run(ctx *context) error {
+	latency, err := ctx.Client.Ping()
+	if err != nil {
+		return exitError{fmt.Errorf("no reply from %s: %w", ctx.Client.Addr(), err), exitConnection}
+	}
+
+	result := pingResult{Address: ctx.Client.Addr(), Latency: float64(latency) / float64(time.Millisecond)}
+	if cmd.JSON {
+		return json.NewEncoder(ctx.Out).Encode(result)
+	}
+	fmt.Fprintf(ctx.Out, "Reply from %s in %.2f ms\n", result.Address, result.Latency)
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// PresetCmdGroup defines the commands for storing strip processing as named presets and applying them to other strips.
+type PresetCmdGroup struct {
+	Save  PresetSaveCmd  `help:"Save the settings of a strip as a named preset." cmd:""`
+	Apply PresetApplyCmd `help:"Apply a named preset to a strip."                cmd:""`
+	List  PresetListCmd  `help:"List the saved presets."                         cmd:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
+}
+
+// PresetSaveCmd defines the command for saving the settings of a strip as a named preset.
+type PresetSaveCmd struct {
+	Name string `arg:"" help:"The name of the preset."`
+	From int    `       help:"The strip to read the preset from. (1-based indexing)" required:""`
+}
+
+// Run executes the PresetSaveCmd command, storing the strip's snapshot without its name.
+func (cmd *PresetSaveCmd) Run(ctx *context) error {
+	path, err := presetPath(cmd.Name)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Client.ValidateIndex("strip", cmd.From); err != nil {
+		return err
+	}
+
+	snap, err := ctx.Client.Strip.Snapshot(cmd.From)
+	if err != nil {
+		return fmt.Errorf("failed to read strip %d: %w", cmd.From, err)
+	}
+	snap.Name = ""
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode preset: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create preset directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write preset: %w", err)
+	}
+
+	fmt.Fprintf(ctx.Confirm, "Preset %q saved from strip %d\n", cmd.Name, cmd.From)
+	return nil
+}
+
+// PresetApplyCmd defines the command for applying a named preset to a strip.
+// Only the processing is applied by default, so the strip keeps its name, mix and routing.
+type PresetApplyCmd struct {
+	Name     string   `arg:"" help:"The name of the preset."`
+	To       int      `       help:"The strip to apply the preset to. (1-based indexing)" required:""`
+	Sections []string `       help:"The sections of the preset to apply."                  default:"gate,eq,comp" enum:"config,sends,gate,eq,comp,mix" sep:","`
+}
+
+// Run executes the PresetApplyCmd command, writing the selected sections of the preset to the strip.
+func (cmd *PresetApplyCmd) Run(ctx *context) error {
+	path, err := presetPath(cmd.Name)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Client.ValidateIndex("strip", cmd.To); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("preset %q does not exist", cmd.Name)
+		}
+		return fmt.Errorf("failed to read preset: %w", err)
+	}
+	var snap xair.StripSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode preset %q: %w", cmd.Name, err)
+	}
+
+	if err := ctx.Client.Strip.ApplySections(cmd.To, snap, cmd.Sections...); err != nil {
+		return fmt.Errorf("failed to apply preset to strip %d: %w", cmd.To, err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Preset %q applied to strip %d: %s\n", cmd.Name, cmd.To, strings.Join(cmd.Sections, ", "))
+	return nil
+}
+
+// PresetListCmd defines the command for listing the saved presets.
+type PresetListCmd struct{}
+
+// Run executes the PresetListCmd command, printing the name of every saved preset in alphabetical order.
+func (cmd *PresetListCmd) Run(ctx *context) error {
+	dir, err := presetDir()
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read preset directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(ctx.Out, "No presets saved")
+		return nil
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(ctx.Out, name)
+	}
+	return nil
+}
+
+// presetDir returns the directory presets are stored in.
+func presetDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "x32-cli", "presets"), nil
+}
+
+// presetPath returns the file a preset is stored in, rejecting names that would escape the preset directory.
+func presetPath(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid preset name %q", name)
+	}
+
+	dir, err := presetDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
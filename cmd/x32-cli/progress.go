@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+// progress draws the completion of a long running command, such as a fade or a dump, so it does not look hung.
+// A nil *progress draws nothing, newProgress returns nil when there is no terminal to draw on.
+type progress struct {
+	w       io.Writer
+	label   string
+	mu      sync.Mutex
+	percent int
+}
+
+// newProgress starts a progress line on w, or returns nil when w is nil.
+func newProgress(w io.Writer, label string) *progress {
+	if w == nil {
+		return nil
+	}
+	p := &progress{w: w, label: label, percent: -1}
+	p.update(0)
+	return p
+}
+
+// update redraws the line for fraction, from 0 to 1, once it has moved on by a whole percent.
+// It never moves backwards, so several concurrent fades can share one line. It is safe for concurrent use.
+func (p *progress) update(fraction float64) {
+	if p == nil {
+		return
+	}
+	percent := int(math.Round(math.Max(0, math.Min(1, fraction)) * 100))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if percent <= p.percent {
+		return
+	}
+	p.percent = percent
+	fmt.Fprintf(p.w, "\r%s %3d%%", p.label, percent)
+}
+
+// done clears the progress line, so the output that follows starts on a clean line.
+func (p *progress) done() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(p.w, "\r\x1b[K")
+}
+
+// progressWriter returns stderr when it is a terminal, and nil when it is redirected or --quiet is set.
+func progressWriter(quiet bool) io.Writer {
+	if quiet {
+		return nil
+	}
+	info, err := os.Stderr.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return os.Stderr
+}
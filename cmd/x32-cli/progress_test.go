@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// drawnPercents returns the percentages drawn by a progress line labelled label.
+func drawnPercents(t *testing.T, out string, label string) []int {
+	t.Helper()
+	var percents []int
+	for _, m := range regexp.MustCompile(`\r`+label+` +(\d+)%`).FindAllStringSubmatch(out, -1) {
+		p, err := strconv.Atoi(m[1])
+		if err != nil {
+			t.Fatalf("bad percentage %q", m[1])
+		}
+		percents = append(percents, p)
+	}
+	return percents
+}
+
+func checkIncreasing(t *testing.T, percents []int) {
+	t.Helper()
+	if len(percents) < 2 || percents[0] != 0 || percents[len(percents)-1] != 100 {
+		t.Fatalf("got percentages %v, want them to run from 0 to 100", percents)
+	}
+	for i := 1; i < len(percents); i++ {
+		if percents[i] <= percents[i-1] {
+			t.Errorf("progress went from %d%% to %d%%", percents[i-1], percents[i])
+		}
+	}
+}
+
+func TestProgressIncreasesDuringFade(t *testing.T) {
+	var out bytes.Buffer
+	bar := newProgress(&out, "Fading")
+	if err := rampFader(func(float64) error { return nil }, -90, 0, 0, "linear", bar); err != nil {
+		t.Fatalf("rampFader failed: %v", err)
+	}
+	checkIncreasing(t, drawnPercents(t, out.String(), "Fading"))
+}
+
+func TestProgressIncreasesDuringJobs(t *testing.T) {
+	var out bytes.Buffer
+	bar := newProgress(&out, "Reading")
+	jobs := make([]func() error, 40)
+	for i := range jobs {
+		jobs[i] = func() error { return nil }
+	}
+	if err := runJobs(jobs, 4, bar); err != nil {
+		t.Fatalf("runJobs failed: %v", err)
+	}
+	checkIncreasing(t, drawnPercents(t, out.String(), "Reading"))
+}
+
+func TestProgressNeverMovesBackwards(t *testing.T) {
+	var out bytes.Buffer
+	bar := newProgress(&out, "Fading")
+	for _, fraction := range []float64{0.5, 0.25, 0.5, 1} {
+		bar.update(fraction)
+	}
+	if got, want := drawnPercents(t, out.String(), "Fading"), []int{0, 50, 100}; !slices.Equal(got, want) {
+		t.Errorf("got percentages %v, want %v", got, want)
+	}
+
+	// without a terminal there is no progress line at all
+	none := newProgress(nil, "Fading")
+	none.update(0.5)
+	none.done()
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSoloLevel(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "solo", "level", "--", "-10"); err != nil {
+		t.Fatalf("solo level failed: %v", err)
+	}
+	flush(t, client)
+	if got := mixer.Value("/config/solo/level"); len(got) != 1 || got[0] != float32(0.5) {
+		t.Errorf("got /config/solo/level %v, want [0.5]", got)
+	}
+	out, err := runCommand(t, client, "solo", "level")
+	if err != nil {
+		t.Fatalf("solo level failed: %v", err)
+	}
+	if want := "Solo level: -10.00 dB"; !strings.Contains(out, want) {
+		t.Errorf("output %q does not contain %q", out, want)
+	}
+}
+
+func TestSoloDimIsMainDim(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "solo", "dim", "true"); err != nil {
+		t.Fatalf("solo dim failed: %v", err)
+	}
+	flush(t, client)
+	if got := mixer.Value("/config/solo/dim"); len(got) != 1 || got[0] != int32(1) {
+		t.Errorf("got /config/solo/dim %v, want [1]", got)
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStripEqBandSetOnlyWritesGivenFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want map[string]bool // band parameters expected to be written
+	}{
+		{"gain", []string{"--gain", "-3"}, map[string]bool{"g": true}},
+		{"frequency and type", []string{"--freq", "2k", "--type", "lshv"}, map[string]bool{"f": true, "type": true}},
+		{"q", []string{"--q", "1.5"}, map[string]bool{"q": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t, "X32")
+			if _, err := runCommand(t, client, append([]string{"strip", "3", "eq", "2", "set"}, tt.args...)...); err != nil {
+				t.Fatalf("eq band set failed: %v", err)
+			}
+			flush(t, client)
+			for _, p := range []string{"g", "f", "q", "type"} {
+				address := "/ch/03/eq/2/" + p
+				if got := mixer.Value(address) != nil; got != tt.want[p] {
+					t.Errorf("%s written: %t, want %t", address, got, tt.want[p])
+				}
+			}
+		})
+	}
+}
+
+func TestStripEqBandSetRequiresAFlag(t *testing.T) {
+	client, _ := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "strip", "3", "eq", "2", "set"); err == nil {
+		t.Error("eq band set without flags succeeded, want an error")
+	}
+}
+
+func TestStripMonoSendAddresses(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "strip", "4", "mono", "on", "true"); err != nil {
+		t.Fatalf("mono on failed: %v", err)
+	}
+	if _, err := runCommand(t, client, "strip", "4", "mono", "level", "--", "-6"); err != nil {
+		t.Fatalf("mono level failed: %v", err)
+	}
+	flush(t, client)
+
+	if got := mixer.Value("/ch/04/mix/mono"); len(got) != 1 || got[0] != int32(1) {
+		t.Errorf("got /ch/04/mix/mono %v, want 1", got)
+	}
+	out, err := runCommand(t, client, "strip", "4", "mono", "level")
+	if err != nil {
+		t.Fatalf("failed to read the mono level: %v", err)
+	}
+	if want := "Strip 4 mono send level: -6.00 dB"; !strings.Contains(out, want) {
+		t.Errorf("got %q, want %q", out, want)
+	}
+	if mixer.Value("/ch/04/mix/mlevel") == nil {
+		t.Error("/ch/04/mix/mlevel was not written")
+	}
+}
+
+func TestStripFaderFineReportsStoredLevel(t *testing.T) {
+	client, _ := newTestClient(t, "X32")
+	out, err := runCommand(t, client, "strip", "1", "fader", "--fine", "--", "-10.04")
+	if err != nil {
+		t.Fatalf("fader --fine failed: %v", err)
+	}
+	for _, want := range []string{"Strip 1 fader level set to: -10.00 dB", "Strip 1 fader level stored as: -10.0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestStripMuteToggle(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	mixer.Set("/ch/01/mix/on", int32(1)) // unmuted
+	for _, want := range []int32{0, 1} {
+		if _, err := runCommand(t, client, "strip", "1", "mute", "toggle"); err != nil {
+			t.Fatalf("mute toggle failed: %v", err)
+		}
+		flush(t, client)
+		if got := mixer.Value("/ch/01/mix/on"); len(got) != 1 || got[0] != want {
+			t.Errorf("got /ch/01/mix/on %v after toggle, want %d", got, want)
+		}
+	}
+}
+
+func TestStripNameClear(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	mixer.Set("/ch/01/config/name", "Vocals")
+	if _, err := runCommand(t, client, "strip", "1", "name", "--clear"); err != nil {
+		t.Fatalf("name --clear failed: %v", err)
+	}
+	flush(t, client)
+	if got := mixer.Value("/ch/01/config/name"); len(got) != 1 || got[0] != "" {
+		t.Errorf("got name %v after --clear, want it empty", got)
+	}
+
+	if _, err := runCommand(t, client, "strip", "1", "name", "Vocals", "--clear"); err == nil {
+		t.Error("a name with --clear succeeded, want an error")
+	}
+}
+
+func TestStripFaderMatchSetsEveryStrip(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	mixer.Set("/ch/05/config/name", "Vocals")
+	if _, err := runCommand(t, client, "strip", "fader-match", "1", "2", "vocals", "--to", "-10"); err != nil {
+		t.Fatalf("fader-match failed: %v", err)
+	}
+	flush(t, client)
+
+	for _, strip := range []int{1, 2, 5} {
+		address := fmt.Sprintf("/ch/%02d/mix/fader", strip)
+		if got := mixer.Value(address); len(got) != 1 || got[0] != float32(0.5) {
+			t.Errorf("got %s = %v, want [0.5]", address, got)
+		}
+	}
+	if mixer.Sets("/ch/03/mix/fader") != 0 {
+		t.Error("fader-match set strip 3, which was not given")
+	}
+}
+
+func TestStripFaderMatchRejectsLevelBeforeSetting(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	if _, err := runCommand(t, client, "strip", "fader-match", "1", "2", "--to", "20"); err == nil {
+		t.Fatal("fader-match to 20 dB succeeded, want an error")
+	}
+	flush(t, client)
+	for _, address := range []string{"/ch/01/mix/fader", "/ch/02/mix/fader"} {
+		if n := mixer.Sets(address); n != 0 {
+			t.Errorf("got %d sets of %s, want none", n, address)
+		}
+	}
+}
+
+func TestStripCompRatioSnapsToNearest(t *testing.T) {
+	client, _ := newTestClient(t, "X32")
+	out, err := runCommand(t, client, "strip", "1", "comp", "ratio", "4.1")
+	if err != nil {
+		t.Fatalf("comp ratio failed: %v", err)
+	}
+	if want := "Strip 1 compressor ratio set to: 4.00"; !strings.Contains(out, want) {
+		t.Errorf("got %q, want %q", out, want)
+	}
+	if _, err := runCommand(t, client, "strip", "1", "comp", "ratio", "4.1", "--exact"); err == nil {
+		t.Error("comp ratio 4.1 --exact succeeded, want an error")
+	}
+}
+
+func TestStripGateSetAppliesOnlyGivenFlags(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	out, err := runCommand(t, client, "strip", "3", "gate", "set", "--threshold=-40", "--release", "200")
+	if err != nil {
+		t.Fatalf("gate set failed: %v", err)
+	}
+	flush(t, client)
+	for _, p := range []string{"mode", "thr", "range", "attack", "hold", "release"} {
+		address := "/ch/03/gate/" + p
+		want := p == "thr" || p == "release"
+		if got := mixer.Value(address) != nil; got != want {
+			t.Errorf("%s written: %t, want %t", address, got, want)
+		}
+	}
+	for _, want := range []string{"Strip 3 gate threshold set to: -40.00 dB", "Strip 3 gate release time set to: 200.00 ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestStripSendFansOutToBuses(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want float32 // the raw send level expected on every bus
+	}{
+		{"level", []string{"--level=-10"}, 0.5},
+		{"percent", []string{"--percent", "75"}, 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t, "X32")
+			if _, err := runCommand(t, client, append([]string{"strip", "1", "send", "--buses", "1,2,3"}, tt.args...)...); err != nil {
+				t.Fatalf("send failed: %v", err)
+			}
+			flush(t, client)
+			for bus := 1; bus <= 4; bus++ {
+				address := fmt.Sprintf("/ch/01/mix/%02d/level", bus)
+				got := mixer.Value(address)
+				if bus == 4 {
+					if got != nil {
+						t.Errorf("%s written %v, want it untouched", address, got)
+					}
+					continue
+				}
+				if len(got) != 1 || got[0] != tt.want {
+					t.Errorf("got %s %v, want [%g]", address, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStripEqBandCopyToOtherBand(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	mixer.Set("/ch/01/eq/2/type", int32(2))
+	if _, err := runCommand(t, client, "strip", "1", "eq", "2", "gain", "--", "-6"); err != nil {
+		t.Fatalf("eq gain failed: %v", err)
+	}
+	out, err := runCommand(t, client, "strip", "1", "eq", "2", "copy-to", "5", "--band", "3")
+	if err != nil {
+		t.Fatalf("copy-to failed: %v", err)
+	}
+	if want := "Strip 1 EQ band 2 copied to strip 5 EQ band 3: peq"; !strings.Contains(out, want) {
+		t.Errorf("output %q does not contain %q", out, want)
+	}
+
+	out, err = runCommand(t, client, "strip", "5", "eq", "3", "gain")
+	if err != nil {
+		t.Fatalf("eq gain failed: %v", err)
+	}
+	if want := "Strip 5 EQ band 3 gain: -6.00"; !strings.Contains(out, want) {
+		t.Errorf("output %q does not contain %q", out, want)
+	}
+
+	if _, err := runCommand(t, client, "strip", "1", "eq", "2", "copy-to", "1"); err == nil {
+		t.Error("copying a band onto itself succeeded, want an error")
+	}
+}
+
+func TestStripSourceCarryGain(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	mixer.Set("/ch/07/config/source", int32(7)) // IN 07
+	mixer.Set("/headamp/006/gain", float32(0.25))
+
+	if _, err := runCommand(t, client, "strip", "7", "source", "in09", "--carry-gain"); err != nil {
+		t.Fatalf("source failed: %v", err)
+	}
+	flush(t, client)
+	if got := mixer.Value("/ch/07/config/source"); len(got) != 1 || got[0] != int32(9) {
+		t.Errorf("got /ch/07/config/source %v, want [9]", got)
+	}
+	if got := mixer.Value("/headamp/008/gain"); len(got) != 1 || got[0] != float32(0.25) {
+		t.Errorf("got /headamp/008/gain %v, want the carried [0.25]", got)
+	}
+}
+
+func TestStripSourceCarryGainSkipsSourcesWithoutHeadamp(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	mixer.Set("/ch/07/config/source", int32(7)) // IN 07
+	if _, err := runCommand(t, client, "strip", "7", "source", "aux1", "--carry-gain"); err != nil {
+		t.Fatalf("source failed: %v", err)
+	}
+	flush(t, client)
+	if got := mixer.Value("/headamp/006/gain"); got != nil {
+		t.Errorf("/headamp/006/gain written %v, want it untouched", got)
+	}
+}
+
+func TestStripSendPan(t *testing.T) {
+	client, mixer := newTestClient(t, "X32")
+	mixer.Set("/config/buslink/1-2", int32(1))
+	if _, err := runCommand(t, client, "strip", "2", "send-pan", "2", "-100"); err != nil {
+		t.Fatalf("send-pan failed: %v", err)
+	}
+	flush(t, client)
+	if got := mixer.Value("/ch/02/mix/01/pan"); len(got) != 1 || got[0] != float32(0) {
+		t.Errorf("got /ch/02/mix/01/pan %v, want [0]", got)
+	}
+
+	if _, err := runCommand(t, client, "strip", "2", "send-pan", "3", "50"); err == nil || !strings.Contains(err.Error(), "mono bus") {
+		t.Errorf("got error %v, want a mono bus error", err)
+	}
+}
+
+func TestAuditionBandRestoresGain(t *testing.T) {
+	tests := []struct {
+		name      string
+		hold      time.Duration
+		interrupt bool
+	}{
+		{"after the hold", 10 * time.Millisecond, false},
+		{"on interrupt", time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gains := []float64{-3}
+			set := func(gain float64) error {
+				gains = append(gains, gain)
+				if tt.interrupt && gain == 12 {
+					// the handler is installed before the boost, so the signal cannot stop the test
+					p, err := os.FindProcess(os.Getpid())
+					if err != nil {
+						return err
+					}
+					return p.Signal(os.Interrupt)
+				}
+				return nil
+			}
+			get := func() (float64, error) { return gains[len(gains)-1], nil }
+
+			done := make(chan struct{})
+			var restored float64
+			var err error
+			go func() {
+				defer close(done)
+				restored, err = auditionBand(get, set, 12, tt.hold)
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("audition did not restore the band")
+			}
+
+			if err != nil {
+				t.Fatalf("auditionBand failed: %v", err)
+			}
+			if restored != -3 || len(gains) != 3 || gains[1] != 12 || gains[2] != -3 {
+				t.Errorf("got gains %v, restored %g, want [-3 12 -3] and -3", gains, restored)
+			}
+		})
+	}
+}
+
+func TestStripDynamicsRelativeChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  map[string]float32 // raw values set on the mixer first
+		args    []string
+		address string
+		want    string // the expected confirmation
+	}{
+		{
+			"comp threshold up", nil,
+			[]string{"comp", "threshold", "+3"}, "/ch/01/dyn/thr",
+			"Strip 1 compressor threshold set to: -27.00 (+3.00 dB)",
+		},
+		{
+			"comp threshold down", nil,
+			[]string{"comp", "threshold", "-3"}, "/ch/01/dyn/thr",
+			"Strip 1 compressor threshold set to: -33.00 (-3.00 dB)",
+		},
+		{
+			"gate range up", nil,
+			[]string{"gate", "range", "+3"}, "/ch/01/gate/range",
+			"Strip 1 gate range set to: 34.50 (+3.00 dB)",
+		},
+		{
+			"gate threshold clamped at the top", map[string]float32{"/ch/01/gate/thr": 0.95},
+			[]string{"gate", "threshold", "+6"}, "/ch/01/gate/thr",
+			"Strip 1 gate threshold set to: 0.00 (+4.00 dB)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t, "X32")
+			for address, v := range tt.preset {
+				mixer.Set(address, v)
+			}
+			out, err := runCommand(t, client, append([]string{"strip", "1"}, tt.args...)...)
+			if err != nil {
+				t.Fatalf("%v failed: %v", tt.args, err)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output %q does not contain %q", out, tt.want)
+			}
+			flush(t, client)
+			if mixer.Value(tt.address) == nil {
+				t.Errorf("%s was not written", tt.address)
+			}
+		})
+	}
+}
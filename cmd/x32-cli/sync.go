@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// SyncCmd defines the command for bringing the mixer back to a state saved with dump.
+// Only the parts that drifted are written, so with --every it holds an unattended mixer to a known state.
+type SyncCmd struct {
+	Scene string `arg:"" help:"A file written by dump. Only the sections it contains are synced." type:"existingfile"`
+}
+
+// Run executes the SyncCmd command, comparing each output in the scene with the mixer and reapplying the ones that differ.
+// Strips are reapplied section by section, the other outputs as a whole.
+func (cmd *SyncCmd) Run(ctx *context) error {
+	data, err := os.ReadFile(cmd.Scene)
+	if err != nil {
+		return fmt.Errorf("failed to read scene: %w", err)
+	}
+	var scene mixerState
+	if err := json.Unmarshal(data, &scene); err != nil {
+		return fmt.Errorf("failed to decode scene %s: %w", cmd.Scene, err)
+	}
+	if len(scene.Strips) > ctx.Client.StripCount() || len(scene.Buses) > ctx.Client.BusCount() {
+		return fmt.Errorf("scene %s has %d strips and %d buses, the mixer only has %d and %d",
+			cmd.Scene, len(scene.Strips), len(scene.Buses), ctx.Client.StripCount(), ctx.Client.BusCount())
+	}
+
+	corrections := 0
+	if scene.Main != nil {
+		fixed, err := syncMain(ctx.Client.Main, *scene.Main)
+		if err != nil {
+			return fmt.Errorf("failed to sync Main L/R: %w", err)
+		}
+		if fixed {
+			fmt.Fprintln(ctx.Confirm, "Main L/R reapplied from scene")
+			corrections++
+		}
+	}
+	if scene.MainMono != nil {
+		fixed, err := syncMain(ctx.Client.MainMono, *scene.MainMono)
+		if err != nil {
+			return fmt.Errorf("failed to sync Main Mono: %w", err)
+		}
+		if fixed {
+			fmt.Fprintln(ctx.Confirm, "Main Mono reapplied from scene")
+			corrections++
+		}
+	}
+
+	for i, want := range scene.Strips {
+		strip := i + 1
+		current, err := ctx.Client.Strip.Snapshot(strip)
+		if err != nil {
+			return fmt.Errorf("failed to read strip %d: %w", strip, err)
+		}
+		var drifted []string
+		for _, section := range xair.StripSections {
+			differs, err := snapshotDiffers(stripSection(current, section), stripSection(want, section))
+			if err != nil {
+				return err
+			}
+			if differs {
+				drifted = append(drifted, section)
+			}
+		}
+		if len(drifted) == 0 {
+			continue
+		}
+		if err := ctx.Client.Strip.ApplySections(strip, want, drifted...); err != nil {
+			return fmt.Errorf("failed to sync strip %d: %w", strip, err)
+		}
+		fmt.Fprintf(ctx.Confirm, "Strip %d reapplied from scene: %s\n", strip, strings.Join(drifted, ", "))
+		corrections++
+	}
+
+	for i, want := range scene.Buses {
+		bus := i + 1
+		current, err := ctx.Client.Bus.Snapshot(bus)
+		if err != nil {
+			return fmt.Errorf("failed to read bus %d: %w", bus, err)
+		}
+		differs, err := snapshotDiffers(current, want)
+		if err != nil {
+			return err
+		}
+		if !differs {
+			continue
+		}
+		if err := ctx.Client.Bus.ApplySnapshot(bus, want); err != nil {
+			return fmt.Errorf("failed to sync bus %d: %w", bus, err)
+		}
+		fmt.Fprintf(ctx.Confirm, "Bus %d reapplied from scene\n", bus)
+		corrections++
+	}
+
+	if corrections == 0 {
+		log.Infof("Mixer matches %s", cmd.Scene)
+	}
+	return nil
+}
+
+// syncMain reapplies want to a main output when it has drifted from it, and reports whether it did.
+func syncMain(main *xair.Main, want xair.MainSnapshot) (bool, error) {
+	current, err := main.Snapshot()
+	if err != nil {
+		return false, err
+	}
+	differs, err := snapshotDiffers(current, want)
+	if err != nil || !differs {
+		return false, err
+	}
+	return true, main.ApplySnapshot(want)
+}
+
+// stripSection returns the values of snap that ApplySections writes for section.
+func stripSection(snap xair.StripSnapshot, section string) any {
+	switch section {
+	case "config":
+		return []any{snap.Name, snap.Color}
+	case "sends":
+		return snap.Sends
+	case "gate":
+		return snap.Gate
+	case "eq":
+		return snap.Eq
+	case "comp":
+		return snap.Comp
+	default: // mix
+		return []any{snap.Fader, snap.Mute}
+	}
+}
+
+// snapshotDiffers reports whether current differs from want, using the same float tolerance as dump --changed-only.
+func snapshotDiffers(current, want any) (bool, error) {
+	var c, w any
+	if err := jsonRoundTrip(current, &c); err != nil {
+		return false, err
+	}
+	if err := jsonRoundTrip(want, &w); err != nil {
+		return false, err
+	}
+	_, differs := diffValue(c, w)
+	return differs, nil
+}
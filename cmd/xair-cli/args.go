@@ -0,0 +1,283 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+const (
+	minLevel = -90.0 // Minimum fader/send level in dB.
+	maxLevel = 10.0  // Maximum fader/send level in dB.
+	minGain  = -12.0 // Minimum headamp gain in dB.
+	maxGain  = 60.0  // Maximum headamp gain in dB.
+
+	minCompThreshold = -60.0 // Minimum compressor threshold in dB.
+	maxCompThreshold = 0.0   // Maximum compressor threshold in dB.
+	minGateThreshold = -80.0 // Minimum gate threshold in dB.
+	maxGateThreshold = 0.0   // Maximum gate threshold in dB.
+	minGateRange     = 3.0   // Minimum gate range in dB.
+	maxGateRange     = 60.0  // Maximum gate range in dB.
+
+	fineStep = 0.1 // Step in dB that fader levels are snapped to with --fine.
+)
+
+// relativeFloat is a numeric argument that may be given relative to the current value.
+// A leading '+' marks the value as a relative change, so "+3" raises the current value by 3 and "+-3" lowers it by 3.
+// negativeArgs passes a plain "-3" on as "+-3", so on the command line a leading '-' lowers the value as well.
+// Any other value, including a negative one given after "--" such as "-- -10", is absolute.
+type relativeFloat struct {
+	Value    float64
+	Relative bool
+}
+
+// relativeFloatMapper decodes relativeFloat arguments.
+func relativeFloatMapper() kong.MapperFunc {
+	return func(ctx *kong.DecodeContext, target reflect.Value) error {
+		var s string
+		if err := ctx.Scan.PopValueInto("value", &s); err != nil {
+			return err
+		}
+
+		var r relativeFloat
+		num := s
+		if rest, ok := strings.CutPrefix(s, "+"); ok {
+			r.Relative = true
+			num = rest
+		}
+
+		v, err := parseUnitFloat(num)
+		if err != nil {
+			return fmt.Errorf("expected a number or a relative change such as +3 or -3 but got %q", s)
+		}
+		r.Value = v
+
+		target.Set(reflect.ValueOf(r))
+		return nil
+	}
+}
+
+// unitSuffixes are the units a number may be followed by, with the factor they scale it by.
+// Longer suffixes come first so that "khz" is not read as "hz".
+var unitSuffixes = []struct {
+	suffix string
+	factor float64
+}{
+	{"khz", 1000},
+	{"hz", 1},
+	{"db", 1},
+	{"ms", 1},
+	{"%", 1},
+	{"k", 1000},
+}
+
+// parseUnitFloat parses a number that may carry a unit, such as -6dB, 250Hz, 1.5k, 20ms or 75%.
+// The unit only affects the value through its factor, so "1.5k" and "1.5kHz" both give 1500.
+// Units are not checked against the parameter, "20ms" and "20dB" are both 20. NaN and infinities are rejected.
+func parseUnitFloat(s string) (float64, error) {
+	num, factor := s, 1.0
+	lower := strings.ToLower(s)
+	for _, u := range unitSuffixes {
+		if strings.HasSuffix(lower, u.suffix) {
+			num, factor = strings.TrimSpace(s[:len(s)-len(u.suffix)]), u.factor
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, err
+	}
+	// ParseFloat accepts "nan" and "inf", neither is a value any parameter can take
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", s)
+	}
+	return v * factor, nil
+}
+
+// unitFloatMapper decodes float64 arguments and flags, accepting a unit after the number, see parseUnitFloat.
+func unitFloatMapper() kong.MapperFunc {
+	return func(ctx *kong.DecodeContext, target reflect.Value) error {
+		var s string
+		if err := ctx.Scan.PopValueInto("float", &s); err != nil {
+			return err
+		}
+		v, err := parseUnitFloat(s)
+		if err != nil {
+			return fmt.Errorf("expected a number, optionally with a unit such as -6dB, 1.5k or 20ms, but got %q", s)
+		}
+		target.SetFloat(v)
+		return nil
+	}
+}
+
+// resolve returns the absolute value to set, reading the current value with get when the value is relative.
+// Relative results are clamped to the range [lo, hi] and the delta is adjusted to the change actually applied.
+func (r *relativeFloat) resolve(get func() (float64, error), lo, hi float64) (float64, error) {
+	if !r.Relative {
+		return r.Value, nil
+	}
+
+	current, err := get()
+	if err != nil {
+		return 0, err
+	}
+	target := math.Max(lo, math.Min(hi, current+r.Value))
+	r.Value = target - current
+	return target, nil
+}
+
+// delta describes a relative change for use in confirmation messages, it returns an empty string for absolute values.
+func (r *relativeFloat) delta(unit string) string {
+	if !r.Relative {
+		return ""
+	}
+	return fmt.Sprintf(" (%+.2f %s)", r.Value, unit)
+}
+
+// quantize rounds the resolved value v to the nearest multiple of step, adjusting the delta of a relative value to match.
+func (r *relativeFloat) quantize(v, step float64) float64 {
+	q := math.Round(v/step) * step
+	if r.Relative {
+		r.Value += q - v
+	}
+	return q
+}
+
+// resolveToggle returns the state to set for a "true", "false" or "toggle" argument.
+// For "toggle" the current state is read with get and the inverse is returned.
+func resolveToggle(arg string, get func() (bool, error)) (bool, error) {
+	if arg != "toggle" {
+		return arg == "true", nil
+	}
+
+	current, err := get()
+	if err != nil {
+		return false, err
+	}
+	return !current, nil
+}
+
+// negativeNumber matches arguments such as -10, -90.5 or -6dB, which kong would otherwise parse as short flags.
+var negativeNumber = regexp.MustCompile(`^-(\d+(\.\d*)?|\.\d+)(?i:khz|hz|db|ms|%|k)?$`)
+
+// negativeArgs rewrites args so that negative numbers are parsed as values rather than flags.
+// A negative number given to a value that may be relative, such as a fader level, is a relative change, so
+// "fader -3" lowers the fader by 3 dB. It is passed on as "+-3", see relativeFloat. Any other negative number is absolute.
+// A negative number following a flag that takes a value is joined to it, e.g. "--threshold -40" becomes "--threshold=-40".
+// Positional arguments from the first negative number on are moved behind a "--", flags stay in front of it.
+// Arguments after an explicit "--" are left as they are, so "fader -- -10" still sets the fader to -10 dB.
+func negativeArgs(app *kong.Application, args []string) []string {
+	valueFlags := map[string]*kong.Flag{}
+	_ = kong.Visit(app, func(node kong.Visitable, next kong.Next) error {
+		if flag, ok := node.(*kong.Flag); ok && !flag.IsBool() && !flag.IsCounter() {
+			valueFlags["--"+flag.Name] = flag
+			for _, alias := range flag.Aliases {
+				valueFlags["--"+alias] = flag
+			}
+			if flag.Short != 0 {
+				valueFlags["-"+string(flag.Short)] = flag
+			}
+		}
+		return next(nil)
+	})
+
+	var front, positional, rest []string
+	walk := argWalker{node: app.Node}
+	moving := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch flag := valueFlags[arg]; {
+		case arg == "--":
+			rest = args[i+1:]
+			i = len(args)
+		case flag != nil && i+1 < len(args):
+			// the next argument is the flag's value, whatever it looks like
+			value := args[i+1]
+			i++
+			if negativeNumber.MatchString(value) {
+				if isRelative(flag.Value) {
+					value = "+" + value
+				}
+				if strings.HasPrefix(arg, "--") {
+					front = append(front, arg+"="+value)
+				} else {
+					front = append(front, arg+value)
+				}
+				continue
+			}
+			front = append(front, arg, value)
+		case negativeNumber.MatchString(arg):
+			moving = true
+			if isRelative(walk.next(arg)) {
+				arg = "+" + arg
+			}
+			positional = append(positional, arg)
+		case !strings.HasPrefix(arg, "-"):
+			walk.next(arg)
+			if moving {
+				positional = append(positional, arg)
+			} else {
+				front = append(front, arg)
+			}
+		default:
+			front = append(front, arg)
+		}
+	}
+
+	if len(positional) == 0 && rest == nil {
+		return front
+	}
+	return append(append(append(front, "--"), positional...), rest...)
+}
+
+// argWalker follows positional arguments through the command tree the way kong parses them,
+// to tell which value each positional argument is decoded into.
+type argWalker struct {
+	node       *kong.Node
+	positional int
+}
+
+// next returns the value arg is decoded into, or nil when arg selects a command or is not understood.
+// Like kong, it fills the positional arguments of the current command before matching subcommands.
+func (w *argWalker) next(arg string) *kong.Value {
+	if w.node == nil {
+		return nil
+	}
+	if w.positional < len(w.node.Positional) {
+		value := w.node.Positional[w.positional]
+		// a slice takes every remaining argument
+		if !value.IsCumulative() {
+			w.positional++
+		}
+		return value
+	}
+	for _, child := range w.node.Children {
+		if child.Type == kong.CommandNode && (child.Name == arg || slices.Contains(child.Aliases, arg)) {
+			w.node, w.positional = child, 0
+			return nil
+		}
+	}
+	for _, child := range w.node.Children {
+		if child.Type == kong.ArgumentNode {
+			w.node, w.positional = child, 0
+			return child.Argument
+		}
+	}
+	w.node = nil
+	return nil
+}
+
+// isRelative reports whether value is decoded as a relativeFloat.
+func isRelative(value *kong.Value) bool {
+	if value == nil {
+		return false
+	}
+	t := value.Target.Type()
+	return t == reflect.TypeOf(relativeFloat{}) || t == reflect.TypeOf(&relativeFloat{})
+}
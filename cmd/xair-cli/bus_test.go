@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+func TestBusInputsNonzeroJSON(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	for i := 1; i <= client.StripCount(); i++ {
+		mixer.Set(fmt.Sprintf("/ch/%02d/mix/02/level", i), float32(0))
+		mixer.Set(fmt.Sprintf("/ch/%02d/mix/02/on", i), int32(1))
+	}
+	mixer.Set("/ch/04/mix/02/level", float32(0.5))
+	mixer.Set("/ch/02/mix/02/level", float32(0.75))
+
+	out, err := runCommand(t, client, "bus", "2", "inputs", "--nonzero", "--json")
+	if err != nil {
+		t.Fatalf("bus inputs failed: %v", err)
+	}
+	var inputs []xair.SendFrom
+	if err := json.Unmarshal([]byte(out), &inputs); err != nil {
+		t.Fatalf("failed to decode %q: %v", out, err)
+	}
+	if len(inputs) != 2 || inputs[0].Strip != 2 || inputs[1].Strip != 4 {
+		t.Errorf("got inputs %+v, want strips 2 and 4, loudest first", inputs)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// CompSetFlags holds the flags shared by the compressor set commands, omitted flags leave the parameter untouched.
+type CompSetFlags struct {
+	Mode      *string  `help:"The compressor mode to set."                                                          enum:"comp,exp"`
+	Threshold *float64 `help:"The compressor threshold to set (in dB)."`
+	Ratio     *float64 `help:"The compressor ratio to set."`
+	Mix       *float64 `help:"The compressor mix level to set (in %)."`
+	Makeup    *float64 `help:"The compressor makeup gain to set (in dB)."`
+	Attack    *float64 `help:"The compressor attack time to set (in ms)."`
+	Hold      *float64 `help:"The compressor hold time to set (in ms)."`
+	Release   *float64 `help:"The compressor release time to set (in ms)."`
+	Exact     bool     `help:"Reject a ratio that is not one of the valid values instead of using the nearest one."`
+}
+
+// params converts the flags into compressor parameters, it returns an error if no flag was given.
+func (f *CompSetFlags) params() (xair.CompParams, error) {
+	params := xair.CompParams{
+		Mode:      f.Mode,
+		Threshold: f.Threshold,
+		Mix:       f.Mix,
+		Makeup:    f.Makeup,
+		Attack:    f.Attack,
+		Hold:      f.Hold,
+		Release:   f.Release,
+	}
+	if f.Ratio != nil {
+		ratio := ratioArg(*f.Ratio, f.Exact)
+		params.Ratio = &ratio
+	}
+	if params == (xair.CompParams{}) {
+		return params, fmt.Errorf("at least one of --mode, --threshold, --ratio, --mix, --makeup, --attack, --hold or --release must be provided")
+	}
+	return params, nil
+}
+
+// ratioArg returns the ratio to send for a requested ratio, the nearest valid one unless exact is set.
+// With exact the requested ratio is sent as it is, and SetRatio rejects it if it is not valid.
+func ratioArg(ratio float64, exact bool) float64 {
+	if exact {
+		return ratio
+	}
+	return xair.NearestRatio(ratio)
+}
+
+// apply sets the provided parameters on comp and prints a confirmation for each of them, prefixed by label.
+func (f *CompSetFlags) apply(out io.Writer, comp *xair.Comp, index int, label string) error {
+	params, err := f.params()
+	if err != nil {
+		return err
+	}
+	if err := comp.SetAll(index, params); err != nil {
+		return fmt.Errorf("failed to set %s compressor parameters: %w", label, err)
+	}
+
+	if f.Mode != nil {
+		fmt.Fprintf(out, "%s compressor mode set to: %s\n", label, *f.Mode)
+	}
+	if f.Threshold != nil {
+		fmt.Fprintf(out, "%s compressor threshold set to: %.2f dB\n", label, *f.Threshold)
+	}
+	if params.Ratio != nil {
+		fmt.Fprintf(out, "%s compressor ratio set to: %.2f\n", label, *params.Ratio)
+	}
+	if f.Mix != nil {
+		fmt.Fprintf(out, "%s compressor mix level set to: %.2f%%\n", label, *f.Mix)
+	}
+	if f.Makeup != nil {
+		fmt.Fprintf(out, "%s compressor makeup gain set to: %.2f dB\n", label, *f.Makeup)
+	}
+	if f.Attack != nil {
+		fmt.Fprintf(out, "%s compressor attack time set to: %.2f ms\n", label, *f.Attack)
+	}
+	if f.Hold != nil {
+		fmt.Fprintf(out, "%s compressor hold time set to: %.2f ms\n", label, *f.Hold)
+	}
+	if f.Release != nil {
+		fmt.Fprintf(out, "%s compressor release time set to: %.2f ms\n", label, *f.Release)
+	}
+	return nil
+}
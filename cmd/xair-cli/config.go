@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/posener/complete"
+)
+
+// ConfigCmdGroup defines the commands for managing the saved connection profiles.
+type ConfigCmdGroup struct {
+	Add    ConfigAddCmd    `help:"Save the address of a mixer as a named profile." cmd:"" aliases:"save-profile"`
+	List   ConfigListCmd   `help:"List the saved profiles."                         cmd:""`
+	Use    ConfigUseCmd    `help:"Connect through a profile by default."            cmd:""`
+	Remove ConfigRemoveCmd `help:"Remove a saved profile."                          cmd:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
+}
+
+// ConfigAddCmd defines the command for saving the address of a mixer as a named profile.
+type ConfigAddCmd struct {
+	Name    string `arg:"" help:"The name of the profile."`
+	Address string `       help:"The address of the X-Air device, as host or host:port." required:""`
+}
+
+// Run executes the ConfigAddCmd command, it does not need a connection to the mixer.
+func (cmd *ConfigAddCmd) Run(ctx *context) error {
+	p, err := parseAddress(cmd.Address)
+	if err != nil {
+		return err
+	}
+	if err := saveProfile(cmd.Name, p); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Profile %q saved as %s:%d\n", cmd.Name, p.Host, p.Port)
+	return nil
+}
+
+// ConfigListCmd defines the command for listing the saved profiles.
+type ConfigListCmd struct{}
+
+// Run executes the ConfigListCmd command, printing the profiles sorted by name and marking the default.
+func (cmd *ConfigListCmd) Run(ctx *context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Profiles) == 0 {
+		fmt.Fprintln(ctx.Out, "No saved profiles")
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(ctx.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tADDRESS\tDEFAULT")
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		fmt.Fprintf(w, "%s\t%s\t%t\n", name, net.JoinHostPort(p.Host, strconv.Itoa(p.Port)), name == cfg.Default)
+	}
+	return w.Flush()
+}
+
+// ConfigUseCmd defines the command for choosing the profile to connect through when neither a profile nor a host is given.
+type ConfigUseCmd struct {
+	Name  *string `arg:"" help:"The name of the profile." optional:"" completion-predictor:"profile"`
+	Clear bool    `       help:"Stop connecting through a profile by default."`
+}
+
+// Validate checks that exactly one of a name and --clear is given.
+func (cmd *ConfigUseCmd) Validate() error {
+	if cmd.Clear == (cmd.Name != nil) {
+		return fmt.Errorf("either a profile name or --clear is required")
+	}
+	return nil
+}
+
+// Run executes the ConfigUseCmd command, it does not need a connection to the mixer.
+func (cmd *ConfigUseCmd) Run(ctx *context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cmd.Clear {
+		cfg.Default = ""
+		if err := writeConfig(cfg); err != nil {
+			return err
+		}
+		fmt.Fprintln(ctx.Confirm, "Default profile cleared")
+		return nil
+	}
+
+	if _, ok := cfg.Profiles[*cmd.Name]; !ok {
+		return fmt.Errorf("profile %q does not exist", *cmd.Name)
+	}
+	cfg.Default = *cmd.Name
+	if err := writeConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Default profile set to: %s\n", *cmd.Name)
+	return nil
+}
+
+// ConfigRemoveCmd defines the command for removing a saved profile.
+type ConfigRemoveCmd struct {
+	Name string `arg:"" help:"The name of the profile." completion-predictor:"profile"`
+}
+
+// Run executes the ConfigRemoveCmd command, removing the profile from the default as well if it was the default.
+func (cmd *ConfigRemoveCmd) Run(ctx *context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[cmd.Name]; !ok {
+		return fmt.Errorf("profile %q does not exist", cmd.Name)
+	}
+	delete(cfg.Profiles, cmd.Name)
+	if cfg.Default == cmd.Name {
+		cfg.Default = ""
+	}
+	if err := writeConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Profile %q removed\n", cmd.Name)
+	return nil
+}
+
+// profile is the address of a mixer, saved under a name in the config file.
+type profile struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// defaultPort is the port profiles use when their address does not name one.
+const defaultPort = 10024
+
+// parseAddress reads an address of the form host or host:port into a profile.
+func parseAddress(address string) (profile, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		// no port, the whole address is the host
+		if address == "" {
+			return profile{}, fmt.Errorf("address cannot be empty")
+		}
+		return profile{Host: address, Port: defaultPort}, nil
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return profile{}, fmt.Errorf("invalid port %q in address %s", port, address)
+	}
+	return profile{Host: host, Port: p}, nil
+}
+
+// configFile is the layout of the config file in the user config directory.
+type configFile struct {
+	Profiles map[string]profile `json:"profiles"`
+	Default  string             `json:"default,omitempty"`
+}
+
+// configPath returns the location of the config file.
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "xair-cli", "config.json"), nil
+}
+
+// loadConfig reads the config file, a missing file is treated as empty.
+func loadConfig() (configFile, error) {
+	cfg := configFile{Profiles: map[string]profile{}}
+	path, err := configPath()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to decode config file %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]profile{}
+	}
+	return cfg, nil
+}
+
+// writeConfig replaces the config file with cfg.
+func writeConfig(cfg configFile) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// saveProfile adds a profile to the config file, replacing any profile of the same name.
+func saveProfile(name string, p profile) error {
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Profiles[name] = p
+	return writeConfig(cfg)
+}
+
+// lookupProfile returns the profile saved under name.
+func lookupProfile(name string) (profile, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return profile{}, err
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("profile %q does not exist", name)
+	}
+	return p, nil
+}
+
+// defaultProfile returns the name of the profile chosen with config use, or "" when there is none.
+func defaultProfile() (string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", err
+	}
+	return cfg.Default, nil
+}
+
+// predictProfiles completes the names of the saved profiles.
+func predictProfiles(complete.Args) []string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	return names
+}
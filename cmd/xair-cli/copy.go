@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alecthomas/kong"
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// mixerPairCmd is implemented by commands that copy between two mixers.
+// They connect to the addresses they return instead of the configured host.
+type mixerPairCmd interface {
+	addresses() (from, to string)
+}
+
+// mixerPair holds the clients of a mixerPairCmd, bound for its Run method.
+type mixerPair struct {
+	From *xair.XAirClient
+	To   *xair.XAirClient
+}
+
+// runMixerPair connects to both mixers of cmd and runs it. The other connection options, such as the timeout, still apply.
+// The destination is also bound as the context client.
+func runMixerPair(ctx *kong.Context, config Config, cmd mixerPairCmd, confirm io.Writer) error {
+	from, to := cmd.addresses()
+	undo, err := openUndoLog(config)
+	if err != nil {
+		return err
+	}
+
+	var clients []*xair.XAirClient
+	for _, address := range []string{from, to} {
+		p, err := parseAddress(address)
+		if err != nil {
+			return exitError{err, exitUsage}
+		}
+		config.Host, config.Port = p.Host, p.Port
+
+		client, err := connect(config, undo)
+		if err != nil {
+			return exitError{fmt.Errorf("failed to connect to X-Air device at %s: %w", address, err), exitConnection}
+		}
+		defer client.Close()
+
+		client.StartListening()
+		if _, err := client.RequestInfo(); err != nil {
+			return exitError{fmt.Errorf("no reply from X-Air device at %s: %w", address, err), exitConnection}
+		}
+		if err := validateIndexes(ctx, &client.Client); err != nil {
+			return exitError{err, exitUsage}
+		}
+		clients = append(clients, client)
+	}
+
+	ctx.Bind(
+		&context{Client: clients[1], Out: os.Stdout, Confirm: confirm, Progress: progressWriter(config.Quiet)},
+		&mixerPair{From: clients[0], To: clients[1]},
+	)
+	defer undo.commit(clients[1].Addr())
+	return withExitCode(ctx.Run())
+}
+
+// copyAddresses are the flags of the commands that copy between two mixers.
+type copyAddresses struct {
+	FromAddress string `help:"The address of the mixer to copy from, as host or host:port." required:""`
+	ToAddress   string `help:"The address of the mixer to copy to, as host or host:port."   required:""`
+}
+
+func (a *copyAddresses) addresses() (string, string) {
+	return a.FromAddress, a.ToAddress
+}
+
+// StripCopyCmd defines the command for copying every setting of a strip from one mixer to the same strip of another.
+type StripCopyCmd struct {
+	copyAddresses `embed:""`
+}
+
+// Run executes the StripCopyCmd command, reading the whole strip before writing any of it.
+func (cmd *StripCopyCmd) Run(ctx *context, pair *mixerPair, strip *StripCmdGroup) error {
+	snap, err := pair.From.Strip.Snapshot(strip.Index.Index)
+	if err != nil {
+		return fmt.Errorf("failed to read strip %d from %s: %w", strip.Index.Index, cmd.FromAddress, err)
+	}
+	if err := pair.To.Strip.ApplySnapshot(strip.Index.Index, snap); err != nil {
+		return fmt.Errorf("failed to write strip %d to %s: %w", strip.Index.Index, cmd.ToAddress, err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d copied from %s to %s\n", strip.Index.Index, cmd.FromAddress, cmd.ToAddress)
+	return nil
+}
+
+// BusCopyCmd defines the command for copying every setting of a bus from one mixer to the same bus of another.
+type BusCopyCmd struct {
+	copyAddresses `embed:""`
+}
+
+// Run executes the BusCopyCmd command, reading the whole bus before writing any of it.
+func (cmd *BusCopyCmd) Run(ctx *context, pair *mixerPair, bus *BusCmdGroup) error {
+	snap, err := pair.From.Bus.Snapshot(bus.Index.Index)
+	if err != nil {
+		return fmt.Errorf("failed to read bus %d from %s: %w", bus.Index.Index, cmd.FromAddress, err)
+	}
+	if err := pair.To.Bus.ApplySnapshot(bus.Index.Index, snap); err != nil {
+		return fmt.Errorf("failed to write bus %d to %s: %w", bus.Index.Index, cmd.ToAddress, err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Bus %d copied from %s to %s\n", bus.Index.Index, cmd.FromAddress, cmd.ToAddress)
+	return nil
+}
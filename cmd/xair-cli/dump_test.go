@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+func TestDumpConcurrencyMatchesSequential(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	for i := 1; i <= client.StripCount(); i++ {
+		mixer.Set(fmt.Sprintf("/ch/%02d/config/name", i), fmt.Sprintf("Strip %d", i))
+		mixer.Set(fmt.Sprintf("/ch/%02d/mix/fader", i), float32(i)/20)
+	}
+	for i := 1; i <= client.BusCount(); i++ {
+		mixer.Set(fmt.Sprintf("/bus/%d/config/name", i), fmt.Sprintf("Bus %d", i))
+	}
+
+	dump := func(concurrency int) string {
+		var out bytes.Buffer
+		cmd := DumpCmd{Sections: []string{"main", "strips", "buses"}, Concurrency: concurrency}
+		if err := cmd.Run(&context{Client: client, Out: &out, Confirm: io.Discard}); err != nil {
+			t.Fatalf("dump with concurrency %d failed: %v", concurrency, err)
+		}
+		return out.String()
+	}
+
+	sequential := dump(1)
+	if !strings.Contains(sequential, `"Strip 16"`) || !strings.Contains(sequential, `"Bus 6"`) {
+		t.Fatalf("dump is missing the names set on the mixer:\n%s", sequential)
+	}
+	if parallel := dump(maxDumpConcurrency); parallel != sequential {
+		t.Errorf("dump with concurrency %d differs from the sequential dump\nsequential:\n%s\nparallel:\n%s",
+			maxDumpConcurrency, sequential, parallel)
+	}
+}
+
+func TestDumpChangedOnly(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	// bring every strip to the built-in defaults, the fake mixer starts muted with mid-scale compressors
+	for i := 1; i <= client.StripCount(); i++ {
+		mixer.Set(fmt.Sprintf("/ch/%02d/mix/on", i), int32(1))
+		if err := client.Strip.Comp.ApplySnapshot(i, xair.DefaultCompSnapshot()); err != nil {
+			t.Fatalf("failed to reset the compressor of strip %d: %v", i, err)
+		}
+		flush(t, client)
+	}
+	mixer.Set("/ch/03/config/name", "Kick")
+	mixer.Set("/ch/05/eq/2/g", float32(0.7)) // +6 dB
+	mixer.Set("/ch/07/mix/fader", float32(0.25))
+
+	out, err := runCommand(t, client, "dump", "--sections", "strips", "--changed-only")
+	if err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	var got struct {
+		Strips map[string]struct {
+			Name *string `json:"name"`
+			Eq   *struct {
+				Bands map[string]struct {
+					Gain float64 `json:"gain"`
+				} `json:"bands"`
+			} `json:"eq"`
+		} `json:"strips"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("failed to decode %q: %v", out, err)
+	}
+	if len(got.Strips) != 2 {
+		t.Fatalf("got changes to %d strips, want strips 3 and 5 only:\n%s", len(got.Strips), out)
+	}
+	if s := got.Strips["3"]; s.Name == nil || *s.Name != "Kick" || s.Eq != nil {
+		t.Errorf("got strip 3 changes %s, want only the name Kick", out)
+	}
+	if s := got.Strips["5"]; s.Name != nil || s.Eq == nil || len(s.Eq.Bands) != 1 || math.Abs(s.Eq.Bands["2"].Gain-6) > 0.01 {
+		t.Errorf("got strip 5 changes %s, want only EQ band 2 at +6 dB", out)
+	}
+}
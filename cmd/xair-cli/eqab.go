@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// eqABState is the A/B comparison of one EQ: the two stored settings and the one the mixer is on.
+// B is only stored once the EQ is first toggled, until then it is whatever the mixer holds.
+type eqABState struct {
+	Active string           `json:"active"`
+	A      xair.EqSnapshot  `json:"a"`
+	B      *xair.EqSnapshot `json:"b,omitempty"`
+}
+
+// eqABPath returns the file the A/B comparisons are stored in, keyed by strip or bus.
+func eqABPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "xair-cli", "eq-ab.json"), nil
+}
+
+// loadEqAB reads the A/B comparisons, a missing file holds none.
+func loadEqAB() (map[string]eqABState, error) {
+	states := map[string]eqABState{}
+	path, err := eqABPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return states, nil
+		}
+		return nil, fmt.Errorf("failed to read EQ A/B file: %w", err)
+	}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("failed to decode EQ A/B file %s: %w", path, err)
+	}
+	return states, nil
+}
+
+// saveEqAB replaces the A/B comparisons with states.
+func saveEqAB(states map[string]eqABState) error {
+	path, err := eqABPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode EQ A/B file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write EQ A/B file: %w", err)
+	}
+	return nil
+}
+
+// storeEqAB starts a new comparison for key with current as setting A.
+// The mixer is then on B, so the changes made before the first toggle make up setting B.
+func storeEqAB(key string, current xair.EqSnapshot) error {
+	states, err := loadEqAB()
+	if err != nil {
+		return err
+	}
+	states[key] = eqABState{Active: "B", A: current}
+	return saveEqAB(states)
+}
+
+// toggleEqAB keeps current as the setting the mixer is on and applies the other setting of the comparison for key.
+// It returns the name of the setting applied, the comparison only switches once apply succeeds.
+func toggleEqAB(key string, current xair.EqSnapshot, apply func(xair.EqSnapshot) error) (string, error) {
+	states, err := loadEqAB()
+	if err != nil {
+		return "", err
+	}
+	state, ok := states[key]
+	if !ok {
+		return "", fmt.Errorf("no A/B comparison stored for %s, run eq ab store first", key)
+	}
+
+	next := state.A
+	if state.Active == "A" {
+		// A is only active once B was stored by a toggle, a file edited by hand may still lack it
+		if state.B == nil {
+			return "", fmt.Errorf("the A/B comparison for %s has no setting B, run eq ab store again", key)
+		}
+		state.A = current
+		next = *state.B
+		state.Active = "B"
+	} else {
+		state.B = &current
+		state.Active = "A"
+	}
+	if err := apply(next); err != nil {
+		return "", fmt.Errorf("failed to apply EQ %s: %w", state.Active, err)
+	}
+
+	states[key] = state
+	if err := saveEqAB(states); err != nil {
+		return "", err
+	}
+	return state.Active, nil
+}
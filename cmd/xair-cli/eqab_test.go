@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+func TestEqABRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	a := xair.EqSnapshot{On: true, Bands: []xair.EqBandSnapshot{{Type: "peq", Frequency: 1000, Gain: 3, Q: 2}}}
+	b := xair.EqSnapshot{On: true, Bands: []xair.EqBandSnapshot{{Type: "peq", Frequency: 2000, Gain: -4, Q: 1}}}
+
+	if err := storeEqAB("strip 1", a); err != nil {
+		t.Fatalf("failed to store: %v", err)
+	}
+
+	var applied xair.EqSnapshot
+	apply := func(next xair.EqSnapshot) error {
+		applied = next
+		return nil
+	}
+	// The mixer was changed to b after the store, the first toggle keeps it as B and goes back to A.
+	for i, step := range []struct {
+		current xair.EqSnapshot
+		active  string
+		applied xair.EqSnapshot
+	}{
+		{b, "A", a},
+		{a, "B", b},
+		{b, "A", a},
+	} {
+		active, err := toggleEqAB("strip 1", step.current, apply)
+		if err != nil {
+			t.Fatalf("toggle %d failed: %v", i+1, err)
+		}
+		if active != step.active {
+			t.Errorf("toggle %d: got active setting %s, want %s", i+1, active, step.active)
+		}
+		if !reflect.DeepEqual(applied, step.applied) {
+			t.Errorf("toggle %d: applied %+v, want %+v", i+1, applied, step.applied)
+		}
+	}
+}
+
+func TestEqABToggleErrors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	apply := func(xair.EqSnapshot) error { return nil }
+
+	if _, err := toggleEqAB("bus 2", xair.EqSnapshot{}, apply); err == nil {
+		t.Error("toggle without a stored comparison succeeded, want an error")
+	}
+
+	if err := saveEqAB(map[string]eqABState{"bus 2": {Active: "A"}}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if _, err := toggleEqAB("bus 2", xair.EqSnapshot{}, apply); err == nil {
+		t.Error("toggle to a missing setting B succeeded, want an error")
+	}
+
+	if err := storeEqAB("bus 2", xair.EqSnapshot{}); err != nil {
+		t.Fatalf("failed to store: %v", err)
+	}
+	failed := errors.New("mixer gone")
+	if _, err := toggleEqAB("bus 2", xair.EqSnapshot{}, func(xair.EqSnapshot) error { return failed }); !errors.Is(err, failed) {
+		t.Fatalf("got error %v, want %v", err, failed)
+	}
+	states, err := loadEqAB()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got := states["bus 2"]; got.Active != "B" || got.B != nil {
+		t.Errorf("failed toggle changed the comparison to %+v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/kong"
+)
+
+// example is a single curated invocation, shown by the examples command of its group.
+type example struct {
+	Description string
+	Args        string
+}
+
+// examples holds the curated invocations for each command group, keyed by the group's command name.
+var examples = map[string][]example{
+	"main": {
+		{"Fade out main L/R all the way to -∞ over a 5s duration", "main fadeout"},
+		{"Mute main L/R with a 200ms fade instead of a hard cut", "main mute --fade 200ms true"},
+		{"Lower the main L/R fader by 3 dB", "main fader -3"},
+		{"Set the compressor threshold and ratio of main L/R in one go", "main comp set --threshold=-18 --ratio=4"},
+		{"Reset the EQ of main L/R, leaving the compressor alone", "main reset --section eq"},
+	},
+	"strip": {
+		{"Toggle the mute state of strip 01", "strip 1 mute toggle"},
+		{"Set the fader of strip 02 to -6 dB", "strip 2 fader -6"},
+		{"Take strip 03 out of the main mix while keeping its bus sends", "strip 3 main false"},
+		{"Move the fader of strip 04 to 0 dB, making up the difference with the trim", "strip 4 normalize"},
+		{"Raise the bus 03 send of strip 01 by 3 dB", "strip 1 send 3 +3"},
+		{"Pan the send of strip 02 to the linked stereo buses 01/02 hard left", "strip 2 send-pan 1 -100"},
+		{"Set the sends of strip 01 to buses 01, 02 and 03 to -6 dB together", "strip 1 send --buses 1,2,3 --level -6"},
+		{"Bring strips 01, 03 and the strip named 'Kick' to -6 dB together", "strip fader-match --to=-6 1 3 Kick"},
+		{"Swap strips 04 and 05, including their input routing", "strip swap 4 5 --include routing"},
+		{"Repatch strip 07 to IN 09, taking the headamp gain along", "strip 7 source in09 --carry-gain"},
+		{"Copy strip 05 from the FOH desk to the monitor desk", "strip 5 copy --from-address 192.168.0.2 --to-address 192.168.0.3"},
+		{"Show every send of strip 01 as JSON", "strip 1 sends --json"},
+		{"Show an overview of every setting of strip 01", "strip 1 show"},
+		{"Export the EQ response of strip 01 as CSV for plotting", "strip 1 eq curve --points 256 > eq.csv"},
+		{"Boost EQ band 03 of strip 02 by 12 dB for 3 seconds to hear it, then restore it", "strip 2 eq 3 audition"},
+		{"Copy EQ band 02 of strip 01 to band 03 of strip 05", "strip 1 eq 2 copy-to 5 --band 3"},
+		{"Switch strip 01 between the EQ stored with 'eq ab store' and the changes made since", "strip 1 eq ab toggle"},
+		{"Raise the compressor threshold of strip 01 by 3 dB", "strip 1 comp threshold +3"},
+		{"Set the gate threshold, range and release of strip 01 in one go", "strip 1 gate set --threshold=-40 --range 20 --release 200"},
+	},
+	"bus": {
+		{"Fade in bus 02 to 0 dB over 10 seconds", "bus 2 fadein --duration 10s"},
+		{"Name bus 01 'Drummer'", "bus 1 name Drummer"},
+		{"Show which strips feed bus 02, hiding the sends that are off", "bus 2 inputs --nonzero"},
+		{"Set band 10 of the graphic EQ of bus 01 to -4 dB (geq or teq mode only)", "bus 1 eq graphic 10 -4"},
+	},
+	"headamp": {
+		{"Set the gain of headamp 01 to 30 dB", "headamp 1 gain 30"},
+		{"Turn on phantom power for headamp 02", "headamp 2 phantom true"},
+	},
+	"snapshot": {
+		{"List the saved snapshots", "snapshot list"},
+		{"Save the current mixer state to snapshot 20", "snapshot 20 save 'twitch live'"},
+		{"Load snapshot 20", "snapshot 20 load"},
+	},
+	"link": {
+		{"Show which strips and buses are linked", "link show"},
+	},
+	"monitor": {
+		{"Sum the monitor bus to mono to check mono compatibility", "monitor mono true"},
+		{"Listen to the main L/R mix pre-fader on the phones", "monitor source lrpfl"},
+		{"Mirror the main mix into bus 03, 6 dB down", "monitor mirror 3 --offset=-6"},
+	},
+	"solo": {
+		{"Turn the solo bus down by 6 dB", "solo level -6"},
+		{"Dim the solo bus, the same as main dim", "solo dim true"},
+	},
+	"preset": {
+		{"Save strip 02 as a preset", "preset save LeadVox --from 2"},
+		{"Apply the gate, EQ and compressor of a preset to strip 05", "preset apply LeadVox --to 5"},
+		{"Apply only the EQ of a preset", "preset apply LeadVox --to 5 --sections eq"},
+	},
+	"config": {
+		{"Save the address of the mixer at the venue as a profile", "config add venue --address 192.168.1.20"},
+		{"Connect through a saved profile", "--profile venue main fader"},
+		{"Save the address used by a command as a profile", "--host 192.168.1.20 --save venue main fader"},
+		{"Connect through the venue profile whenever no host is given", "config use venue"},
+		{"List the saved profiles", "config list"},
+	},
+}
+
+// ExamplesCmd defines the command for printing the example invocations of the command group it belongs to.
+type ExamplesCmd struct{}
+
+// Run executes the ExamplesCmd command, looking up the examples for its parent command group.
+func (cmd *ExamplesCmd) Run(kctx *kong.Context) error {
+	group := kctx.Selected().Parent.Name
+	for i, ex := range examples[group] {
+		if i > 0 {
+			fmt.Fprintln(kctx.Stdout)
+		}
+		fmt.Fprintf(kctx.Stdout, "# %s\n%s %s\n", ex.Description, kctx.Model.Name, ex.Args)
+	}
+	return nil
+}
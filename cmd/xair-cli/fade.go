@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// FadeCmd defines the command for running several fades at the same time, for example a crossfade between two buses.
+type FadeCmd struct {
+	In       []string      `help:"Targets to fade in, as kind:index (e.g. strip:3, bus:2 or main)."             sep:","`
+	Out      []string      `help:"Targets to fade out, as kind:index (e.g. strip:3, bus:1 or main)."            sep:","`
+	Duration time.Duration `help:"The duration of the fades."                                                   default:"5s"`
+	InLevel  float64       `help:"The fader level (in dB) that faded in targets end at."                        default:"0.0"`
+	OutLevel float64       `help:"The fader level (in dB) that faded out targets end at."                       default:"-90.0"`
+	At       string        `help:"Start the fades at the next occurrence of a time of day (HH:MM or HH:MM:SS)."`
+	Taper    string        `help:"Step evenly in dB (linear) or along the fader travel (audio)."                default:"linear" enum:"linear,audio"`
+}
+
+// fadeTarget is a single fader that can be faded.
+type fadeTarget struct {
+	label string
+	get   func() (float64, error)
+	set   func(float64) error
+}
+
+// Validate checks that at least one target was given.
+func (cmd *FadeCmd) Validate() error {
+	if len(cmd.In) == 0 && len(cmd.Out) == 0 {
+		return fmt.Errorf("at least one --in or --out target is required")
+	}
+	return nil
+}
+
+// Run executes the FadeCmd command.
+// All current levels are read first, then every fade runs in its own goroutine against the shared client.
+func (cmd *FadeCmd) Run(ctx *context) error {
+	if err := scheduleFade(cmd.At); err != nil {
+		return err
+	}
+
+	type fade struct {
+		target   fadeTarget
+		from, to float64
+	}
+
+	var fades []fade
+	for _, group := range []struct {
+		specs []string
+		to    float64
+	}{
+		{cmd.In, cmd.InLevel},
+		{cmd.Out, cmd.OutLevel},
+	} {
+		for _, spec := range group.specs {
+			target, err := parseFadeTarget(ctx, spec)
+			if err != nil {
+				return err
+			}
+			from, err := target.get()
+			if err != nil {
+				return fmt.Errorf("failed to get current fader level of %s: %w", target.label, err)
+			}
+			fades = append(fades, fade{target: target, from: from, to: group.to})
+		}
+	}
+
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	var wg sync.WaitGroup
+	errs := make([]error, len(fades))
+	for i, f := range fades {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = rampFader(f.target.set, f.from, f.to, cmd.Duration, cmd.Taper, bar)
+		}()
+	}
+	wg.Wait()
+	bar.done()
+
+	for i, f := range fades {
+		if errs[i] != nil {
+			return fmt.Errorf("failed to fade %s: %w", f.target.label, errs[i])
+		}
+		fmt.Fprintf(ctx.Confirm, "%s fade complete. Final level: %.2f dB\n", f.target.label, f.to)
+	}
+	return nil
+}
+
+// rampFader moves a fader from one level to another in steps of about 1 dB spread evenly over duration.
+// With the linear taper the steps are equal in dB. With the audio taper they are equal on the fader travel,
+// which spends less of the fade in the inaudible range near -∞ and more where the change is heard.
+// Each step is reported to bar.
+func rampFader(set func(float64) error, from, to float64, duration time.Duration, taper string, bar *progress) error {
+	steps := math.Max(1, math.Ceil(math.Abs(to-from)))
+	stepDuration := time.Duration(float64(duration) / steps)
+	for i := 1; i <= int(steps); i++ {
+		level := taperLevel(taper, from, to, float64(i)/steps)
+		if i == int(steps) {
+			level = to
+		}
+		if err := set(level); err != nil {
+			return err
+		}
+		bar.update(float64(i) / steps)
+		time.Sleep(stepDuration)
+	}
+	return nil
+}
+
+// fadeMute mutes or unmutes with a ramp of the fader around the mute, so the change does not click.
+// Muting fades the fader down, mutes, then puts the fader back while muted. Unmuting drops the fader,
+// unmutes, then fades it back up. The fader therefore keeps the pre-mute level between the two.
+func fadeMute(target fadeTarget, setMute func(bool) error, mute bool, duration time.Duration, bar *progress) error {
+	level, err := target.get()
+	if err != nil {
+		return err
+	}
+
+	if mute {
+		if err := rampFader(target.set, level, -90, duration, "audio", bar); err != nil {
+			return err
+		}
+		if err := setMute(true); err != nil {
+			return err
+		}
+		return target.set(level)
+	}
+
+	if err := target.set(-90); err != nil {
+		return err
+	}
+	if err := setMute(false); err != nil {
+		return err
+	}
+	return rampFader(target.set, -90, level, duration, "audio", bar)
+}
+
+// taperLevel returns the level at fraction t, from 0 to 1, of the way from one level to another.
+func taperLevel(taper string, from, to, t float64) float64 {
+	if taper == "audio" {
+		start, end := xair.PercentFromLevel(from), xair.PercentFromLevel(to)
+		return xair.LevelFromPercent(start + (end-start)*t)
+	}
+	return from + (to-from)*t
+}
+
+// splitFadeSpec splits a "kind:index" spec, the index is 0 when the kind takes none.
+func splitFadeSpec(spec string) (string, int, error) {
+	kind, rawIndex, found := strings.Cut(spec, ":")
+	if !found {
+		return kind, 0, nil
+	}
+	index, err := strconv.Atoi(rawIndex)
+	if err != nil || index < 1 {
+		return "", 0, fmt.Errorf("invalid index in fade target %q", spec)
+	}
+	return kind, index, nil
+}
+
+// parseFadeTarget resolves a "kind:index" spec, such as strip:3, bus:2 or main, to a fader.
+func parseFadeTarget(ctx *context, spec string) (fadeTarget, error) {
+	kind, index, err := splitFadeSpec(spec)
+	if err != nil {
+		return fadeTarget{}, err
+	}
+
+	switch kind {
+	case "strip", "bus":
+		if index == 0 {
+			return fadeTarget{}, fmt.Errorf("fade target %q needs an index, e.g. %s:1", spec, kind)
+		}
+		if err := ctx.Client.ValidateIndex(kind, index); err != nil {
+			return fadeTarget{}, fmt.Errorf("invalid fade target %q: %w", spec, err)
+		}
+	case "main":
+		if index != 0 {
+			return fadeTarget{}, fmt.Errorf("fade target %q takes no index", spec)
+		}
+	}
+
+	switch kind {
+	case "strip":
+		return fadeTarget{
+			label: fmt.Sprintf("Strip %d", index),
+			get:   func() (float64, error) { return ctx.Client.Strip.Fader(index) },
+			set:   func(level float64) error { return ctx.Client.Strip.SetFader(index, level) },
+		}, nil
+	case "bus":
+		return fadeTarget{
+			label: fmt.Sprintf("Bus %d", index),
+			get:   func() (float64, error) { return ctx.Client.Bus.Fader(index) },
+			set:   func(level float64) error { return ctx.Client.Bus.SetFader(index, level) },
+		}, nil
+	case "main":
+		return fadeTarget{
+			label: "Main L/R",
+			get:   ctx.Client.Main.Fader,
+			set:   ctx.Client.Main.SetFader,
+		}, nil
+	}
+	return fadeTarget{}, fmt.Errorf("unknown fade target %q, expected strip:<n>, bus:<n> or main", spec)
+}
+
+// scheduleFade waits until the next occurrence of the time of day given by --at, it returns immediately if at is empty.
+func scheduleFade(at string) error {
+	if at == "" {
+		return nil
+	}
+
+	start, err := nextOccurrence(at, time.Now())
+	if err != nil {
+		return err
+	}
+	log.Infof("Fade scheduled to start at %s", start.Format(time.DateTime))
+	return waitUntil(start)
+}
+
+// nextOccurrence returns the first moment at or after now whose time of day is clock, given as HH:MM or HH:MM:SS.
+func nextOccurrence(clock string, now time.Time) (time.Time, error) {
+	var t time.Time
+	var err error
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err = time.ParseInLocation(layout, clock, now.Location()); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected HH:MM or HH:MM:SS", clock)
+	}
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+	if next.Before(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next, nil
+}
+
+// waitUntil blocks until t, logging a countdown every second at debug level. It returns an error if the wait is interrupted.
+func waitUntil(t time.Time) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-ticker.C:
+			log.Debugf("Fade starts in %s", time.Until(t).Round(time.Second))
+		case <-interrupt:
+			return fmt.Errorf("interrupted while waiting to start the fade at %s", t.Format(time.TimeOnly))
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"io"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRampFaderIsMonotonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to float64
+	}{
+		{"fade in from -inf", -90, 0},
+		{"fade out to -inf", 0, -90},
+		{"fade up to the maximum", -20, 10},
+		{"fade down a little", -6, -10.5},
+		{"fade by less than a step", -10, -10.25},
+	}
+	for _, taper := range []string{"linear", "audio"} {
+		for _, tt := range tests {
+			t.Run(taper+"/"+tt.name, func(t *testing.T) {
+				var levels []float64
+				set := func(level float64) error {
+					levels = append(levels, level)
+					return nil
+				}
+				if err := rampFader(set, tt.from, tt.to, 0, taper, nil); err != nil {
+					t.Fatalf("rampFader failed: %v", err)
+				}
+
+				if got := levels[len(levels)-1]; got != tt.to {
+					t.Errorf("fade ended at %g dB, want %g dB", got, tt.to)
+				}
+				lo, hi := math.Min(tt.from, tt.to), math.Max(tt.from, tt.to)
+				prev := tt.from
+				for i, level := range levels {
+					if level < lo || level > hi {
+						t.Errorf("step %d at %g dB is outside [%g, %g]", i, level, lo, hi)
+					}
+					if (tt.to > tt.from && level < prev) || (tt.to < tt.from && level > prev) {
+						t.Errorf("step %d at %g dB moves back from %g dB", i, level, prev)
+					}
+					prev = level
+				}
+			})
+		}
+	}
+}
+
+func TestStripFadeinRampsToTarget(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	mixer.Set("/ch/01/mix/fader", float32(0))
+
+	cmd := StripFadeinCmd{Target: -6, Taper: "audio"}
+	strip := &StripCmdGroup{}
+	strip.Index.Index = 1
+	if err := cmd.Run(&context{Client: client, Out: io.Discard, Confirm: io.Discard}, strip); err != nil {
+		t.Fatalf("fade-in failed: %v", err)
+	}
+	level, err := client.Strip.Fader(1)
+	if err != nil {
+		t.Fatalf("failed to read fader: %v", err)
+	}
+	if math.Abs(level+6) > 0.1 {
+		t.Errorf("got fader level %.2f dB, want -6 dB", level)
+	}
+}
+
+func TestParseFadeTargetValidatesIndex(t *testing.T) {
+	client, _ := newTestClient(t, "XR18")
+	ctx := &context{Client: client, Out: io.Discard, Confirm: io.Discard}
+	tests := []struct {
+		spec  string
+		valid bool
+	}{
+		{"strip:1", true},
+		{"bus:2", true},
+		{"main", true},
+		{"strip", false},
+		{"bus", false},
+		{"main:1", false},
+		{"strip:0", false},
+		{"strip:99", false},
+		{"bus:x", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := parseFadeTarget(ctx, tt.spec)
+			if (err == nil) != tt.valid {
+				t.Errorf("parseFadeTarget(%q) error = %v, want valid %t", tt.spec, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestFadeRunsTargetsConcurrently(t *testing.T) {
+	client, _ := newTestClient(t, "XR18")
+	if err := client.Strip.SetFader(1, -90); err != nil {
+		t.Fatalf("failed to set strip fader: %v", err)
+	}
+	if err := client.Bus.SetFader(2, 0); err != nil {
+		t.Fatalf("failed to set bus fader: %v", err)
+	}
+
+	const duration = 300 * time.Millisecond
+	start := time.Now()
+	if _, err := runCommand(t, client, "fade", "--in", "strip:1", "--out", "bus:2", "--duration", duration.String()); err != nil {
+		t.Fatalf("fade failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 2*duration {
+		t.Errorf("two fades of %s took %s, want them to run at the same time", duration, elapsed)
+	}
+
+	strip, err := client.Strip.Fader(1)
+	if err != nil {
+		t.Fatalf("failed to read strip fader: %v", err)
+	}
+	bus, err := client.Bus.Fader(2)
+	if err != nil {
+		t.Fatalf("failed to read bus fader: %v", err)
+	}
+	if math.Abs(strip) > 0.1 || bus > -89 {
+		t.Errorf("got strip %.2f dB and bus %.2f dB, want 0 dB and -90 dB", strip, bus)
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	now := time.Date(2025, time.December, 31, 23, 59, 30, 0, time.Local)
+	tests := []struct {
+		clock string
+		want  time.Time
+	}{
+		{"23:59:45", time.Date(2025, time.December, 31, 23, 59, 45, 0, time.Local)},
+		{"23:59:30", now},
+		{"00:00:10", time.Date(2026, time.January, 1, 0, 0, 10, 0, time.Local)},
+		{"23:59", time.Date(2026, time.January, 1, 23, 59, 0, 0, time.Local)},
+		{"12:00", time.Date(2026, time.January, 1, 12, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.clock, func(t *testing.T) {
+			got, err := nextOccurrence(tt.clock, now)
+			if err != nil {
+				t.Fatalf("nextOccurrence failed: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextOccurrenceRejectsInvalidTimes(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.Local)
+	for _, clock := range []string{"25:00", "12:60", "noon", "12"} {
+		if _, err := nextOccurrence(clock, now); err == nil {
+			t.Errorf("nextOccurrence(%q) succeeded, want an error", clock)
+		}
+	}
+}
+
+func TestFadeMuteRampsAroundTheMute(t *testing.T) {
+	tests := []struct {
+		name string
+		mute bool
+	}{
+		{"mute ramps down then mutes", true},
+		{"unmute unmutes then ramps up", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const level = -6.0
+			var faders []float64
+			muteAt := -1 // the number of fader sets before the mute was set
+			target := fadeTarget{
+				get: func() (float64, error) { return level, nil },
+				set: func(l float64) error {
+					faders = append(faders, l)
+					return nil
+				},
+			}
+			setMute := func(m bool) error {
+				if m != tt.mute {
+					t.Errorf("mute set to %t, want %t", m, tt.mute)
+				}
+				muteAt = len(faders)
+				return nil
+			}
+			if err := fadeMute(target, setMute, tt.mute, 0, nil); err != nil {
+				t.Fatalf("fadeMute failed: %v", err)
+			}
+			if muteAt < 1 || muteAt >= len(faders) {
+				t.Fatalf("mute set after %d of %d fader sets, want the fader moved on both sides", muteAt, len(faders))
+			}
+
+			before, after := faders[:muteAt], faders[muteAt:]
+			if tt.mute {
+				// the ramp reaches -inf before muting, then the pre-mute level is put back
+				if got := before[len(before)-1]; got != -90 {
+					t.Errorf("fader at %g dB when muting, want -90 dB", got)
+				}
+				for i := 1; i < len(before); i++ {
+					if before[i] > before[i-1] {
+						t.Errorf("ramp down rose from %g to %g dB", before[i-1], before[i])
+					}
+				}
+				if len(after) != 1 || after[0] != level {
+					t.Errorf("fader set to %v after muting, want [%g]", after, level)
+				}
+				return
+			}
+			// the fader drops to -inf before unmuting, then ramps back up to the pre-mute level
+			if len(before) != 1 || before[0] != -90 {
+				t.Errorf("fader set to %v before unmuting, want [-90]", before)
+			}
+			for i := 1; i < len(after); i++ {
+				if after[i] < after[i-1] {
+					t.Errorf("ramp up fell from %g to %g dB", after[i-1], after[i])
+				}
+			}
+			if got := after[len(after)-1]; got != level {
+				t.Errorf("ramp up ended at %g dB, want %g dB", got, level)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// GateSetFlags holds the flags of the gate set command, omitted flags leave the parameter untouched.
+type GateSetFlags struct {
+	Mode      *string  `help:"The gate mode to set."                 enum:"exp2,exp3,exp4,gate,duck"`
+	Threshold *float64 `help:"The gate threshold to set (in dB)."`
+	Range     *float64 `help:"The gate range to set (in dB)."`
+	Attack    *float64 `help:"The gate attack time to set (in ms)."`
+	Hold      *float64 `help:"The gate hold time to set (in ms)."`
+	Release   *float64 `help:"The gate release time to set (in ms)."`
+}
+
+// params converts the flags into gate parameters, it returns an error if no flag was given.
+func (f *GateSetFlags) params() (xair.GateParams, error) {
+	params := xair.GateParams{
+		Mode:      f.Mode,
+		Threshold: f.Threshold,
+		Range:     f.Range,
+		Attack:    f.Attack,
+		Hold:      f.Hold,
+		Release:   f.Release,
+	}
+	if params == (xair.GateParams{}) {
+		return params, fmt.Errorf("at least one of --mode, --threshold, --range, --attack, --hold or --release must be provided")
+	}
+	return params, nil
+}
+
+// apply sets the provided parameters on gate and prints a confirmation for each of them, prefixed by label.
+func (f *GateSetFlags) apply(out io.Writer, gate *xair.Gate, index int, label string) error {
+	params, err := f.params()
+	if err != nil {
+		return err
+	}
+	if err := gate.SetAll(index, params); err != nil {
+		return fmt.Errorf("failed to set %s gate parameters: %w", label, err)
+	}
+
+	if f.Mode != nil {
+		fmt.Fprintf(out, "%s gate mode set to: %s\n", label, *f.Mode)
+	}
+	if f.Threshold != nil {
+		fmt.Fprintf(out, "%s gate threshold set to: %.2f dB\n", label, *f.Threshold)
+	}
+	if f.Range != nil {
+		fmt.Fprintf(out, "%s gate range set to: %.2f dB\n", label, *f.Range)
+	}
+	if f.Attack != nil {
+		fmt.Fprintf(out, "%s gate attack time set to: %.2f ms\n", label, *f.Attack)
+	}
+	if f.Hold != nil {
+		fmt.Fprintf(out, "%s gate hold time set to: %.2f ms\n", label, *f.Hold)
+	}
+	if f.Release != nil {
+		fmt.Fprintf(out, "%s gate release time set to: %.2f ms\n", label, *f.Release)
+	}
+	return nil
+}
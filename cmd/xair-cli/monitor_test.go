@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMirrorLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		fader  float64
+		muted  bool
+		offset float64
+		want   float64
+	}{
+		{"fader copied", -12, false, 0, -12},
+		{"offset added", -12, false, -6, -18},
+		{"muted strip silent", -12, true, 0, -90},
+		{"strip at -inf stays silent", -90, false, 6, -90},
+		{"clamped to the send maximum", 8, false, 6, 10},
+		{"clamped to -inf", -80, false, -20, -90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mirrorLevel(tt.fader, tt.muted, tt.offset); got != tt.want {
+				t.Errorf("mirrorLevel(%g, %t, %g) = %g, want %g", tt.fader, tt.muted, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonitorMirrorCarriesPanToStereoBus(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	mixer.Set("/config/buslink/1-2", int32(1))
+	mixer.Set("/ch/03/mix/pan", float32(0.25))
+
+	cmd := MonitorMirrorCmd{Bus: 1}
+	if err := cmd.Run(&context{Client: client, Out: io.Discard, Confirm: io.Discard}); err != nil {
+		t.Fatalf("monitor mirror failed: %v", err)
+	}
+	pan, err := client.Strip.SendPan(3, 1)
+	if err != nil {
+		t.Fatalf("failed to read send pan: %v", err)
+	}
+	if pan != -50 {
+		t.Errorf("got strip 3 send pan %g, want -50", pan)
+	}
+}
+
+func TestMonitorMirrorRefusesPostFaderSends(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	mixer.Set("/ch/02/mix/03/tap", int32(4))
+
+	cmd := MonitorMirrorCmd{Bus: 3}
+	err := cmd.Run(&context{Client: client, Out: io.Discard, Confirm: io.Discard})
+	if err == nil || !strings.Contains(err.Error(), "after its fader") {
+		t.Fatalf("got error %v, want a refusal of the post-fader send", err)
+	}
+	if _, err := client.Strip.SendLevel(1, 3); err != nil {
+		t.Fatalf("failed to read send level: %v", err)
+	}
+	if got := mixer.Value("/ch/01/mix/03/level"); got != nil {
+		t.Errorf("send level of strip 1 was written before the refusal: %v", got)
+	}
+}
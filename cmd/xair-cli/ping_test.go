@@ -0,0 +1,28 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPingReportsLatencyAsJSON(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	const delay = 20 * time.Millisecond
+	mixer.Delay("/xinfo", delay)
+
+	out, err := runCommand(t, client, "ping", "--json")
+	if err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	var result pingResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to decode %q: %v", out, err)
+	}
+	if result.Address != client.Addr() {
+		t.Errorf("got address %q, want %q", result.Address, client.Addr())
+	}
+	if result.Latency < float64(delay/time.Millisecond) {
+		t.Errorf("got latency %g ms, want at least %v", result.Latency, delay)
+	}
+}
@@ -11,6 +11,8 @@ type SnapshotCmdGroup struct {
 		Load   LoadCmd   `help:"Load a mixer state from a snapshot."         cmd:"load"`
 		Delete DeleteCmd `help:"Delete a snapshot."                      cmd:"delete"`
 	} `help:"The index of the snapshot."            arg:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
 }
 
 type ListCmd struct {
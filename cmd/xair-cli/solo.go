@@ -0,0 +1,40 @@
+package main
+
+import "fmt"
+
+// SoloCmdGroup defines the commands related to the solo bus, which the monitor source feeds.
+// Dim is the same parameter as main dim, so it shares its command.
+type SoloCmdGroup struct {
+	Level SoloLevelCmd `help:"Get or set the level of the solo bus."                   cmd:""`
+	Dim   MainDimCmd   `help:"Get or set the dim state of the solo bus, as main dim." cmd:""`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
+}
+
+// SoloLevelCmd defines the command for getting or setting the level of the solo bus.
+type SoloLevelCmd struct {
+	Level *relativeFloat `arg:"" help:"The level to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." optional:""`
+}
+
+// Run executes the SoloLevelCmd command, either retrieving the current solo level or setting it based on the provided argument.
+func (cmd *SoloLevelCmd) Run(ctx *context) error {
+	if cmd.Level == nil {
+		resp, err := ctx.Client.MonitorLevel()
+		if err != nil {
+			return fmt.Errorf("failed to get solo level: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Solo level: %.2f dB\n", resp)
+		return nil
+	}
+
+	level, err := cmd.Level.resolve(ctx.Client.MonitorLevel, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get current solo level: %w", err)
+	}
+
+	if err := ctx.Client.SetMonitorLevel(level); err != nil {
+		return fmt.Errorf("failed to set solo level: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Solo level set to: %.2f dB%s\n", level, cmd.Level.delta("dB"))
+	return nil
+}
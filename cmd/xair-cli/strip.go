@@ -1,32 +1,229 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
 
-	"github.com/alecthomas/kong"
+	"github.com/charmbracelet/log"
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
 )
 
 // StripCmdGroup defines the command group for controlling the strips of the mixer, including commands for getting and setting various parameters such as mute state, fader level, send levels, and EQ settings.
 type StripCmdGroup struct {
+	Swap       StripSwapCmd       `help:"Swap the settings of two strips. Input routing is only swapped with --include routing." cmd:""`
+	FaderMatch StripFaderMatchCmd `help:"Set several strips to the same fader level."                                              cmd:"fader-match"`
+
 	Index struct {
-		Index   int             `arg:"" help:"The index of the strip. (1-based indexing)"`
-		Mute    StripMuteCmd    `       help:"Get or set the mute state of the strip." cmd:""`
-		Fader   StripFaderCmd   `     help:"Get or set the fader level of the strip." cmd:""`
-		Fadein  StripFadeinCmd  `      help:"Fade in the strip over a specified duration." cmd:""`
-		Fadeout StripFadeoutCmd `     help:"Fade out the strip over a specified duration." cmd:""`
-		Send    StripSendCmd    `      help:"Get or set the send level for a specific bus." cmd:""`
-		Name    StripNameCmd    `      help:"Get or set the name of the strip." cmd:""`
-
-		Gate StripGateCmdGroup `     help:"Commands related to the strip gate." cmd:"gate"`
-		Eq   StripEqCmdGroup   `       help:"Commands related to the strip EQ." cmd:"eq"`
-		Comp StripCompCmdGroup `      help:"Commands related to the strip compressor." cmd:"comp"`
+		Index     int               `arg:"" help:"The index of the strip. (1-based indexing)"`
+		Mute      StripMuteCmd      `       help:"Get or set the mute state of the strip." cmd:""`
+		Fader     StripFaderCmd     `     help:"Get or set the fader level of the strip." cmd:""`
+		Pan       StripPanCmd       `       help:"Get or set the pan position of the strip." cmd:""`
+		Fadein    StripFadeinCmd    `      help:"Fade in the strip over a specified duration." cmd:""`
+		Fadeout   StripFadeoutCmd   `     help:"Fade out the strip over a specified duration." cmd:""`
+		Send      StripSendCmd      `      help:"Get or set the send level for a specific bus." cmd:""`
+		SendPan   StripSendPanCmd   `      help:"Get or set the pan of the send to a stereo bus." cmd:"send-pan"`
+		Sends     StripSendsCmd     `      help:"Show the send level for every bus." cmd:""`
+		Show      StripShowCmd      `      help:"Show an overview of every setting of the strip." cmd:""`
+		Copy      StripCopyCmd      `      help:"Copy the strip from one mixer to the same strip of another." cmd:""`
+		Name      StripNameCmd      `      help:"Get or set the name of the strip." cmd:""`
+		Source    StripSourceCmd    `    help:"Get or set the input source of the strip." cmd:""`
+		Main      StripMainCmd      `      help:"Get or set whether the strip is assigned to the main L/R bus." cmd:""`
+		Normalize StripNormalizeCmd `help:"Move the fader to a target level, making up the difference with the trim." cmd:""`
+
+		Insert StripInsertCmdGroup `help:"Commands related to the strip insert." cmd:"insert"`
+		Gate   StripGateCmdGroup   `     help:"Commands related to the strip gate." cmd:"gate"`
+		Eq     StripEqCmdGroup     `       help:"Commands related to the strip EQ." cmd:"eq"`
+		Comp   StripCompCmdGroup   `      help:"Commands related to the strip compressor." cmd:"comp"`
 	} `arg:"" help:"Control a specific strip by index."`
+
+	Examples ExamplesCmd `help:"Show example invocations." cmd:""`
+}
+
+// indexes returns the strip index addressed by the command, see validateIndexes.
+func (cmd *StripCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "strip <index>") {
+		return "", nil
+	}
+	return "strip", []int{cmd.Index.Index}
+}
+
+// StripSwapCmd defines the command for exchanging the settings of two strips, including their names, processing and sends.
+// Physical input patching stays in place unless routing is explicitly included.
+type StripSwapCmd struct {
+	A       int      `arg:"" help:"The index of the first strip. (1-based indexing)"`
+	B       int      `arg:"" help:"The index of the second strip. (1-based indexing)"`
+	Include []string `       help:"Additional sections to swap."                                       enum:"routing" default:""`
+}
+
+// indexes returns the two strips being swapped, see validateIndexes.
+func (cmd *StripSwapCmd) indexes(command string) (string, []int) {
+	return "strip", []int{cmd.A, cmd.B}
+}
+
+// Run executes the StripSwapCmd command, reading both strips before writing either of them.
+func (cmd *StripSwapCmd) Run(ctx *context) error {
+	if cmd.A == cmd.B {
+		return fmt.Errorf("cannot swap strip %d with itself", cmd.A)
+	}
+
+	snapA, err := ctx.Client.Strip.Snapshot(cmd.A)
+	if err != nil {
+		return fmt.Errorf("failed to read strip %d: %w", cmd.A, err)
+	}
+	snapB, err := ctx.Client.Strip.Snapshot(cmd.B)
+	if err != nil {
+		return fmt.Errorf("failed to read strip %d: %w", cmd.B, err)
+	}
+
+	var swapRouting bool
+	for _, section := range cmd.Include {
+		if section == "routing" {
+			swapRouting = true
+		}
+	}
+
+	var srcA, srcB int32
+	if swapRouting {
+		if srcA, err = ctx.Client.Strip.InputSource(cmd.A); err != nil {
+			return fmt.Errorf("failed to read strip %d input source: %w", cmd.A, err)
+		}
+		if srcB, err = ctx.Client.Strip.InputSource(cmd.B); err != nil {
+			return fmt.Errorf("failed to read strip %d input source: %w", cmd.B, err)
+		}
+	}
+
+	if err := ctx.Client.Strip.ApplySnapshot(cmd.A, snapB); err != nil {
+		return fmt.Errorf("failed to write strip %d: %w", cmd.A, err)
+	}
+	if err := ctx.Client.Strip.ApplySnapshot(cmd.B, snapA); err != nil {
+		return fmt.Errorf("failed to write strip %d: %w", cmd.B, err)
+	}
+
+	if swapRouting {
+		if err := ctx.Client.Strip.SetInputSource(cmd.A, srcB); err != nil {
+			return fmt.Errorf("failed to write strip %d input source: %w", cmd.A, err)
+		}
+		if err := ctx.Client.Strip.SetInputSource(cmd.B, srcA); err != nil {
+			return fmt.Errorf("failed to write strip %d input source: %w", cmd.B, err)
+		}
+	}
+
+	fmt.Fprintf(ctx.Confirm, "Strips %d and %d swapped\n", cmd.A, cmd.B)
+	return nil
+}
+
+// StripFaderMatchCmd defines the command for setting several strips to the same fader level, optionally fading them there together.
+type StripFaderMatchCmd struct {
+	Strips   []string      `arg:""      help:"The strips to set, by index (1-based indexing) or name."`
+	To       float64       `required:"" help:"The fader level (in dB) to set the strips to."`
+	Duration time.Duration `            help:"Fade to the level over this duration instead of setting it at once."`
+	Taper    string        `            help:"Step evenly in dB (linear) or along the fader travel (audio)."       default:"linear" enum:"linear,audio"`
+}
+
+// indexes returns the strips given by index, strips given by name are found on the mixer and are always in range.
+func (cmd *StripFaderMatchCmd) indexes(command string) (string, []int) {
+	var indexes []int
+	for _, strip := range cmd.Strips {
+		if index, err := strconv.Atoi(strip); err == nil {
+			indexes = append(indexes, index)
+		}
+	}
+	return "strip", indexes
+}
+
+// Validate checks that --to is a fader level, so a bad level fails before any strip is looked up or changed.
+func (cmd *StripFaderMatchCmd) Validate() error {
+	if cmd.To < minLevel || cmd.To > maxLevel {
+		return fmt.Errorf("--to must be between %g and %g dB", minLevel, maxLevel)
+	}
+	return nil
+}
+
+// Run executes the StripFaderMatchCmd command, resolving every strip before changing any fader.
+func (cmd *StripFaderMatchCmd) Run(ctx *context) error {
+	indexes := make([]int, len(cmd.Strips))
+	for i, strip := range cmd.Strips {
+		index, err := resolveStrip(ctx, strip)
+		if err != nil {
+			return err
+		}
+		indexes[i] = index
+	}
+
+	if cmd.Duration == 0 {
+		for _, index := range indexes {
+			if err := ctx.Client.Strip.SetFader(index, cmd.To); err != nil {
+				return fmt.Errorf("failed to set strip %d fader level: %w", index, err)
+			}
+			fmt.Fprintf(ctx.Confirm, "Strip %d fader level set to: %.2f dB\n", index, cmd.To)
+		}
+		return nil
+	}
+
+	from := make([]float64, len(indexes))
+	for i, index := range indexes {
+		level, err := ctx.Client.Strip.Fader(index)
+		if err != nil {
+			return fmt.Errorf("failed to get strip %d fader level: %w", index, err)
+		}
+		from[i] = level
+	}
+
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	var wg sync.WaitGroup
+	errs := make([]error, len(indexes))
+	for i, index := range indexes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			set := func(level float64) error { return ctx.Client.Strip.SetFader(index, level) }
+			errs[i] = rampFader(set, from[i], cmd.To, cmd.Duration, cmd.Taper, bar)
+		}()
+	}
+	wg.Wait()
+	bar.done()
+
+	for i, index := range indexes {
+		if errs[i] != nil {
+			return fmt.Errorf("failed to fade strip %d: %w", index, errs[i])
+		}
+		fmt.Fprintf(ctx.Confirm, "Strip %d fade complete. Final level: %.2f dB\n", index, cmd.To)
+	}
+	return nil
+}
+
+// resolveStrip returns the index of a strip given by index or by name, names are matched case-insensitively.
+func resolveStrip(ctx *context, strip string) (int, error) {
+	if index, err := strconv.Atoi(strip); err == nil {
+		return index, nil
+	}
+
+	for index := 1; index <= ctx.Client.StripCount(); index++ {
+		name, err := ctx.Client.Strip.Name(index)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get strip %d name: %w", index, err)
+		}
+		if strings.EqualFold(name, strip) {
+			return index, nil
+		}
+	}
+	return 0, fmt.Errorf("no strip named %q", strip)
 }
 
 // StripMuteCmd defines the command for getting or setting the mute state of a strip.
 type StripMuteCmd struct {
-	State *string `arg:"" help:"The mute state to set (true or false). If not provided, the current mute state will be returned." optional:"" enum:"true,false"`
+	State *string `arg:"" help:"The mute state to set (true, false or toggle). If not provided, the current mute state will be returned." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the StripMuteCmd command, either retrieving the current mute state of the strip or setting it based on the provided argument.
@@ -40,16 +237,24 @@ func (cmd *StripMuteCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Strip.SetMute(strip.Index.Index, *cmd.State == "true"); err != nil {
+	state, err := resolveToggle(*cmd.State, func() (bool, error) {
+		return ctx.Client.Strip.Mute(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get mute state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.SetMute(strip.Index.Index, state); err != nil {
 		return fmt.Errorf("failed to set mute state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d mute state set to: %s\n", strip.Index.Index, *cmd.State)
+	fmt.Fprintf(ctx.Confirm, "Strip %d mute state set to: %t\n", strip.Index.Index, state)
 	return nil
 }
 
 // StripFaderCmd defines the command for getting or setting the fader level of a strip.
 type StripFaderCmd struct {
-	Level *float64 `arg:"" help:"The fader level to set (in dB)." optional:""`
+	Level *relativeFloat `arg:"" help:"The fader level to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." optional:""`
+	Fine  bool           `       help:"Snap the level to 0.1 dB and print the value stored by the mixer."`
 }
 
 // Run executes the StripFaderCmd command, either retrieving the current fader level of the strip or setting it based on the provided argument.
@@ -63,10 +268,62 @@ func (cmd *StripFaderCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Strip.SetFader(strip.Index.Index, *cmd.Level); err != nil {
+	level, err := cmd.Level.resolve(func() (float64, error) {
+		return ctx.Client.Strip.Fader(strip.Index.Index)
+	}, minLevel, maxLevel)
+	if err != nil {
+		return fmt.Errorf("failed to get current fader level: %w", err)
+	}
+
+	if cmd.Fine {
+		level = cmd.Level.quantize(level, fineStep)
+	}
+
+	if err := ctx.Client.Strip.SetFader(strip.Index.Index, level); err != nil {
 		return fmt.Errorf("failed to set fader level: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d fader level set to: %.2f dB\n", strip.Index.Index, *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Strip %d fader level set to: %.2f dB%s\n", strip.Index.Index, level, cmd.Level.delta("dB"))
+
+	if cmd.Fine {
+		// The fader has a fixed number of steps, so the stored value may differ from the requested one.
+		stored, err := ctx.Client.Strip.Fader(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to confirm fader level: %w", err)
+		}
+		fmt.Fprintf(ctx.Confirm, "Strip %d fader level stored as: %.2f dB\n", strip.Index.Index, stored)
+	}
+	return nil
+}
+
+// StripPanCmd defines the command for getting or setting the pan position of a strip.
+// For a linked pair the mixer applies the same parameter as the balance of the pair.
+type StripPanCmd struct {
+	Pan *float64 `arg:"" help:"The pan position to set (-100 left to 100 right). Acts as balance when the strip is linked." optional:""`
+}
+
+// Validate checks that the provided pan position is within the valid range (-100 to 100).
+func (cmd *StripPanCmd) Validate() error {
+	if cmd.Pan != nil && (*cmd.Pan < -100 || *cmd.Pan > 100) {
+		return fmt.Errorf("pan must be between -100 and 100")
+	}
+	return nil
+}
+
+// Run executes the StripPanCmd command, either retrieving the current pan position of the strip or setting it based on the provided argument.
+func (cmd *StripPanCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Pan == nil {
+		resp, err := ctx.Client.Strip.Pan(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get pan position: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d pan position: %.2f\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.SetPan(strip.Index.Index, *cmd.Pan); err != nil {
+		return fmt.Errorf("failed to set pan position: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d pan position set to: %.2f\n", strip.Index.Index, *cmd.Pan)
 	return nil
 }
 
@@ -74,10 +331,16 @@ func (cmd *StripFaderCmd) Run(ctx *context, strip *StripCmdGroup) error {
 type StripFadeinCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-in (in seconds)." default:"5s"`
 	Target   float64       `        help:"The target fader level (in dB)."           default:"0.0" arg:""`
+	At       string        `        help:"Start the fade at the next occurrence of a time of day (HH:MM or HH:MM:SS)."`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the StripFadeinCmd command, gradually increasing the fader level of the strip from its current value to the specified target value over the specified duration.
 func (cmd *StripFadeinCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if err := scheduleFade(cmd.At); err != nil {
+		return err
+	}
+
 	currentLevel, err := ctx.Client.Strip.Fader(strip.Index.Index)
 	if err != nil {
 		return fmt.Errorf("failed to get current fader level: %w", err)
@@ -91,17 +354,15 @@ func (cmd *StripFadeinCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		)
 	}
 
-	totalSteps := float64(cmd.Target - currentLevel)
-	stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-	for currentLevel < cmd.Target {
-		currentLevel++
-		if err := ctx.Client.Strip.SetFader(strip.Index.Index, currentLevel); err != nil {
-			return fmt.Errorf("failed to set fader level during fade-in: %w", err)
-		}
-		time.Sleep(stepDuration)
+	bar := newProgress(ctx.Progress, "Fading")
+	defer bar.done()
+	set := func(level float64) error { return ctx.Client.Strip.SetFader(strip.Index.Index, level) }
+	if err := rampFader(set, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+		return fmt.Errorf("failed to set fader level during fade-in: %w", err)
 	}
+	bar.done()
 
-	fmt.Fprintf(ctx.Out, "Strip %d fade-in complete. Final level: %.2f dB\n", strip.Index.Index, cmd.Target)
+	fmt.Fprintf(ctx.Confirm, "Strip %d fade-in complete. Final level: %.2f dB\n", strip.Index.Index, cmd.Target)
 	return nil
 }
 
@@ -109,10 +370,16 @@ func (cmd *StripFadeinCmd) Run(ctx *context, strip *StripCmdGroup) error {
 type StripFadeoutCmd struct {
 	Duration time.Duration `flag:"" help:"The duration of the fade-out (in seconds)." default:"5s"`
 	Target   float64       `        help:"The target fader level (in dB)."            default:"-90.0" arg:""`
+	At       string        `        help:"Start the fade at the next occurrence of a time of day (HH:MM or HH:MM:SS)."`
+	Taper    string        `        help:"Step evenly in dB (linear) or along the fader travel (audio)." default:"linear" enum:"linear,audio"`
 }
 
 // Run executes the StripFadeoutCmd command, gradually decreasing the fader level of the strip from its current value to the specified target value over the specified duration.
 func (cmd *StripFadeoutCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if err := scheduleFade(cmd.At); err != nil {
+		return err
+	}
+
 	{
 		currentLevel, err := ctx.Client.Strip.Fader(strip.Index.Index)
 		if err != nil {
@@ -127,52 +394,325 @@ func (cmd *StripFadeoutCmd) Run(ctx *context, strip *StripCmdGroup) error {
 			)
 		}
 
-		totalSteps := float64(currentLevel - cmd.Target)
-		stepDuration := time.Duration(cmd.Duration.Seconds()*1000/totalSteps) * time.Millisecond
-		for currentLevel > cmd.Target {
-			currentLevel--
-			if err := ctx.Client.Strip.SetFader(strip.Index.Index, currentLevel); err != nil {
-				return fmt.Errorf("failed to set fader level during fade-out: %w", err)
-			}
-			time.Sleep(stepDuration)
+		bar := newProgress(ctx.Progress, "Fading")
+		defer bar.done()
+		set := func(level float64) error { return ctx.Client.Strip.SetFader(strip.Index.Index, level) }
+		if err := rampFader(set, currentLevel, cmd.Target, cmd.Duration, cmd.Taper, bar); err != nil {
+			return fmt.Errorf("failed to set fader level during fade-out: %w", err)
 		}
+		bar.done()
 
-		fmt.Fprintf(ctx.Out, "Strip %d fade-out complete. Final level: %.2f dB\n", strip.Index.Index, cmd.Target)
+		fmt.Fprintf(ctx.Confirm, "Strip %d fade-out complete. Final level: %.2f dB\n", strip.Index.Index, cmd.Target)
 		return nil
 	}
 }
 
 // StripSendCmd defines the command for getting or setting the send level for a specific bus on a strip, allowing users to control the level of the signal being sent from the strip to a particular bus.
+// With --buses the same level is set on several buses at once, e.g. for a group of monitor mixes.
 type StripSendCmd struct {
-	BusNum int      `arg:"" help:"The bus number to get or set the send level for."`
-	Level  *float64 `arg:"" help:"The send level to set (in dB)."                   optional:""`
+	BusNum     *int           `arg:"" help:"The bus number to get or set the send level for."                                     optional:""`
+	Level      *relativeFloat `arg:"" help:"The send level to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10."  optional:""`
+	Buses      []int          `       help:"Set the send level for several buses at once, instead of giving a bus number."        sep:","`
+	BusesLevel *relativeFloat `       help:"The send level to set for --buses (in dB). Prefix with + or - for a relative change."  name:"level"`
+	Percent    *float64       `       help:"The send level to set as a percentage of the fader travel (0-100), instead of in dB."`
 }
 
-// Run executes the StripSendCmd command, either retrieving the current send level for the specified bus on the strip or setting it based on the provided argument.
+// Validate checks that the command addresses either a bus number or --buses, with at most one level.
+func (cmd *StripSendCmd) Validate() error {
+	if (cmd.BusNum == nil) == (len(cmd.Buses) == 0) {
+		return fmt.Errorf("either a bus number or --buses must be provided")
+	}
+	if len(cmd.Buses) > 0 && cmd.Level != nil {
+		return fmt.Errorf("use --level to give the send level for --buses")
+	}
+	if cmd.BusNum != nil && cmd.BusesLevel != nil {
+		return fmt.Errorf("--level can only be used with --buses")
+	}
+	if (cmd.Level != nil || cmd.BusesLevel != nil) && cmd.Percent != nil {
+		return fmt.Errorf("cannot set the send level in dB and --percent at the same time")
+	}
+	if len(cmd.Buses) > 0 && cmd.BusesLevel == nil && cmd.Percent == nil {
+		return fmt.Errorf("--buses requires --level or --percent")
+	}
+	if cmd.Percent != nil && (*cmd.Percent < 0 || *cmd.Percent > 100) {
+		return fmt.Errorf("percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// indexes returns the buses addressed by the command, see validateIndexes.
+func (cmd *StripSendCmd) indexes(command string) (string, []int) {
+	if cmd.BusNum != nil {
+		return "bus", []int{*cmd.BusNum}
+	}
+	return "bus", cmd.Buses
+}
+
+// Run executes the StripSendCmd command, either retrieving the current send level for the specified bus on the strip or setting it on every addressed bus.
 func (cmd *StripSendCmd) Run(ctx *context, strip *StripCmdGroup) error {
-	if cmd.Level == nil {
-		resp, err := ctx.Client.Strip.SendLevel(strip.Index.Index, cmd.BusNum)
+	buses := cmd.Buses
+	if cmd.BusNum != nil {
+		buses = []int{*cmd.BusNum}
+	}
+	level := cmd.Level
+	if cmd.BusesLevel != nil {
+		level = cmd.BusesLevel
+	}
+	if cmd.Percent != nil {
+		level = &relativeFloat{Value: xair.LevelFromPercent(*cmd.Percent)}
+	}
+
+	if level == nil {
+		resp, err := ctx.Client.Strip.SendLevel(strip.Index.Index, buses[0])
 		if err != nil {
 			return fmt.Errorf("failed to get send level: %w", err)
 		}
-		fmt.Fprintf(ctx.Out, "Strip %d send level for bus %d: %.2f dB\n", strip.Index.Index, cmd.BusNum, resp)
+		fmt.Fprintf(ctx.Out, "Strip %d send level for bus %d: %.2f dB\n", strip.Index.Index, buses[0], resp)
 		return nil
 	}
 
-	if err := ctx.Client.Strip.SetSendLevel(strip.Index.Index, cmd.BusNum, *cmd.Level); err != nil {
-		return fmt.Errorf("failed to set send level: %w", err)
+	for _, bus := range buses {
+		// a relative change is resolved against the current level of each bus
+		l := *level
+		target, err := l.resolve(func() (float64, error) {
+			return ctx.Client.Strip.SendLevel(strip.Index.Index, bus)
+		}, minLevel, maxLevel)
+		if err != nil {
+			return fmt.Errorf("failed to get current send level: %w", err)
+		}
+
+		if err := ctx.Client.Strip.SetSendLevel(strip.Index.Index, bus, target); err != nil {
+			return fmt.Errorf("failed to set send level for bus %d: %w", bus, err)
+		}
+		fmt.Fprintf(
+			ctx.Confirm,
+			"Strip %d send level for bus %d set to: %.2f dB%s\n",
+			strip.Index.Index,
+			bus,
+			target,
+			l.delta("dB"),
+		)
+	}
+	return nil
+}
+
+// StripMainCmd defines the command for getting or setting whether a strip is assigned to the main L/R bus.
+type StripMainCmd struct {
+	Enable *string `arg:"" help:"Whether to assign the strip to the main L/R bus." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the StripMainCmd command, either retrieving the current main L/R assignment of the strip or setting it based on the provided argument.
+func (cmd *StripMainCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Enable == nil {
+		resp, err := ctx.Client.Strip.MainAssign(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get main assignment: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d main L/R assignment: %t\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.MainAssign(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get main assignment: %w", err)
+	}
+
+	if err := ctx.Client.Strip.SetMainAssign(strip.Index.Index, state); err != nil {
+		return fmt.Errorf("failed to set main assignment: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d main L/R assignment set to: %t\n", strip.Index.Index, state)
+	return nil
+}
+
+// StripNormalizeCmd defines the command for moving the fader of a strip to a target level without changing how loud the strip is.
+type StripNormalizeCmd struct {
+	Target float64 `help:"The fader level (in dB) to move the fader to." default:"0.0"`
+}
+
+// Validate checks that the target is a fader level above -∞.
+func (cmd *StripNormalizeCmd) Validate() error {
+	if cmd.Target <= -90 || cmd.Target > 10 {
+		return fmt.Errorf("target must be above -90 dB and at most 10 dB")
+	}
+	return nil
+}
+
+// Run executes the StripNormalizeCmd command, moving the difference between the fader and the target onto the trim.
+func (cmd *StripNormalizeCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	n, err := ctx.Client.Strip.Normalize(strip.Index.Index, cmd.Target)
+	if err != nil {
+		return fmt.Errorf("failed to normalize strip %d: %w", strip.Index.Index, err)
+	}
+	if n.Clamped {
+		log.Warnf("Strip %d trim is at the end of its range, the fader stops at %.2f dB instead of %.2f dB", strip.Index.Index, n.Fader, cmd.Target)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d normalized. Fader: %.2f dB, trim: %.2f dB\n", strip.Index.Index, n.Fader, n.Trim)
+	return nil
+}
+
+// StripSendPanCmd defines the command for getting or setting the pan of the send from a strip to a linked stereo bus pair.
+type StripSendPanCmd struct {
+	BusNum int      `arg:"" help:"The bus number to get or set the send pan for, either bus of a linked pair."`
+	Pan    *float64 `arg:"" help:"The send pan to set (-100 left to 100 right)." optional:""`
+}
+
+// Validate checks that the provided send pan is within the valid range (-100 to 100).
+func (cmd *StripSendPanCmd) Validate() error {
+	if cmd.Pan != nil && (*cmd.Pan < -100 || *cmd.Pan > 100) {
+		return fmt.Errorf("pan must be between -100 and 100")
+	}
+	return nil
+}
+
+// indexes returns the bus whose send pan is addressed, see validateIndexes.
+func (cmd *StripSendPanCmd) indexes(command string) (string, []int) {
+	return "bus", []int{cmd.BusNum}
+}
+
+// Run executes the StripSendPanCmd command, either retrieving the current send pan of the strip or setting it based on the provided argument.
+func (cmd *StripSendPanCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Pan == nil {
+		resp, err := ctx.Client.Strip.SendPan(strip.Index.Index, cmd.BusNum)
+		if err != nil {
+			return fmt.Errorf("failed to get send pan: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d send pan for bus %d: %.2f\n", strip.Index.Index, cmd.BusNum, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.SetSendPan(strip.Index.Index, cmd.BusNum, *cmd.Pan); err != nil {
+		return fmt.Errorf("failed to set send pan: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d send level for bus %d set to: %.2f dB\n", strip.Index.Index, cmd.BusNum, *cmd.Level)
+	fmt.Fprintf(ctx.Confirm, "Strip %d send pan for bus %d set to: %.2f\n", strip.Index.Index, cmd.BusNum, *cmd.Pan)
 	return nil
 }
 
+// StripSendsCmd defines the command for printing the send to every bus of a strip, giving an overview of a monitor mix.
+type StripSendsCmd struct {
+	JSON bool `help:"Print the sends as JSON." name:"json"`
+}
+
+// Run executes the StripSendsCmd command, reading every send of the strip before printing them as a table or as JSON.
+func (cmd *StripSendsCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	sends, err := ctx.Client.Strip.AllSends(strip.Index.Index)
+	if err != nil {
+		return fmt.Errorf("failed to get sends: %w", err)
+	}
+
+	if cmd.JSON {
+		enc := json.NewEncoder(ctx.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sends)
+	}
+
+	w := tabwriter.NewWriter(ctx.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BUS\tLEVEL\tON")
+	for _, send := range sends {
+		on := "-"
+		if send.On != nil {
+			on = fmt.Sprintf("%t", *send.On)
+		}
+		fmt.Fprintf(w, "%d\t%.2f dB\t%s\n", send.Bus, send.Level, on)
+	}
+	return w.Flush()
+}
+
+// StripShowCmd defines the command for printing an overview of every setting of a strip.
+type StripShowCmd struct {
+	JSON bool `help:"Print the overview as JSON." name:"json"`
+}
+
+// stripOverview is a strip snapshot together with the settings that are not part of it.
+type stripOverview struct {
+	Strip int `json:"strip"`
+	xair.StripSnapshot
+	Pan float64 `json:"pan"`
+}
+
+// Run executes the StripShowCmd command, reading a snapshot of the strip before printing it as a report or as JSON.
+func (cmd *StripShowCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	snap, err := ctx.Client.Strip.Snapshot(strip.Index.Index)
+	if err != nil {
+		return fmt.Errorf("failed to read strip %d: %w", strip.Index.Index, err)
+	}
+	pan, err := ctx.Client.Strip.Pan(strip.Index.Index)
+	if err != nil {
+		return fmt.Errorf("failed to get pan position: %w", err)
+	}
+	overview := stripOverview{Strip: strip.Index.Index, StripSnapshot: snap, Pan: pan}
+
+	if cmd.JSON {
+		enc := json.NewEncoder(ctx.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(overview)
+	}
+	return printStripOverview(ctx.Out, overview)
+}
+
+// printStripOverview prints a strip overview as an aligned report, one section per line.
+func printStripOverview(out io.Writer, o stripOverview) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Strip %d\t%s\n", o.Strip, o.Name)
+	fmt.Fprintf(w, "Mute\t%t\n", o.Mute)
+	fmt.Fprintf(w, "Fader\t%.2f dB\n", o.Fader)
+	fmt.Fprintf(w, "Pan\t%.2f\n", o.Pan)
+
+	g := o.Gate
+	fmt.Fprintf(
+		w,
+		"Gate\t%s, %s, threshold %.2f dB, range %.2f dB, attack %.2f ms, hold %.2f ms, release %.2f ms\n",
+		onOff(g.On), g.Mode, g.Threshold, g.Range, g.Attack, g.Hold, g.Release,
+	)
+
+	fmt.Fprintf(w, "EQ\t%s\n", onOff(o.Eq.On))
+	for i, band := range o.Eq.Bands {
+		fmt.Fprintf(w, "  Band %d\t%s, %.2f Hz, %.2f dB, Q %.2f\n", i+1, band.Type, band.Frequency, band.Gain, band.Q)
+	}
+
+	c := o.Comp
+	fmt.Fprintf(
+		w,
+		"Comp\t%s, %s, threshold %.2f dB, ratio %.1f:1, makeup %.2f dB, attack %.2f ms, release %.2f ms\n",
+		onOff(c.On), c.Mode, c.Threshold, c.Ratio, c.Makeup, c.Attack, c.Release,
+	)
+
+	fmt.Fprintln(w, "Sends\t")
+	for i, level := range o.Sends {
+		fmt.Fprintf(w, "  Bus %d\t%.2f dB\n", i+1, level)
+	}
+	return w.Flush()
+}
+
+// onOff formats an on state for a report.
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
 // StripNameCmd defines the command for getting or setting the name of a strip, allowing users to assign custom names to strips for easier identification and organization.
 type StripNameCmd struct {
-	Name *string `arg:"" help:"The name to set for the strip." optional:""`
+	Name  *string `arg:"" help:"The name to set for the strip." optional:""`
+	Clear bool    `       help:"Clear the name of the strip."`
+}
+
+// Validate checks that a name and --clear are not given together.
+func (cmd *StripNameCmd) Validate() error {
+	if cmd.Clear && cmd.Name != nil {
+		return fmt.Errorf("cannot set a name and --clear at the same time")
+	}
+	return nil
 }
 
 // Run executes the StripNameCmd command, either retrieving the current name of the strip or setting it based on the provided argument.
 func (cmd *StripNameCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Clear {
+		cmd.Name = new(string)
+	}
+
 	if cmd.Name == nil {
 		resp, err := ctx.Client.Strip.Name(strip.Index.Index)
 		if err != nil {
@@ -185,7 +725,152 @@ func (cmd *StripNameCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.SetName(strip.Index.Index, *cmd.Name); err != nil {
 		return fmt.Errorf("failed to set strip name: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d name set to: %s\n", strip.Index.Index, *cmd.Name)
+	fmt.Fprintf(ctx.Confirm, "Strip %d name set to: %s\n", strip.Index.Index, *cmd.Name)
+	return nil
+}
+
+// StripSourceCmd defines the command for getting or setting the input source of a strip, such as a physical input or a USB return.
+type StripSourceCmd struct {
+	Source    *string `arg:"" help:"The input source to set, e.g. 'IN 01', 'USB 01' or in01." optional:""`
+	CarryGain bool    `       help:"Copy the headamp gain of the old source to the headamp of the new source."`
+}
+
+// Validate checks that --carry-gain is only given when setting a source.
+func (cmd *StripSourceCmd) Validate() error {
+	if cmd.CarryGain && cmd.Source == nil {
+		return fmt.Errorf("--carry-gain requires a source to set")
+	}
+	return nil
+}
+
+// Run executes the StripSourceCmd command, either retrieving the current input source of the strip or setting it based on the provided argument.
+func (cmd *StripSourceCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Source == nil {
+		resp, err := ctx.Client.Strip.Source(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get input source: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d input source: %s\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	var previous string
+	if cmd.CarryGain {
+		var err error
+		if previous, err = ctx.Client.Strip.Source(strip.Index.Index); err != nil {
+			return fmt.Errorf("failed to get input source: %w", err)
+		}
+	}
+
+	if err := ctx.Client.Strip.SetSource(strip.Index.Index, *cmd.Source); err != nil {
+		return fmt.Errorf("failed to set input source: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d input source set to: %s\n", strip.Index.Index, *cmd.Source)
+
+	if cmd.CarryGain {
+		return carryGain(ctx, previous, *cmd.Source)
+	}
+	return nil
+}
+
+// carryGain copies the gain of the headamp feeding one input source to the headamp feeding another.
+// Sources without a headamp, such as USB returns, leave the gain alone with a warning.
+func carryGain(ctx *context, from, to string) error {
+	fromHeadamp, ok, err := ctx.Client.SourceHeadamp(from)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Warnf("Input source %s has no headamp, the gain was not carried over", from)
+		return nil
+	}
+	toHeadamp, ok, err := ctx.Client.SourceHeadamp(to)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Warnf("Input source %s has no headamp, the gain was not carried over", to)
+		return nil
+	}
+	if fromHeadamp == toHeadamp {
+		return nil
+	}
+
+	gain, err := ctx.Client.HeadAmp.Gain(fromHeadamp)
+	if err != nil {
+		return fmt.Errorf("failed to get headamp %d gain: %w", fromHeadamp, err)
+	}
+	if err := ctx.Client.HeadAmp.SetGain(toHeadamp, gain); err != nil {
+		return fmt.Errorf("failed to set headamp %d gain: %w", toHeadamp, err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Headamp %d gain set to: %.2f dB\n", toHeadamp, gain)
+	return nil
+}
+
+// StripInsertCmdGroup defines the command group for controlling the insert of a strip, used to patch an FX slot or outboard processing into the channel.
+type StripInsertCmdGroup struct {
+	On   StripInsertOnCmd   `help:"Get or set whether the strip insert is engaged."    cmd:""`
+	Slot StripInsertSlotCmd `help:"Get or set the slot selected for the strip insert." cmd:""`
+}
+
+// StripInsertOnCmd defines the command for getting or setting whether the insert of a strip is engaged.
+type StripInsertOnCmd struct {
+	Enable *string `arg:"" help:"Whether to engage the strip insert." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the StripInsertOnCmd command, either retrieving the current insert state of the strip or setting it based on the provided argument.
+func (cmd *StripInsertOnCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Enable == nil {
+		resp, err := ctx.Client.Strip.InsertOn(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get insert state: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d insert state: %t\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.InsertOn(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get insert state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.SetInsertOn(strip.Index.Index, state); err != nil {
+		return fmt.Errorf("failed to set insert state: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d insert state set to: %t\n", strip.Index.Index, state)
+	return nil
+}
+
+// StripInsertSlotCmd defines the command for getting or setting the slot selected for the insert of a strip.
+type StripInsertSlotCmd struct {
+	Slot *int `arg:"" help:"The insert slot to select. (0-4, 0 is off and 1-4 are the FX slots)" optional:""`
+}
+
+// Validate checks that the provided insert slot is within the valid range (0-4).
+func (cmd *StripInsertSlotCmd) Validate() error {
+	if cmd.Slot != nil && (*cmd.Slot < 0 || *cmd.Slot > 4) {
+		return fmt.Errorf("insert slot must be between 0 and 4")
+	}
+	return nil
+}
+
+// Run executes the StripInsertSlotCmd command, either retrieving the current insert slot of the strip or setting it based on the provided argument.
+func (cmd *StripInsertSlotCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Slot == nil {
+		resp, err := ctx.Client.Strip.InsertSlot(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get insert slot: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d insert slot: %d\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.SetInsertSlot(strip.Index.Index, *cmd.Slot); err != nil {
+		return fmt.Errorf("failed to set insert slot: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d insert slot set to: %d\n", strip.Index.Index, *cmd.Slot)
 	return nil
 }
 
@@ -198,11 +883,25 @@ type StripGateCmdGroup struct {
 	Attack    StripGateAttackCmd    `help:"Get or set the gate attack time of the strip."  cmd:""`
 	Hold      StripGateHoldCmd      `help:"Get or set the gate hold time of the strip."    cmd:""`
 	Release   StripGateReleaseCmd   `help:"Get or set the gate release time of the strip." cmd:""`
+	KeySource StripGateKeySourceCmd `help:"Get or set the gate key source of the strip."   cmd:"" name:"keysrc"`
+
+	Filter StripGateFilterCmdGroup `help:"Commands related to the gate key filter of the strip." cmd:"filter"`
+	Set    StripGateSetCmd         `help:"Set several gate parameters of the strip at once."     cmd:""`
+}
+
+// StripGateSetCmd defines the command for setting several gate parameters of the strip in one go, leaving any omitted parameters untouched.
+type StripGateSetCmd struct {
+	GateSetFlags `embed:""`
+}
+
+// Run executes the StripGateSetCmd command, applying all provided gate parameters to the strip.
+func (cmd *StripGateSetCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	return cmd.apply(ctx.Confirm, ctx.Client.Strip.Gate, strip.Index.Index, fmt.Sprintf("Strip %d", strip.Index.Index))
 }
 
 // StripGateOnCmd defines the command for getting or setting the gate on/off state of a strip, allowing users to enable or disable the gate effect on the strip.
 type StripGateOnCmd struct {
-	Enable *string `arg:"" help:"Whether to enable or disable the gate." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"Whether to enable or disable the gate." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the StripGateOnCmd command, either retrieving the current gate on/off state of the strip or setting it based on the provided argument.
@@ -216,10 +915,17 @@ func (cmd *StripGateOnCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Strip.Gate.SetOn(strip.Index.Index, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.Gate.On(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get gate state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Gate.SetOn(strip.Index.Index, state); err != nil {
 		return fmt.Errorf("failed to set gate state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d gate state set to: %s\n", strip.Index.Index, *cmd.Enable)
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate state set to: %t\n", strip.Index.Index, state)
 	return nil
 }
 
@@ -242,13 +948,13 @@ func (cmd *StripGateModeCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Gate.SetMode(strip.Index.Index, *cmd.Mode); err != nil {
 		return fmt.Errorf("failed to set gate mode: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d gate mode set to: %s\n", strip.Index.Index, *cmd.Mode)
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate mode set to: %s\n", strip.Index.Index, *cmd.Mode)
 	return nil
 }
 
 // StripGateThresholdCmd defines the command for getting or setting the gate threshold of a strip, allowing users to specify the threshold level at which the gate will start to attenuate the signal.
 type StripGateThresholdCmd struct {
-	Threshold *float64 `arg:"" help:"The gate threshold to set (in dB)." optional:""`
+	Threshold *relativeFloat `arg:"" help:"The gate threshold to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." optional:""`
 }
 
 // Run executes the StripGateThresholdCmd command, either retrieving the current gate threshold of the strip or setting it based on the provided argument.
@@ -262,16 +968,23 @@ func (cmd *StripGateThresholdCmd) Run(ctx *context, strip *StripCmdGroup) error
 		return nil
 	}
 
-	if err := ctx.Client.Strip.Gate.SetThreshold(strip.Index.Index, *cmd.Threshold); err != nil {
+	threshold, err := cmd.Threshold.resolve(func() (float64, error) {
+		return ctx.Client.Strip.Gate.Threshold(strip.Index.Index)
+	}, minGateThreshold, maxGateThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to get current gate threshold: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Gate.SetThreshold(strip.Index.Index, threshold); err != nil {
 		return fmt.Errorf("failed to set gate threshold: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d gate threshold set to: %.2f\n", strip.Index.Index, *cmd.Threshold)
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate threshold set to: %.2f%s\n", strip.Index.Index, threshold, cmd.Threshold.delta("dB"))
 	return nil
 }
 
 // StripGateRangeCmd defines the command for getting or setting the gate range of a strip, allowing users to specify the amount of attenuation applied by the gate when the signal falls below the threshold.
 type StripGateRangeCmd struct {
-	Range *float64 `arg:"" help:"The gate range to set (in dB)." optional:""`
+	Range *relativeFloat `arg:"" help:"The gate range to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." optional:""`
 }
 
 // Run executes the StripGateRangeCmd command, either retrieving the current gate range of the strip or setting it based on the provided argument.
@@ -285,10 +998,17 @@ func (cmd *StripGateRangeCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Strip.Gate.SetRange(strip.Index.Index, *cmd.Range); err != nil {
+	gateRange, err := cmd.Range.resolve(func() (float64, error) {
+		return ctx.Client.Strip.Gate.Range(strip.Index.Index)
+	}, minGateRange, maxGateRange)
+	if err != nil {
+		return fmt.Errorf("failed to get current gate range: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Gate.SetRange(strip.Index.Index, gateRange); err != nil {
 		return fmt.Errorf("failed to set gate range: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d gate range set to: %.2f\n", strip.Index.Index, *cmd.Range)
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate range set to: %.2f%s\n", strip.Index.Index, gateRange, cmd.Range.delta("dB"))
 	return nil
 }
 
@@ -311,7 +1031,7 @@ func (cmd *StripGateAttackCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Gate.SetAttack(strip.Index.Index, *cmd.Attack); err != nil {
 		return fmt.Errorf("failed to set gate attack time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d gate attack time set to: %.2f ms\n", strip.Index.Index, *cmd.Attack)
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate attack time set to: %.2f ms\n", strip.Index.Index, *cmd.Attack)
 	return nil
 }
 
@@ -334,7 +1054,7 @@ func (cmd *StripGateHoldCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Gate.SetHold(strip.Index.Index, *cmd.Hold); err != nil {
 		return fmt.Errorf("failed to set gate hold time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d gate hold time set to: %.2f ms\n", strip.Index.Index, *cmd.Hold)
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate hold time set to: %.2f ms\n", strip.Index.Index, *cmd.Hold)
 	return nil
 }
 
@@ -357,33 +1077,162 @@ func (cmd *StripGateReleaseCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Gate.SetRelease(strip.Index.Index, *cmd.Release); err != nil {
 		return fmt.Errorf("failed to set gate release time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d gate release time set to: %.2f ms\n", strip.Index.Index, *cmd.Release)
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate release time set to: %.2f ms\n", strip.Index.Index, *cmd.Release)
+	return nil
+}
+
+// StripGateKeySourceCmd defines the command for getting or setting the gate key source of a strip, allowing users to key the gate from another channel or bus.
+type StripGateKeySourceCmd struct {
+	Source *string `arg:"" help:"The gate key source to set, e.g. self, ch02 or bus1." optional:""`
+}
+
+// Run executes the StripGateKeySourceCmd command, either retrieving the current gate key source of the strip or setting it based on the provided argument.
+func (cmd *StripGateKeySourceCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Source == nil {
+		resp, err := ctx.Client.Strip.Gate.KeySource(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get gate key source: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d gate key source: %s\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.Gate.SetKeySource(strip.Index.Index, *cmd.Source); err != nil {
+		return fmt.Errorf("failed to set gate key source: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate key source set to: %s\n", strip.Index.Index, *cmd.Source)
+	return nil
+}
+
+// StripGateFilterCmdGroup defines the command group for controlling the gate key filter of a strip.
+type StripGateFilterCmdGroup struct {
+	On   StripGateFilterOnCmd   `help:"Get or set the gate key filter on/off state of the strip." cmd:""`
+	Freq StripGateFilterFreqCmd `help:"Get or set the gate key filter frequency of the strip."    cmd:""`
+}
+
+// StripGateFilterOnCmd defines the command for getting or setting the gate key filter on/off state of a strip.
+type StripGateFilterOnCmd struct {
+	Enable *string `arg:"" help:"Whether to enable or disable the gate key filter." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the StripGateFilterOnCmd command, either retrieving the current gate key filter on/off state of the strip or setting it based on the provided argument.
+func (cmd *StripGateFilterOnCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Enable == nil {
+		resp, err := ctx.Client.Strip.Gate.FilterOn(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get gate key filter state: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d gate key filter state: %t\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.Gate.FilterOn(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get gate key filter state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Gate.SetFilterOn(strip.Index.Index, state); err != nil {
+		return fmt.Errorf("failed to set gate key filter state: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate key filter state set to: %t\n", strip.Index.Index, state)
+	return nil
+}
+
+// StripGateFilterFreqCmd defines the command for getting or setting the gate key filter frequency of a strip.
+type StripGateFilterFreqCmd struct {
+	Frequency *float64 `arg:"" help:"The gate key filter frequency to set (in Hz)." optional:""`
+}
+
+// Run executes the StripGateFilterFreqCmd command, either retrieving the current gate key filter frequency of the strip or setting it based on the provided argument.
+func (cmd *StripGateFilterFreqCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Frequency == nil {
+		resp, err := ctx.Client.Strip.Gate.FilterFreq(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get gate key filter frequency: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d gate key filter frequency: %.2f Hz\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.Gate.SetFilterFreq(strip.Index.Index, *cmd.Frequency); err != nil {
+		return fmt.Errorf("failed to set gate key filter frequency: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d gate key filter frequency set to: %.2f Hz\n", strip.Index.Index, *cmd.Frequency)
 	return nil
 }
 
 // StripEqCmdGroup defines the command group for controlling the EQ settings of a strip, including commands for getting and setting the EQ on/off state and parameters for each EQ band such as gain, frequency, Q factor, and type.
 type StripEqCmdGroup struct {
-	On   StripEqOnCmd `help:"Get or set the EQ on/off state of the strip."              cmd:""`
-	Band struct {
-		Band int                `arg:"" help:"The EQ band number."`
-		Gain StripEqBandGainCmd `help:"Get or set the gain of the EQ band." cmd:""`
-		Freq StripEqBandFreqCmd `help:"Get or set the frequency of the EQ band." cmd:""`
-		Q    StripEqBandQCmd    `help:"Get or set the Q factor of the EQ band." cmd:""`
-		Type StripEqBandTypeCmd `help:"Get or set the type of the EQ band." cmd:""`
+	On    StripEqOnCmd      `help:"Get or set the EQ on/off state of the strip."              cmd:""`
+	Curve StripEqCurveCmd   `help:"Print the frequency response of the EQ as CSV."            cmd:""`
+	AB    StripEqABCmdGroup `help:"Compare two EQ settings of the strip."                    cmd:"ab"`
+	Band  struct {
+		Band     int                    `arg:"" help:"The EQ band number."`
+		Gain     StripEqBandGainCmd     `help:"Get or set the gain of the EQ band." cmd:""`
+		Freq     StripEqBandFreqCmd     `help:"Get or set the frequency of the EQ band." cmd:""`
+		Q        StripEqBandQCmd        `help:"Get or set the Q factor of the EQ band." cmd:""`
+		Type     StripEqBandTypeCmd     `help:"Get or set the type of the EQ band." cmd:""`
+		Set      StripEqBandSetCmd      `help:"Set several parameters of the EQ band at once." cmd:""`
+		CopyTo   StripEqBandCopyToCmd   `help:"Copy the EQ band to another strip or band." cmd:"copy-to"`
+		Audition StripEqBandAuditionCmd `help:"Boost the EQ band for a while to hear it, then restore its gain." cmd:""`
 	} `help:"Commands for controlling a specific EQ band of the strip."        arg:""`
 }
 
-// Validate checks if the provided EQ band number is valid (between 1 and 4) and returns an error if it is not.
-func (cmd *StripEqCmdGroup) Validate(ctx kong.Context) error {
-	if cmd.Band.Band < 1 || cmd.Band.Band > 4 {
-		return fmt.Errorf("EQ band number must be between 1 and 4")
+// indexes returns the EQ band addressed by the command, see validateIndexes.
+// Commands that do not address a band, such as eq on, leave the band unset and are not checked.
+func (cmd *StripEqCmdGroup) indexes(command string) (string, []int) {
+	if !strings.HasPrefix(command, "strip <index> eq <band>") {
+		return "", nil
 	}
+	return "strip EQ band", []int{cmd.Band.Band}
+}
+
+// StripEqABCmdGroup defines the commands for comparing two EQ settings of a strip, stored as A and B.
+type StripEqABCmdGroup struct {
+	Store  StripEqABStoreCmd  `help:"Store the current EQ as setting A, the changes that follow make up setting B." cmd:""`
+	Toggle StripEqABToggleCmd `help:"Switch the EQ between settings A and B."                                     cmd:""`
+}
+
+// StripEqABStoreCmd defines the command for starting an A/B comparison of the EQ of a strip.
+type StripEqABStoreCmd struct{}
+
+// Run executes the StripEqABStoreCmd command, storing the current EQ of the strip as setting A.
+func (cmd *StripEqABStoreCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	snap, err := ctx.Client.Strip.Eq.Snapshot(strip.Index.Index, ctx.Client.EqBandCount("strip"))
+	if err != nil {
+		return fmt.Errorf("failed to read EQ: %w", err)
+	}
+	if err := storeEqAB(fmt.Sprintf("strip %d", strip.Index.Index), snap); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ stored as A, changes from now on make up B\n", strip.Index.Index)
+	return nil
+}
+
+// StripEqABToggleCmd defines the command for switching the EQ of a strip between the settings of its A/B comparison.
+type StripEqABToggleCmd struct{}
+
+// Run executes the StripEqABToggleCmd command, keeping the current EQ as the setting the strip is on before applying the other.
+func (cmd *StripEqABToggleCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	snap, err := ctx.Client.Strip.Eq.Snapshot(strip.Index.Index, ctx.Client.EqBandCount("strip"))
+	if err != nil {
+		return fmt.Errorf("failed to read EQ: %w", err)
+	}
+	active, err := toggleEqAB(fmt.Sprintf("strip %d", strip.Index.Index), snap, func(next xair.EqSnapshot) error {
+		return ctx.Client.Strip.Eq.ApplySnapshot(strip.Index.Index, next)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ switched to %s\n", strip.Index.Index, active)
 	return nil
 }
 
 // StripEqOnCmd defines the command for getting or setting the EQ on/off state of a strip, allowing users to enable or disable the EQ effect on the strip.
 type StripEqOnCmd struct {
-	Enable *string `arg:"" help:"Whether to enable or disable the EQ." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"Whether to enable or disable the EQ." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the StripEqOnCmd command, either retrieving the current EQ on/off state of the strip or setting it based on the provided argument.
@@ -397,10 +1246,45 @@ func (cmd *StripEqOnCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Strip.Eq.SetOn(strip.Index.Index, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.Eq.On(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get EQ state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Eq.SetOn(strip.Index.Index, state); err != nil {
 		return fmt.Errorf("failed to set EQ state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d EQ state set to: %s\n", strip.Index.Index, *cmd.Enable)
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ state set to: %t\n", strip.Index.Index, state)
+	return nil
+}
+
+// StripEqCurveCmd defines the command for printing the frequency response of the EQ of a strip as CSV, for plotting in a spreadsheet.
+type StripEqCurveCmd struct {
+	Points int `help:"The number of points of the curve, spaced logarithmically from 20 Hz to 20 kHz." default:"256"`
+}
+
+// Validate checks that the curve has at least two points.
+func (cmd *StripEqCurveCmd) Validate() error {
+	if cmd.Points < 2 {
+		return fmt.Errorf("points must be at least 2")
+	}
+	return nil
+}
+
+// Run executes the StripEqCurveCmd command, reading the EQ bands of the strip and printing the combined response as frequency,gain rows.
+func (cmd *StripEqCurveCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	snap, err := ctx.Client.Strip.Eq.Snapshot(strip.Index.Index, ctx.Client.EqBandCount("strip"))
+	if err != nil {
+		return fmt.Errorf("failed to read EQ: %w", err)
+	}
+
+	fmt.Fprintln(ctx.Out, "frequency,gain")
+	for i := range cmd.Points {
+		freq := 20 * math.Pow(1000, float64(i)/float64(cmd.Points-1))
+		fmt.Fprintf(ctx.Out, "%.2f,%.2f\n", freq, snap.Response(freq))
+	}
 	return nil
 }
 
@@ -423,7 +1307,7 @@ func (cmd *StripEqBandGainCmd) Run(ctx *context, strip *StripCmdGroup, stripEq *
 	if err := ctx.Client.Strip.Eq.SetGain(strip.Index.Index, stripEq.Band.Band, *cmd.Gain); err != nil {
 		return fmt.Errorf("failed to set EQ band gain: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d EQ band %d gain set to: %.2f\n", strip.Index.Index, stripEq.Band.Band, *cmd.Gain)
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d gain set to: %.2f\n", strip.Index.Index, stripEq.Band.Band, *cmd.Gain)
 	return nil
 }
 
@@ -447,7 +1331,7 @@ func (cmd *StripEqBandFreqCmd) Run(ctx *context, strip *StripCmdGroup, stripEq *
 		return fmt.Errorf("failed to set EQ band frequency: %w", err)
 	}
 	fmt.Fprintf(
-		ctx.Out,
+		ctx.Confirm,
 		"Strip %d EQ band %d frequency set to: %.2f Hz\n",
 		strip.Index.Index,
 		stripEq.Band.Band,
@@ -475,7 +1359,7 @@ func (cmd *StripEqBandQCmd) Run(ctx *context, strip *StripCmdGroup, stripEq *Str
 	if err := ctx.Client.Strip.Eq.SetQ(strip.Index.Index, stripEq.Band.Band, *cmd.Q); err != nil {
 		return fmt.Errorf("failed to set EQ band Q factor: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d EQ band %d Q factor set to: %.2f\n", strip.Index.Index, stripEq.Band.Band, *cmd.Q)
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d Q factor set to: %.2f\n", strip.Index.Index, stripEq.Band.Band, *cmd.Q)
 	return nil
 }
 
@@ -498,10 +1382,130 @@ func (cmd *StripEqBandTypeCmd) Run(ctx *context, strip *StripCmdGroup, stripEq *
 	if err := ctx.Client.Strip.Eq.SetType(strip.Index.Index, stripEq.Band.Band, *cmd.Type); err != nil {
 		return fmt.Errorf("failed to set EQ band type: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d EQ band %d type set to: %s\n", strip.Index.Index, stripEq.Band.Band, *cmd.Type)
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d type set to: %s\n", strip.Index.Index, stripEq.Band.Band, *cmd.Type)
+	return nil
+}
+
+// StripEqBandSetCmd defines the command for setting several parameters of a specific EQ band on a strip in one go, leaving any omitted parameters untouched.
+type StripEqBandSetCmd struct {
+	Gain *float64 `help:"The gain to set for the EQ band (in dB)."`
+	Freq *float64 `help:"The frequency to set for the EQ band (in Hz)."`
+	Q    *float64 `help:"The Q factor to set for the EQ band."`
+	Type *string  `help:"The type to set for the EQ band."              enum:"lcut,lshv,peq,veq,hshv,hcut"`
+}
+
+// Run executes the StripEqBandSetCmd command, applying all provided parameters to the specified EQ band on the strip.
+func (cmd *StripEqBandSetCmd) Run(ctx *context, strip *StripCmdGroup, stripEq *StripEqCmdGroup) error {
+	if cmd.Gain == nil && cmd.Freq == nil && cmd.Q == nil && cmd.Type == nil {
+		return fmt.Errorf("at least one of --gain, --freq, --q or --type must be provided")
+	}
+
+	params := xair.BandParams{
+		Gain:      cmd.Gain,
+		Frequency: cmd.Freq,
+		Q:         cmd.Q,
+		Type:      cmd.Type,
+	}
+	if err := ctx.Client.Strip.Eq.SetBand(strip.Index.Index, stripEq.Band.Band, params); err != nil {
+		return fmt.Errorf("failed to set EQ band parameters: %w", err)
+	}
+
+	if cmd.Type != nil {
+		fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d type set to: %s\n", strip.Index.Index, stripEq.Band.Band, *cmd.Type)
+	}
+	if cmd.Freq != nil {
+		fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d frequency set to: %.2f Hz\n", strip.Index.Index, stripEq.Band.Band, *cmd.Freq)
+	}
+	if cmd.Gain != nil {
+		fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d gain set to: %.2f\n", strip.Index.Index, stripEq.Band.Band, *cmd.Gain)
+	}
+	if cmd.Q != nil {
+		fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d Q factor set to: %.2f\n", strip.Index.Index, stripEq.Band.Band, *cmd.Q)
+	}
+	return nil
+}
+
+// StripEqBandCopyToCmd defines the command for copying the settings of a specific EQ band to a band of another strip.
+type StripEqBandCopyToCmd struct {
+	Strip int  `arg:"" help:"The index of the strip to copy the EQ band to. (1-based indexing)"`
+	Band  *int `       help:"The EQ band to copy to. Defaults to the band being copied."`
+}
+
+// indexes returns the strip being copied to, see validateIndexes.
+func (cmd *StripEqBandCopyToCmd) indexes(command string) (string, []int) {
+	return "strip", []int{cmd.Strip}
+}
+
+// Run executes the StripEqBandCopyToCmd command, reading the type, frequency, gain and Q of the band before writing them to the destination band.
+func (cmd *StripEqBandCopyToCmd) Run(ctx *context, strip *StripCmdGroup, stripEq *StripEqCmdGroup) error {
+	toBand := stripEq.Band.Band
+	if cmd.Band != nil {
+		toBand = *cmd.Band
+	}
+	if err := ctx.Client.ValidateIndex("strip EQ band", toBand); err != nil {
+		return exitError{err, exitUsage}
+	}
+	if cmd.Strip == strip.Index.Index && toBand == stripEq.Band.Band {
+		return fmt.Errorf("cannot copy EQ band %d of strip %d onto itself", toBand, cmd.Strip)
+	}
+
+	band, err := ctx.Client.Strip.Eq.CopyBand(strip.Index.Index, stripEq.Band.Band, cmd.Strip, toBand)
+	if err != nil {
+		return fmt.Errorf("failed to copy EQ band: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d copied to strip %d EQ band %d: %s, %.2f Hz, %.2f dB, Q %.2f\n",
+		strip.Index.Index, stripEq.Band.Band, cmd.Strip, toBand, band.Type, band.Frequency, band.Gain, band.Q)
+	return nil
+}
+
+// StripEqBandAuditionCmd defines the command for temporarily boosting a specific EQ band of a strip, so its effect can be heard.
+type StripEqBandAuditionCmd struct {
+	Gain float64       `help:"The gain to hold the EQ band at (in dB)."     default:"12"`
+	Hold time.Duration `help:"How long to hold the gain before restoring." default:"3s"`
+}
+
+// Run executes the StripEqBandAuditionCmd command, restoring the gain of the band when the hold ends or is interrupted.
+func (cmd *StripEqBandAuditionCmd) Run(ctx *context, strip *StripCmdGroup, stripEq *StripEqCmdGroup) error {
+	restored, err := auditionBand(
+		func() (float64, error) { return ctx.Client.Strip.Eq.Gain(strip.Index.Index, stripEq.Band.Band) },
+		func(gain float64) error {
+			return ctx.Client.Strip.Eq.SetGain(strip.Index.Index, stripEq.Band.Band, gain)
+		},
+		cmd.Gain, cmd.Hold,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to audition EQ band: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d EQ band %d gain restored to: %.2f dB\n", strip.Index.Index, stripEq.Band.Band, restored)
 	return nil
 }
 
+// auditionBand holds an EQ band at gain until hold has passed or the user interrupts, then restores the gain it had.
+// It returns the restored gain.
+func auditionBand(get func() (float64, error), set func(float64) error, gain float64, hold time.Duration) (float64, error) {
+	original, err := get()
+	if err != nil {
+		return 0, err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	if err := set(gain); err != nil {
+		return 0, errors.Join(err, set(original))
+	}
+	log.Infof("Holding EQ band at %.2f dB for %s, interrupt to restore early", gain, hold)
+
+	timer := time.NewTimer(hold)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-interrupt:
+	}
+	return original, set(original)
+}
+
 // StripCompCmdGroup defines the command group for controlling the compressor settings of a strip, including commands for getting and setting the compressor on/off state, mode, threshold, ratio, mix, makeup gain, attack time, hold time, and release time.
 type StripCompCmdGroup struct {
 	On        StripCompOnCmd        `help:"Get or set the compressor on/off state of the strip." cmd:""`
@@ -513,11 +1517,27 @@ type StripCompCmdGroup struct {
 	Attack    StripCompAttackCmd    `help:"Get or set the compressor attack time of the strip."  cmd:""`
 	Hold      StripCompHoldCmd      `help:"Get or set the compressor hold time of the strip."    cmd:""`
 	Release   StripCompReleaseCmd   `help:"Get or set the compressor release time of the strip." cmd:""`
+	Knee      StripCompKneeCmd      `help:"Get or set the compressor knee of the strip."         cmd:""`
+	Auto      StripCompAutoCmd      `help:"Get or set the compressor auto gain of the strip."    cmd:""`
+	KeySource StripCompKeySourceCmd `help:"Get or set the compressor key source of the strip."   cmd:"" name:"keysrc"`
+
+	Filter StripCompFilterCmdGroup `help:"Commands related to the compressor key filter of the strip." cmd:"filter"`
+	Set    StripCompSetCmd         `help:"Set several compressor parameters of the strip at once."     cmd:""`
+}
+
+// StripCompSetCmd defines the command for setting several compressor parameters of the strip in one go, leaving any omitted parameters untouched.
+type StripCompSetCmd struct {
+	CompSetFlags `embed:""`
+}
+
+// Run executes the StripCompSetCmd command, applying all provided compressor parameters to the strip.
+func (cmd *StripCompSetCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	return cmd.apply(ctx.Confirm, ctx.Client.Strip.Comp, strip.Index.Index, fmt.Sprintf("Strip %d", strip.Index.Index))
 }
 
 // StripCompOnCmd defines the command for getting or setting the compressor on/off state of a strip, allowing users to enable or disable the compressor effect on the strip.
 type StripCompOnCmd struct {
-	Enable *string `arg:"" help:"Whether to enable or disable the compressor." optional:"" enum:"true,false"`
+	Enable *string `arg:"" help:"Whether to enable or disable the compressor." optional:"" enum:"true,false,toggle"`
 }
 
 // Run executes the StripCompOnCmd command, either retrieving the current compressor on/off state of the strip or setting it based on the provided argument.
@@ -531,10 +1551,17 @@ func (cmd *StripCompOnCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Strip.Comp.SetOn(strip.Index.Index, *cmd.Enable == "true"); err != nil {
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.Comp.On(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get compressor state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Comp.SetOn(strip.Index.Index, state); err != nil {
 		return fmt.Errorf("failed to set compressor state: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor state set to: %s\n", strip.Index.Index, *cmd.Enable)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor state set to: %t\n", strip.Index.Index, state)
 	return nil
 }
 
@@ -557,13 +1584,13 @@ func (cmd *StripCompModeCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Comp.SetMode(strip.Index.Index, *cmd.Mode); err != nil {
 		return fmt.Errorf("failed to set compressor mode: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor mode set to: %s\n", strip.Index.Index, *cmd.Mode)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor mode set to: %s\n", strip.Index.Index, *cmd.Mode)
 	return nil
 }
 
 // StripCompThresholdCmd defines the command for getting or setting the compressor threshold of a strip, allowing users to specify the threshold level at which the compressor will start to reduce the signal level.
 type StripCompThresholdCmd struct {
-	Threshold *float64 `arg:"" help:"The compressor threshold to set (in dB)." optional:""`
+	Threshold *relativeFloat `arg:"" help:"The compressor threshold to set (in dB). Prefix with + or - for a relative change, e.g. +3 or -3, and put a negative value to set after --, e.g. -- -10." optional:""`
 }
 
 // Run executes the StripCompThresholdCmd command, either retrieving the current compressor threshold of the strip or setting it based on the provided argument.
@@ -577,16 +1604,24 @@ func (cmd *StripCompThresholdCmd) Run(ctx *context, strip *StripCmdGroup) error
 		return nil
 	}
 
-	if err := ctx.Client.Strip.Comp.SetThreshold(strip.Index.Index, *cmd.Threshold); err != nil {
+	threshold, err := cmd.Threshold.resolve(func() (float64, error) {
+		return ctx.Client.Strip.Comp.Threshold(strip.Index.Index)
+	}, minCompThreshold, maxCompThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to get current compressor threshold: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Comp.SetThreshold(strip.Index.Index, threshold); err != nil {
 		return fmt.Errorf("failed to set compressor threshold: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor threshold set to: %.2f\n", strip.Index.Index, *cmd.Threshold)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor threshold set to: %.2f%s\n", strip.Index.Index, threshold, cmd.Threshold.delta("dB"))
 	return nil
 }
 
 // StripCompRatioCmd defines the command for getting or setting the compressor ratio of a strip, allowing users to specify the amount of gain reduction applied by the compressor once the signal exceeds the threshold.
 type StripCompRatioCmd struct {
 	Ratio *float64 `arg:"" help:"The compressor ratio to set." optional:""`
+	Exact bool     `       help:"Reject a ratio that is not one of the valid values instead of using the nearest one."`
 }
 
 // Run executes the StripCompRatioCmd command, either retrieving the current compressor ratio of the strip or setting it based on the provided argument.
@@ -600,10 +1635,11 @@ func (cmd *StripCompRatioCmd) Run(ctx *context, strip *StripCmdGroup) error {
 		return nil
 	}
 
-	if err := ctx.Client.Strip.Comp.SetRatio(strip.Index.Index, *cmd.Ratio); err != nil {
+	ratio := ratioArg(*cmd.Ratio, cmd.Exact)
+	if err := ctx.Client.Strip.Comp.SetRatio(strip.Index.Index, ratio); err != nil {
 		return fmt.Errorf("failed to set compressor ratio: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor ratio set to: %.2f\n", strip.Index.Index, *cmd.Ratio)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor ratio set to: %.2f\n", strip.Index.Index, ratio)
 	return nil
 }
 
@@ -626,7 +1662,7 @@ func (cmd *StripCompMixCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Comp.SetMix(strip.Index.Index, *cmd.Mix); err != nil {
 		return fmt.Errorf("failed to set compressor mix: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor mix set to: %.2f%%\n", strip.Index.Index, *cmd.Mix)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor mix set to: %.2f%%\n", strip.Index.Index, *cmd.Mix)
 	return nil
 }
 
@@ -649,7 +1685,7 @@ func (cmd *StripCompMakeupCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Comp.SetMakeup(strip.Index.Index, *cmd.Makeup); err != nil {
 		return fmt.Errorf("failed to set compressor makeup gain: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor makeup gain set to: %.2f\n", strip.Index.Index, *cmd.Makeup)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor makeup gain set to: %.2f\n", strip.Index.Index, *cmd.Makeup)
 	return nil
 }
 
@@ -672,7 +1708,7 @@ func (cmd *StripCompAttackCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Comp.SetAttack(strip.Index.Index, *cmd.Attack); err != nil {
 		return fmt.Errorf("failed to set compressor attack time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor attack time set to: %.2f ms\n", strip.Index.Index, *cmd.Attack)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor attack time set to: %.2f ms\n", strip.Index.Index, *cmd.Attack)
 	return nil
 }
 
@@ -695,7 +1731,7 @@ func (cmd *StripCompHoldCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Comp.SetHold(strip.Index.Index, *cmd.Hold); err != nil {
 		return fmt.Errorf("failed to set compressor hold time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor hold time set to: %.2f ms\n", strip.Index.Index, *cmd.Hold)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor hold time set to: %.2f ms\n", strip.Index.Index, *cmd.Hold)
 	return nil
 }
 
@@ -718,6 +1754,149 @@ func (cmd *StripCompReleaseCmd) Run(ctx *context, strip *StripCmdGroup) error {
 	if err := ctx.Client.Strip.Comp.SetRelease(strip.Index.Index, *cmd.Release); err != nil {
 		return fmt.Errorf("failed to set compressor release time: %w", err)
 	}
-	fmt.Fprintf(ctx.Out, "Strip %d compressor release time set to: %.2f ms\n", strip.Index.Index, *cmd.Release)
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor release time set to: %.2f ms\n", strip.Index.Index, *cmd.Release)
+	return nil
+}
+
+// StripCompKneeCmd defines the command for getting or setting the compressor knee of a strip, allowing users to soften the transition into compression.
+type StripCompKneeCmd struct {
+	Knee *float64 `arg:"" help:"The compressor knee to set (0-5)." optional:""`
+}
+
+// Validate checks that the provided knee is within the valid range (0-5).
+func (cmd *StripCompKneeCmd) Validate() error {
+	if cmd.Knee != nil && (*cmd.Knee < 0 || *cmd.Knee > 5) {
+		return fmt.Errorf("compressor knee must be between 0 and 5")
+	}
+	return nil
+}
+
+// Run executes the StripCompKneeCmd command, either retrieving the current compressor knee of the strip or setting it based on the provided argument.
+func (cmd *StripCompKneeCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Knee == nil {
+		resp, err := ctx.Client.Strip.Comp.Knee(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get compressor knee: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d compressor knee: %.2f\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.Comp.SetKnee(strip.Index.Index, *cmd.Knee); err != nil {
+		return fmt.Errorf("failed to set compressor knee: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor knee set to: %.2f\n", strip.Index.Index, *cmd.Knee)
+	return nil
+}
+
+// StripCompAutoCmd defines the command for getting or setting the compressor auto gain state of a strip.
+type StripCompAutoCmd struct {
+	Enable *string `arg:"" help:"Whether to enable or disable compressor auto gain." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the StripCompAutoCmd command, either retrieving the current compressor auto gain state of the strip or setting it based on the provided argument.
+func (cmd *StripCompAutoCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Enable == nil {
+		resp, err := ctx.Client.Strip.Comp.Auto(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get compressor auto gain state: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d compressor auto gain state: %t\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.Comp.Auto(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get compressor auto gain state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Comp.SetAuto(strip.Index.Index, state); err != nil {
+		return fmt.Errorf("failed to set compressor auto gain state: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor auto gain state set to: %t\n", strip.Index.Index, state)
+	return nil
+}
+
+// StripCompKeySourceCmd defines the command for getting or setting the compressor key source of a strip, allowing users to key the compressor from another channel or bus.
+type StripCompKeySourceCmd struct {
+	Source *string `arg:"" help:"The compressor key source to set, e.g. self, ch02 or bus1." optional:""`
+}
+
+// Run executes the StripCompKeySourceCmd command, either retrieving the current compressor key source of the strip or setting it based on the provided argument.
+func (cmd *StripCompKeySourceCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Source == nil {
+		resp, err := ctx.Client.Strip.Comp.KeySource(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get compressor key source: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d compressor key source: %s\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.Comp.SetKeySource(strip.Index.Index, *cmd.Source); err != nil {
+		return fmt.Errorf("failed to set compressor key source: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor key source set to: %s\n", strip.Index.Index, *cmd.Source)
+	return nil
+}
+
+// StripCompFilterCmdGroup defines the command group for controlling the compressor key filter of a strip.
+type StripCompFilterCmdGroup struct {
+	On   StripCompFilterOnCmd   `help:"Get or set the compressor key filter on/off state of the strip." cmd:""`
+	Freq StripCompFilterFreqCmd `help:"Get or set the compressor key filter frequency of the strip."    cmd:""`
+}
+
+// StripCompFilterOnCmd defines the command for getting or setting the compressor key filter on/off state of a strip.
+type StripCompFilterOnCmd struct {
+	Enable *string `arg:"" help:"Whether to enable or disable the compressor key filter." optional:"" enum:"true,false,toggle"`
+}
+
+// Run executes the StripCompFilterOnCmd command, either retrieving the current compressor key filter on/off state of the strip or setting it based on the provided argument.
+func (cmd *StripCompFilterOnCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Enable == nil {
+		resp, err := ctx.Client.Strip.Comp.FilterOn(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get compressor key filter state: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d compressor key filter state: %t\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	state, err := resolveToggle(*cmd.Enable, func() (bool, error) {
+		return ctx.Client.Strip.Comp.FilterOn(strip.Index.Index)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get compressor key filter state: %w", err)
+	}
+
+	if err := ctx.Client.Strip.Comp.SetFilterOn(strip.Index.Index, state); err != nil {
+		return fmt.Errorf("failed to set compressor key filter state: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor key filter state set to: %t\n", strip.Index.Index, state)
+	return nil
+}
+
+// StripCompFilterFreqCmd defines the command for getting or setting the compressor key filter frequency of a strip.
+type StripCompFilterFreqCmd struct {
+	Frequency *float64 `arg:"" help:"The compressor key filter frequency to set (in Hz)." optional:""`
+}
+
+// Run executes the StripCompFilterFreqCmd command, either retrieving the current compressor key filter frequency of the strip or setting it based on the provided argument.
+func (cmd *StripCompFilterFreqCmd) Run(ctx *context, strip *StripCmdGroup) error {
+	if cmd.Frequency == nil {
+		resp, err := ctx.Client.Strip.Comp.FilterFreq(strip.Index.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get compressor key filter frequency: %w", err)
+		}
+		fmt.Fprintf(ctx.Out, "Strip %d compressor key filter frequency: %.2f Hz\n", strip.Index.Index, resp)
+		return nil
+	}
+
+	if err := ctx.Client.Strip.Comp.SetFilterFreq(strip.Index.Index, *cmd.Frequency); err != nil {
+		return fmt.Errorf("failed to set compressor key filter frequency: %w", err)
+	}
+	fmt.Fprintf(ctx.Confirm, "Strip %d compressor key filter frequency set to: %.2f Hz\n", strip.Index.Index, *cmd.Frequency)
 	return nil
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncCorrectsDrift(t *testing.T) {
+	client, mixer := newTestClient(t, "XR18")
+	var scene mixerState
+	for i := 1; i <= 2; i++ {
+		snap, err := client.Strip.Snapshot(i)
+		if err != nil {
+			t.Fatalf("failed to snapshot strip %d: %v", i, err)
+		}
+		scene.Strips = append(scene.Strips, snap)
+	}
+	data, err := json.Marshal(scene)
+	if err != nil {
+		t.Fatalf("failed to encode scene: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "scene.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write scene: %v", err)
+	}
+
+	mixer.Set("/ch/02/eq/2/g", float32(0.7)) // the desk drifts by +6 dB
+	out, err := runCommand(t, client, "sync", path)
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if strings.TrimSpace(out) != "Strip 2 reapplied from scene: eq" {
+		t.Errorf("got %q, want only the eq of strip 2 reapplied", out)
+	}
+	flush(t, client)
+	if got := mixer.Value("/ch/02/eq/2/g"); len(got) != 1 || got[0] != float32(0.5) {
+		t.Errorf("got strip 2 eq band 2 gain %v after sync, want it back at 0.5", got)
+	}
+
+	out, err = runCommand(t, client, "sync", path)
+	if err != nil {
+		t.Fatalf("second sync failed: %v", err)
+	}
+	if out != "" {
+		t.Errorf("got %q from a sync with nothing drifted, want no corrections", out)
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+)
+
+// undoLogSize is the number of commands kept in the undo log, older ones are dropped.
+const undoLogSize = 100
+
+// UndoCmd defines the command for reverting the most recent changes recorded with --track-undo.
+type UndoCmd struct {
+	Count int `arg:"" help:"The number of commands to revert." default:"1"`
+}
+
+// Run executes the UndoCmd command, reverting the last Count commands recorded on the connected mixer, newest first.
+// Changes recorded on other mixers are left in the log.
+func (cmd *UndoCmd) Run(ctx *context) error {
+	undo, err := newUndoLog()
+	if err != nil {
+		return err
+	}
+
+	records, err := undo.load()
+	if err != nil {
+		return fmt.Errorf("failed to read undo log: %w", err)
+	}
+	mixer := ctx.Client.Addr()
+	var matching []int
+	for i, record := range records {
+		if record.Mixer == mixer {
+			matching = append(matching, i)
+		}
+	}
+	if len(matching) == 0 {
+		return fmt.Errorf("nothing to undo on %s, changes are only recorded with --track-undo", mixer)
+	}
+
+	n := min(cmd.Count, len(matching))
+	for k := len(matching) - 1; k >= len(matching)-n; k-- {
+		i := matching[k]
+		changes := records[i].Changes
+		for j := len(changes) - 1; j >= 0; j-- {
+			change := changes[j]
+			previous, err := decodeOSCArgs(change.Previous)
+			if err != nil {
+				return fmt.Errorf("invalid undo log entry for %s: %w", change.Address, err)
+			}
+			if err := ctx.Client.SendMessage(change.Address, previous...); err != nil {
+				return fmt.Errorf("failed to revert %s: %w", change.Address, err)
+			}
+			fmt.Fprintf(ctx.Confirm, "Reverted %s to %v\n", change.Address, previous)
+		}
+
+		records = slices.Delete(records, i, i+1)
+		if err := undo.save(records); err != nil {
+			return fmt.Errorf("failed to update undo log: %w", err)
+		}
+	}
+	return nil
+}
+
+// undoRecord is the changes one command made to one mixer, they are reverted together.
+type undoRecord struct {
+	Mixer   string       `json:"mixer"`
+	Changes []undoChange `json:"changes"`
+}
+
+// undoChange is a single set within an undoRecord.
+type undoChange struct {
+	Address  string   `json:"address"`
+	Previous []oscArg `json:"previous"`
+	Value    []oscArg `json:"value"`
+}
+
+// oscArg is an OSC argument with its type tag, so int and float values survive the JSON round trip.
+type oscArg struct {
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// undoLog is a rolling log of changes stored as JSON lines in the user config directory.
+// Changes are held in pending until commit writes them as one record.
+type undoLog struct {
+	mu      sync.Mutex
+	path    string
+	pending []undoChange
+}
+
+// newUndoLog returns the undo log in the user config directory.
+func newUndoLog() (*undoLog, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return &undoLog{path: filepath.Join(dir, "xair-cli", "undo.jsonl")}, nil
+}
+
+// record adds a change to the pending record, it is used as the client's change tracking hook.
+// Repeated sets of an address, such as the steps of a fade, keep the value from before the first one.
+func (u *undoLog) record(change xair.Change) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	value := encodeOSCArgs(change.Value)
+	for i := range u.pending {
+		if u.pending[i].Address == change.Address {
+			u.pending[i].Value = value
+			return
+		}
+	}
+	u.pending = append(u.pending, undoChange{
+		Address:  change.Address,
+		Previous: encodeOSCArgs(change.Previous),
+		Value:    value,
+	})
+}
+
+// commit appends the pending changes to the log as one record for mixer, it does nothing when u is nil
+// or nothing changed. Failures are logged rather than returned so that a broken log never fails a command.
+func (u *undoLog) commit(mixer string) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.pending) == 0 {
+		return
+	}
+
+	records, err := u.load()
+	if err != nil {
+		log.Warnf("Failed to read undo log: %v", err)
+		return
+	}
+	records = append(records, undoRecord{Mixer: mixer, Changes: u.pending})
+	u.pending = nil
+	if len(records) > undoLogSize {
+		records = records[len(records)-undoLogSize:]
+	}
+	if err := u.save(records); err != nil {
+		log.Warnf("Failed to write undo log: %v", err)
+	}
+}
+
+// load reads all records from the log, a missing log is empty.
+func (u *undoLog) load() ([]undoRecord, error) {
+	f, err := os.Open(u.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []undoRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record undoRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// save replaces the log with records.
+func (u *undoLog) save(records []undoRecord) error {
+	if err := os.MkdirAll(filepath.Dir(u.path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(u.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeOSCArgs tags each OSC argument with its type.
+func encodeOSCArgs(args []any) []oscArg {
+	tagged := make([]oscArg, len(args))
+	for i, arg := range args {
+		switch arg.(type) {
+		case int32:
+			tagged[i] = oscArg{Type: "i", Value: arg}
+		case float32:
+			tagged[i] = oscArg{Type: "f", Value: arg}
+		default:
+			tagged[i] = oscArg{Type: "s", Value: fmt.Sprint(arg)}
+		}
+	}
+	return tagged
+}
+
+// decodeOSCArgs restores the OSC arguments written by encodeOSCArgs.
+func decodeOSCArgs(tagged []oscArg) ([]any, error) {
+	args := make([]any, len(tagged))
+	for i, arg := range tagged {
+		switch v := arg.Value.(type) {
+		case float64:
+			switch arg.Type {
+			case "i":
+				args[i] = int32(v)
+			case "f":
+				args[i] = float32(v)
+			default:
+				return nil, fmt.Errorf("unexpected numeric value for type %q", arg.Type)
+			}
+		case string:
+			args[i] = v
+		default:
+			return nil, fmt.Errorf("unsupported value %v", arg.Value)
+		}
+	}
+	return args, nil
+}
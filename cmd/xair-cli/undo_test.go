@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xair"
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+func TestOSCArgsRoundTrip(t *testing.T) {
+	args := []any{int32(3), float32(0.25), "Vocals"}
+	data, err := json.Marshal(encodeOSCArgs(args))
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	var tagged []oscArg
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	got, err := decodeOSCArgs(tagged)
+	if err != nil {
+		t.Fatalf("decodeOSCArgs failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, args) {
+		t.Errorf("got %#v, want %#v", got, args)
+	}
+}
+
+func TestUndoLogSaveLoadRoundTrip(t *testing.T) {
+	type change struct {
+		address  string
+		previous []any
+	}
+	want := map[string][]change{
+		"10.0.0.1:10024": {{"/ch/01/mix/fader", []any{float32(0.75)}}, {"/ch/01/mix/on", []any{int32(1)}}},
+		"10.0.0.2:10024": {{"/ch/02/config/name", []any{"Old"}}},
+	}
+	var records []undoRecord
+	for _, mixer := range []string{"10.0.0.1:10024", "10.0.0.2:10024"} {
+		record := undoRecord{Mixer: mixer}
+		for _, c := range want[mixer] {
+			record.Changes = append(record.Changes, undoChange{Address: c.address, Previous: encodeOSCArgs(c.previous)})
+		}
+		records = append(records, record)
+	}
+
+	undo := &undoLog{path: filepath.Join(t.TempDir(), "undo.jsonl")}
+	if err := undo.save(records); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	got, err := undo.load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if len(got) != len(records) {
+		t.Fatalf("loaded %d records, want %d", len(got), len(records))
+	}
+	for _, record := range got {
+		changes := want[record.Mixer]
+		if len(record.Changes) != len(changes) {
+			t.Fatalf("got %d changes for %s, want %d", len(record.Changes), record.Mixer, len(changes))
+		}
+		for i, c := range changes {
+			previous, err := decodeOSCArgs(record.Changes[i].Previous)
+			if err != nil {
+				t.Fatalf("failed to decode %s: %v", c.address, err)
+			}
+			if record.Changes[i].Address != c.address || !reflect.DeepEqual(previous, c.previous) {
+				t.Errorf("got %s %#v, want %s %#v", record.Changes[i].Address, previous, c.address, c.previous)
+			}
+		}
+	}
+}
+
+// newTrackedClient connects a client to a fake mixer, recording its changes in undo.
+func newTrackedClient(t *testing.T, undo *undoLog) (*xair.XAirClient, *xairtest.Mixer) {
+	t.Helper()
+	mixer := xairtest.NewMixer(t, "XR18")
+	client, err := xair.NewXAirClient(mixer.Host(), mixer.Port(), xair.WithTimeout(time.Second), xair.WithChangeTracking(undo.record))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	client.StartListening()
+	if _, err := client.RequestInfo(); err != nil {
+		t.Fatalf("failed to request mixer info: %v", err)
+	}
+	return client, mixer
+}
+
+func TestUndoRevertsACommandOnTheSameMixer(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	undo, err := newUndoLog()
+	if err != nil {
+		t.Fatalf("failed to open undo log: %v", err)
+	}
+
+	client, mixer := newTrackedClient(t, undo)
+	mixer.Set("/ch/01/mix/fader", float32(0.75))
+	if _, err := runCommand(t, client, "strip", "1", "fadeout", "--duration", "10ms"); err != nil {
+		t.Fatalf("fadeout failed: %v", err)
+	}
+	undo.commit(client.Addr())
+
+	records, err := undo.load()
+	if err != nil {
+		t.Fatalf("failed to read undo log: %v", err)
+	}
+	if len(records) != 1 || len(records[0].Changes) != 1 || records[0].Mixer != client.Addr() {
+		t.Fatalf("got undo log %+v, want one record of one change on %s", records, client.Addr())
+	}
+
+	other, _ := newTestClient(t, "XR18")
+	if _, err := runCommand(t, other, "undo"); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Errorf("undo on another mixer returned %v, want nothing to undo", err)
+	}
+
+	if _, err := runCommand(t, client, "undo"); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	flush(t, client)
+	if got := mixer.Value("/ch/01/mix/fader"); len(got) != 1 || got[0] != float32(0.75) {
+		t.Errorf("got fader %v after undo, want 0.75", got)
+	}
+	if records, _ := undo.load(); len(records) != 0 {
+		t.Errorf("got %d records after undo, want none", len(records))
+	}
+}
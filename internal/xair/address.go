@@ -4,8 +4,21 @@ var xairAddressMap = map[string]string{
 	"main":     "/lr",
 	"strip":    "/ch/%02d",
 	"bus":      "/bus/%01d",
+	"busgeq":   "/bus/%01d/geq",
 	"headamp":  "/headamp/%02d",
+	"insrc":    "/ch/%02d/config/insrc",
+	"insslot":  "/ch/%02d/insert/fxslot",
+	"mainasgn": "/ch/%02d/mix/lr",
+	"sendtap":  "/ch/%02d/mix/%02d/tap",
+	"trim":     "/headamp/%02d/gain",
 	"snapshot": "/-snap",
+	"dim":      "/config/solo/dim",
+	"dimatt":   "/config/solo/dimatt",
+	"monomon":  "/config/solo/mono",
+	"monlevel": "/config/solo/level",
+	"monsrc":   "/config/solo/source",
+	"chlink":   "/config/chlink/%d-%d",
+	"buslink":  "/config/buslink/%d-%d",
 }
 
 var x32AddressMap = map[string]string{
@@ -14,8 +27,21 @@ var x32AddressMap = map[string]string{
 	"matrix":   "/mtx/%02d",
 	"strip":    "/ch/%02d",
 	"bus":      "/bus/%02d",
+	"busgeq":   "", // X32 graphic EQs are FX slot effects, buses have none
 	"headamp":  "/headamp/%03d",
+	"insrc":    "/ch/%02d/config/source",
+	"insslot":  "/ch/%02d/insert/sel",
+	"mainasgn": "/ch/%02d/mix/st",
+	"sendtap":  "/ch/%02d/mix/%02d/type",
+	"trim":     "/ch/%02d/preamp/trim",
 	"snapshot": "/-snap",
+	"dim":      "/config/solo/dim",
+	"dimatt":   "/config/solo/dimatt",
+	"monomon":  "/config/solo/mono",
+	"monlevel": "/config/solo/level",
+	"monsrc":   "/config/solo/source",
+	"chlink":   "/config/chlink/%d-%d",
+	"buslink":  "/config/buslink/%d-%d",
 }
 
 func addressMapFromMixerKind(kind mixerKind) map[string]string {
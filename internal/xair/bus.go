@@ -1,12 +1,19 @@
 package xair
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/log"
+)
 
 type Bus struct {
 	client      *Client
 	baseAddress string
 	Eq          *Eq
 	Comp        *Comp
+	link        *Link
+	strip       *Strip
 }
 
 // newBus creates a new Bus instance
@@ -14,26 +21,23 @@ func newBus(c *Client) *Bus {
 	return &Bus{
 		client:      c,
 		baseAddress: c.addressMap["bus"],
-		Eq:          newEq(c, c.addressMap["bus"]),
+		Eq:          newEq(c, c.addressMap["bus"], WithGraphicEqAddress(c.addressMap["busgeq"])),
 		Comp:        newComp(c, c.addressMap["bus"]),
+		link:        newLink(c),
+		strip:       newStrip(c),
 	}
 }
 
 // Mute requests the current mute status for a bus
 func (b *Bus) Mute(bus int) (bool, error) {
 	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/on"
-	err := b.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := b.client.ReceiveMessage()
+	msg, err := b.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for bus mute value")
+		return false, fmt.Errorf("%w for bus mute value", ErrUnexpectedType)
 	}
 	return val == 0, nil
 }
@@ -51,18 +55,13 @@ func (b *Bus) SetMute(bus int, muted bool) error {
 // Fader requests the current fader level for a bus
 func (b *Bus) Fader(bus int) (float64, error) {
 	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/fader"
-	err := b.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := b.client.ReceiveMessage()
+	msg, err := b.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for bus fader value")
+		return 0, fmt.Errorf("%w for bus fader value", ErrUnexpectedType)
 	}
 
 	return mustDbFrom(float64(val)), nil
@@ -77,24 +76,236 @@ func (b *Bus) SetFader(bus int, level float64) error {
 // Name requests the name for a specific bus
 func (b *Bus) Name(bus int) (string, error) {
 	address := fmt.Sprintf(b.baseAddress, bus) + "/config/name"
-	err := b.client.SendMessage(address)
-	if err != nil {
-		return "", fmt.Errorf("failed to send bus name request: %v", err)
-	}
-
-	msg, err := b.client.ReceiveMessage()
+	msg, err := b.client.query(address)
 	if err != nil {
 		return "", err
 	}
 	val, ok := msg.Arguments[0].(string)
 	if !ok {
-		return "", fmt.Errorf("unexpected argument type for bus name value")
+		return "", fmt.Errorf("%w for bus name value", ErrUnexpectedType)
 	}
 	return val, nil
 }
 
-// SetName sets the name for a specific bus
+// SetName sets the name for a specific bus, names longer than MaxNameLength are rejected
 func (b *Bus) SetName(bus int, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
 	address := fmt.Sprintf(b.baseAddress, bus) + "/config/name"
 	return b.client.SendMessage(address, name)
 }
+
+// SendFrom holds the level and on/off status of the send from a strip into a mixbus.
+// On is nil on XAir mixers, which have no per-send switch.
+type SendFrom struct {
+	Strip int     `json:"strip"`
+	Level float64 `json:"level"`
+	On    *bool   `json:"on,omitempty"`
+}
+
+// Off reports whether the send passes no signal, because it is switched off or all the way down.
+func (s SendFrom) Off() bool {
+	return s.Level <= -90 || (s.On != nil && !*s.On)
+}
+
+// Inputs requests the send from every strip into the specified bus (1-based indexing), loudest first.
+// Strips sending at the same level keep their strip order.
+func (b *Bus) Inputs(bus int) ([]SendFrom, error) {
+	inputs := make([]SendFrom, b.client.StripCount())
+	for i := range inputs {
+		input := &inputs[i]
+		input.Strip = i + 1
+
+		var err error
+		if input.Level, err = b.strip.SendLevel(input.Strip, bus); err != nil {
+			return nil, err
+		}
+		if b.client.Kind == kindX32 {
+			on, err := b.strip.SendOn(input.Strip, bus)
+			if err != nil {
+				return nil, err
+			}
+			input.On = &on
+		}
+	}
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return inputs[i].Level > inputs[j].Level
+	})
+	return inputs, nil
+}
+
+// SendLevel requests the send level from a bus to a matrix.
+func (b *Bus) SendLevel(bus int, matrix int) (float64, error) {
+	address := fmt.Sprintf(b.baseAddress, bus) + fmt.Sprintf("/mix/%02d/level", matrix)
+	msg, err := b.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for bus send level value", ErrUnexpectedType)
+	}
+	return mustDbFrom(float64(val)), nil
+}
+
+// SetSendLevel sets the send level from a bus to a matrix.
+func (b *Bus) SetSendLevel(bus int, matrix int, level float64) error {
+	address := fmt.Sprintf(b.baseAddress, bus) + fmt.Sprintf("/mix/%02d/level", matrix)
+	return b.client.SendMessage(address, float32(mustDbInto(level)))
+}
+
+// Pan requests the pan position of the specified bus (-100 to 100).
+func (b *Bus) Pan(bus int) (float64, error) {
+	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/pan"
+	msg, err := b.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for bus pan value", ErrUnexpectedType)
+	}
+	return linGet(panRange.min, panRange.max, float64(val)), nil
+}
+
+// PanMode reports how the pan parameter of the specified bus behaves: "balance" when it is part of a linked pair, otherwise "pan".
+func (b *Bus) PanMode(bus int) (string, error) {
+	linked, err := b.link.BusLinked(bus)
+	if err != nil {
+		return "", err
+	}
+	if linked {
+		return "balance", nil
+	}
+	return "pan", nil
+}
+
+// SetPan sets the pan position of the specified bus (-100 to 100).
+// For a linked pair the mixer treats the same parameter as the balance of the pair, in verbose mode the mode in use is logged.
+func (b *Bus) SetPan(bus int, pan float64) error {
+	if err := panRange.check("pan", pan); err != nil {
+		return err
+	}
+	if b.client.tracer != nil {
+		mode, err := b.PanMode(bus)
+		if err != nil {
+			return err
+		}
+		b.client.tracer.Printf("bus %d pan applied as %s", bus, mode)
+	}
+
+	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/pan"
+	return b.client.SendMessage(address, float32(linSet(panRange.min, panRange.max, pan)))
+}
+
+// MonoSend requests whether the specified bus is sent to the mono/center bus (X32 only).
+func (b *Bus) MonoSend(bus int) (bool, error) {
+	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/mono"
+	msg, err := b.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for bus mono send value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetMonoSend sets whether the specified bus is sent to the mono/center bus (X32 only).
+func (b *Bus) SetMonoSend(bus int, on bool) error {
+	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/mono"
+	var value int32
+	if on {
+		value = 1
+	}
+	return b.client.SendMessage(address, value)
+}
+
+// MonoLevel requests the mono/center bus send level of the specified bus (X32 only).
+func (b *Bus) MonoLevel(bus int) (float64, error) {
+	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/mlevel"
+	msg, err := b.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for bus mono level value", ErrUnexpectedType)
+	}
+	return mustDbFrom(float64(val)), nil
+}
+
+// SetMonoLevel sets the mono/center bus send level of the specified bus (X32 only).
+func (b *Bus) SetMonoLevel(bus int, level float64) error {
+	address := fmt.Sprintf(b.baseAddress, bus) + "/mix/mlevel"
+	return b.client.SendMessage(address, float32(mustDbInto(level)))
+}
+
+// BusSnapshot holds the settings of a bus.
+type BusSnapshot struct {
+	Name  string       `json:"name"`
+	Mute  bool         `json:"mute"`
+	Fader float64      `json:"fader"`
+	Eq    EqSnapshot   `json:"eq"`
+	Comp  CompSnapshot `json:"comp"`
+}
+
+// Snapshot reads all settings of the specified bus (1-based indexing).
+func (b *Bus) Snapshot(bus int) (BusSnapshot, error) {
+	var snap BusSnapshot
+	var err error
+	if !b.configMixFromNode(bus, &snap) {
+		if snap.Name, err = b.Name(bus); err != nil {
+			return snap, err
+		}
+		if snap.Mute, err = b.Mute(bus); err != nil {
+			return snap, err
+		}
+		if snap.Fader, err = b.Fader(bus); err != nil {
+			return snap, err
+		}
+	}
+	if snap.Eq, err = b.Eq.Snapshot(bus, b.client.EqBandCount("bus")); err != nil {
+		return snap, err
+	}
+	if snap.Comp, err = b.Comp.Snapshot(bus); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
+
+// ApplySnapshot writes all settings from snap to the specified bus (1-based indexing).
+// The fader and mute state are written last, like Strip.ApplySnapshot.
+func (b *Bus) ApplySnapshot(bus int, snap BusSnapshot) error {
+	if err := b.SetName(bus, snap.Name); err != nil {
+		return err
+	}
+	if err := b.Eq.ApplySnapshot(bus, snap.Eq); err != nil {
+		return err
+	}
+	if err := b.Comp.ApplySnapshot(bus, snap.Comp); err != nil {
+		return err
+	}
+	if err := b.SetFader(bus, snap.Fader); err != nil {
+		return err
+	}
+	return b.SetMute(bus, snap.Mute)
+}
+
+// configMixFromNode fills the name, mute state and fader level of snap with /node queries where the mixer supports them.
+// It reports false when the values must be read one parameter at a time instead.
+func (b *Bus) configMixFromNode(bus int, snap *BusSnapshot) bool {
+	if b.client.Kind != kindX32 {
+		return false
+	}
+	base := fmt.Sprintf(b.baseAddress, bus)
+	cm, err := b.client.nodeConfigMix(base)
+	if err != nil {
+		log.Debugf("/node query for %s failed, reading single parameters: %v", base, err)
+		return false
+	}
+	snap.Name, snap.Mute, snap.Fader = cm.name, cm.mute, cm.fader
+	return true
+}
@@ -0,0 +1,93 @@
+package xair
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestX32BusAddresses(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     func(c *X32Client) error
+		address string
+		want    any
+	}{
+		{"pan", func(c *X32Client) error { return c.Bus.SetPan(3, 50) }, "/bus/03/mix/pan", float32(0.75)},
+		{"mono send", func(c *X32Client) error { return c.Bus.SetMonoSend(3, true) }, "/bus/03/mix/mono", int32(1)},
+		{"mono level", func(c *X32Client) error { return c.Bus.SetMonoLevel(3, 0) }, "/bus/03/mix/mlevel", float32(0.75)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestX32Client(t)
+			if err := tt.set(client); err != nil {
+				t.Fatalf("set failed: %v", err)
+			}
+			flush(t, &client.Client)
+			if got := mixer.Value(tt.address); len(got) != 1 || got[0] != tt.want {
+				t.Errorf("got %s = %v, want [%v]", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestX32BusPanRoundTrip(t *testing.T) {
+	client, _ := newTestX32Client(t)
+	if err := client.Bus.SetPan(5, -30); err != nil {
+		t.Fatalf("failed to set pan: %v", err)
+	}
+	got, err := client.Bus.Pan(5)
+	if err != nil {
+		t.Fatalf("failed to read pan: %v", err)
+	}
+	if !near(got, -30) {
+		t.Errorf("got pan %g, want -30", got)
+	}
+}
+
+func TestBusInputs(t *testing.T) {
+	client, mixer := newTestClient(t)
+	for i := 1; i <= client.StripCount(); i++ {
+		mixer.Set(fmt.Sprintf("/ch/%02d/mix/02/level", i), float32(0))
+	}
+	mixer.Set("/ch/05/mix/02/level", float32(0.5))
+	mixer.Set("/ch/03/mix/02/level", float32(0.75))
+	mixer.Set("/ch/07/mix/02/level", float32(0.5))
+
+	inputs, err := client.Bus.Inputs(2)
+	if err != nil {
+		t.Fatalf("failed to read inputs: %v", err)
+	}
+	if len(inputs) != client.StripCount() {
+		t.Fatalf("got %d inputs, want one per strip", len(inputs))
+	}
+	want := []struct {
+		strip int
+		level float64
+	}{{3, 0}, {5, -10}, {7, -10}}
+	for i, w := range want {
+		if inputs[i].Strip != w.strip || !near(inputs[i].Level, w.level) || inputs[i].Off() {
+			t.Errorf("input %d is strip %d at %g dB, want strip %d at %g dB", i, inputs[i].Strip, inputs[i].Level, w.strip, w.level)
+		}
+	}
+	if !inputs[len(want)].Off() {
+		t.Errorf("input %d is not off, want every other send off", len(want))
+	}
+}
+
+func TestX32BusInputsReadSendSwitch(t *testing.T) {
+	client, mixer := newTestX32Client(t)
+	mixer.Set("/ch/01/mix/04/on", int32(1))
+
+	inputs, err := client.Bus.Inputs(4)
+	if err != nil {
+		t.Fatalf("failed to read inputs: %v", err)
+	}
+	for _, input := range inputs {
+		if input.On == nil {
+			t.Fatalf("strip %d has no send switch, want one on the X32", input.Strip)
+		}
+		if want := input.Strip == 1; *input.On != want || input.Off() == want {
+			t.Errorf("strip %d send on %t, off %t, want on %t", input.Strip, *input.On, input.Off(), want)
+		}
+	}
+}
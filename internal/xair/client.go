@@ -2,6 +2,7 @@ package xair
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -9,6 +10,13 @@ import (
 	"github.com/hypebeast/go-osc/osc"
 )
 
+// Client is the shared connection used by every parameter group.
+//
+// A Client is safe for concurrent use. Getters go through query, which registers a waiter for the
+// address it requests, and the receive loop hands every reply to the waiter for its address, so
+// goroutines sharing a client never read each other's replies and may have requests in flight at once.
+// SendMessage may be called concurrently on its own; callers pairing it with ReceiveMessage by hand
+// only see the messages no getter is waiting for.
 type Client struct {
 	*engine
 }
@@ -21,6 +29,7 @@ type XAirClient struct {
 	Bus      *Bus
 	HeadAmp  *HeadAmp
 	Snapshot *Snapshot
+	Link     *Link
 }
 
 // X32Client is a client for controlling X32 mixers
@@ -33,6 +42,7 @@ type X32Client struct {
 	Bus      *Bus
 	HeadAmp  *HeadAmp
 	Snapshot *Snapshot
+	Link     *Link
 }
 
 // NewX32Client creates a new X32Client instance with optional engine configuration
@@ -52,6 +62,8 @@ func NewX32Client(mixerIP string, mixerPort int, opts ...EngineOption) (*X32Clie
 	c.Bus = newBus(&c.Client)
 	c.HeadAmp = newHeadAmp(&c.Client)
 	c.Snapshot = newSnapshot(&c.Client)
+	c.Link = newLink(&c.Client)
+	c.drain()
 
 	return c, nil
 }
@@ -71,12 +83,17 @@ func NewXAirClient(mixerIP string, mixerPort int, opts ...EngineOption) (*XAirCl
 	c.Bus = newBus(&c.Client)
 	c.HeadAmp = newHeadAmp(&c.Client)
 	c.Snapshot = newSnapshot(&c.Client)
+	c.Link = newLink(&c.Client)
+	c.drain()
 
 	return c, nil
 }
 
-// Start begins listening for messages in a goroutine
+// Start begins listening for messages in a goroutine.
+// Datagrams that arrived before it, such as updates of an /xremote subscription made by an earlier session, are discarded.
 func (c *Client) StartListening() {
+	c.drain()
+	c.engine.listening.Store(true)
 	go c.engine.receiveLoop()
 	log.Debugf("Started listening on %s...", c.engine.conn.LocalAddr().String())
 }
@@ -89,17 +106,89 @@ func (c *Client) Close() {
 	}
 }
 
-// SendMessage sends an OSC message to the mixer using the unified connection
+// SendMessage sends an OSC message to the mixer using the unified connection.
+// With link mirroring on, a set of a linked strip or bus is repeated on the other strip or bus of its pair.
 func (c *Client) SendMessage(address string, args ...any) error {
-	return c.engine.sendToAddress(c.mixerAddr, address, args...)
+	if err := c.send(address, args...); err != nil {
+		return err
+	}
+	if !c.mirror || len(args) == 0 {
+		return nil
+	}
+
+	partner, ok, err := c.linkPartner(address)
+	if err != nil || !ok {
+		return err
+	}
+	return c.send(partner, args...)
+}
+
+// send sends a single OSC message, running the change and skip hooks around it.
+func (c *Client) send(address string, args ...any) error {
+	var previous []any
+	if (c.changeHook != nil || c.skipHook != nil) && len(args) > 0 {
+		msg, err := c.request(address)
+		if err != nil {
+			log.Warnf("Failed to read the current value of %s: %v", address, err)
+		} else {
+			previous = msg.Arguments
+		}
+	}
+
+	if c.skipHook != nil && previous != nil && argsEqual(previous, args) {
+		c.skipHook(address)
+		return nil
+	}
+
+	if err := c.write(address, args...); err != nil {
+		return err
+	}
+
+	if c.changeHook != nil && previous != nil {
+		c.changeHook(Change{Address: address, Previous: previous, Value: args})
+	}
+	return nil
+}
+
+// sendAction sends a message that triggers an action, such as loading a snapshot, rather than setting a parameter.
+// An action has no current value to compare with or to restore, so the change and skip hooks are bypassed.
+func (c *Client) sendAction(address string, args ...any) error {
+	return c.write(address, args...)
+}
+
+// write sends a single OSC message and waits for the settle time after a set.
+func (c *Client) write(address string, args ...any) error {
+	if c.tracer != nil {
+		c.tracer.Printf("-> %s %v", address, args)
+	}
+	if err := c.engine.sendToAddress(c.mixerAddr, address, args...); err != nil {
+		return err
+	}
+	if c.settle > 0 && len(args) > 0 {
+		time.Sleep(c.settle)
+	}
+	return nil
 }
 
 // ReceiveMessage receives an OSC message from the mixer
 func (c *Client) ReceiveMessage() (*osc.Message, error) {
+	msg, err := c.receiveMessage()
+	if c.tracer != nil {
+		if err != nil {
+			c.tracer.Printf("<- %v", err)
+		} else {
+			c.tracer.Printf("<- %s %v", msg.Address, msg.Arguments)
+		}
+	}
+	return msg, err
+}
+
+// receiveMessage waits for the next OSC message from the receive loop
+func (c *Client) receiveMessage() (*osc.Message, error) {
 	t := time.Tick(c.engine.timeout)
 	select {
 	case <-t:
-		return nil, fmt.Errorf("timeout waiting for response")
+		return nil, ErrTimeout
 	case msg := <-c.respChan:
 		if msg == nil {
 			return nil, fmt.Errorf("no message received")
@@ -108,15 +197,131 @@ func (c *Client) ReceiveMessage() (*osc.Message, error) {
 	}
 }
 
-// RequestInfo requests mixer information
-func (c *Client) RequestInfo() (InfoResponse, error) {
-	var info InfoResponse
-	err := c.SendMessage("/xinfo")
+// query requests the value of address for a getter, passing the raw reply to the raw value hook if one is set.
+// A reply without arguments is an error wrapping ErrNoValue, so getters can read the first argument unchecked.
+func (c *Client) query(address string) (*osc.Message, error) {
+	msg, err := c.request(address)
 	if err != nil {
-		return info, err
+		return nil, err
+	}
+	if c.rawHook != nil {
+		c.rawHook(address, msg.Arguments)
+	}
+	if len(msg.Arguments) == 0 {
+		return nil, fmt.Errorf("%w for %s, the parameter may not exist on this model", ErrNoValue, address)
+	}
+	return msg, nil
+}
+
+// request sends a request to address and waits for the reply to that same address.
+// The receive loop hands the reply to the waiter registered for its address, so requests for different
+// addresses may be in flight at once. Requests for the same address are answered in the order they were made.
+// A reply that arrives after its request timed out is discarded, see abandon.
+func (c *Client) request(address string) (*osc.Message, error) {
+	reply := c.await(address)
+	if err := c.SendMessage(address); err != nil {
+		c.cancel(address, reply)
+		return nil, err
 	}
 
-	msg, err := c.ReceiveMessage()
+	select {
+	case msg := <-reply:
+		if c.tracer != nil {
+			c.tracer.Printf("<- %s %v", msg.Address, msg.Arguments)
+		}
+		return msg, nil
+	case <-time.After(c.engine.timeout):
+		c.abandon(address, reply)
+		if c.tracer != nil {
+			c.tracer.Printf("<- timeout waiting for %s", address)
+		}
+		return nil, ErrTimeout
+	}
+}
+
+// await registers a waiter for the next reply to address.
+func (c *Client) await(address string) chan *osc.Message {
+	reply := make(chan *osc.Message, 1)
+	c.waitMu.Lock()
+	c.waiters[address] = append(c.waiters[address], reply)
+	c.waitMu.Unlock()
+	return reply
+}
+
+// cancel removes the waiter of a request that could not be sent, no reply is on the way.
+func (c *Client) cancel(address string, reply chan *osc.Message) {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	waiting := c.waiters[address]
+	for i, w := range waiting {
+		if w == reply {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+	if len(waiting) == 0 {
+		delete(c.waiters, address)
+	} else {
+		c.waiters[address] = waiting
+	}
+}
+
+// abandon removes a waiter that timed out. Its reply may still be on the way, dispatch discards the next
+// reply to address in its place, so a later request for the same address never takes it for its own answer.
+// A reply that is lost for good costs the next request for the address a timeout instead of a stale value.
+func (c *Client) abandon(address string, reply chan *osc.Message) {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	waiting := c.waiters[address]
+	i := slices.Index(waiting, reply)
+	if i < 0 {
+		// dispatch handed the reply over as the timeout fired, nothing is owed
+		return
+	}
+	if len(waiting) == 1 {
+		delete(c.waiters, address)
+	} else {
+		c.waiters[address] = slices.Delete(waiting, i, i+1)
+	}
+	c.late[address]++
+}
+
+// drain discards any messages already waiting in the receive channel without blocking.
+// Before StartListening nothing reads the socket, so the datagrams buffered on it are discarded instead.
+func (c *Client) drain() {
+	if !c.engine.listening.Load() {
+		c.drainSocket()
+	}
+	for {
+		select {
+		case msg := <-c.respChan:
+			if msg != nil {
+				log.Debugf("Discarding stale message for %s", msg.Address)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// drainSocket reads and discards the datagrams buffered on the socket, it must not run alongside receiveLoop.
+func (c *Client) drainSocket() {
+	buffer := make([]byte, 4096)
+	defer c.engine.conn.SetReadDeadline(time.Time{})
+	for {
+		// A deadline in the past fails the read before it looks at the buffer, so allow a moment.
+		c.engine.conn.SetReadDeadline(time.Now().Add(time.Millisecond))
+		if _, _, err := c.engine.conn.ReadFromUDP(buffer); err != nil {
+			return
+		}
+		log.Debugf("Discarding stale datagram")
+	}
+}
+
+// RequestInfo requests mixer information
+func (c *Client) RequestInfo() (InfoResponse, error) {
+	var info InfoResponse
+	msg, err := c.request("/xinfo")
 	if err != nil {
 		return info, err
 	}
@@ -124,10 +329,25 @@ func (c *Client) RequestInfo() (InfoResponse, error) {
 		info.Host = msg.Arguments[0].(string)
 		info.Name = msg.Arguments[1].(string)
 		info.Model = msg.Arguments[2].(string)
+		c.model = info.Model
 	}
 	return info, nil
 }
 
+// Ping requests mixer information and returns the time the reply took to arrive.
+func (c *Client) Ping() (time.Duration, error) {
+	start := time.Now()
+	if _, err := c.request("/xinfo"); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// Addr returns the address of the mixer as host:port.
+func (c *Client) Addr() string {
+	return c.mixerAddr.String()
+}
+
 // KeepAlive sends keep-alive message (required for multi-client usage)
 func (c *Client) KeepAlive() error {
 	return c.SendMessage("/xremote")
@@ -0,0 +1,335 @@
+package xair
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+// newTestClient connects an XAir client to a fake XR18, replies time out after a second unless opts say otherwise.
+func newTestClient(t *testing.T, opts ...EngineOption) (*XAirClient, *xairtest.Mixer) {
+	t.Helper()
+	mixer := xairtest.NewMixer(t, "XR18")
+	opts = append([]EngineOption{WithTimeout(time.Second)}, opts...)
+	client, err := NewXAirClient(mixer.Host(), mixer.Port(), opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	client.StartListening()
+	if _, err := client.RequestInfo(); err != nil {
+		t.Fatalf("failed to request mixer info: %v", err)
+	}
+	return client, mixer
+}
+
+// newTestX32Client connects an X32 client to a fake X32, replies time out after a second.
+func newTestX32Client(t *testing.T) (*X32Client, *xairtest.Mixer) {
+	t.Helper()
+	mixer := xairtest.NewMixer(t, "X32")
+	client, err := NewX32Client(mixer.Host(), mixer.Port(), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	client.StartListening()
+	return client, mixer
+}
+
+func TestConcurrentGettersReceiveTheirOwnReplies(t *testing.T) {
+	client, mixer := newTestClient(t)
+	for i := 1; i <= client.StripCount(); i++ {
+		mixer.Set(fmt.Sprintf("/ch/%02d/config/name", i), fmt.Sprintf("Strip %d", i))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, client.StripCount())
+	for i := 1; i <= client.StripCount(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				name, err := client.Strip.Name(i)
+				if err != nil {
+					errs <- fmt.Errorf("strip %d: %w", i, err)
+					return
+				}
+				if want := fmt.Sprintf("Strip %d", i); name != want {
+					errs <- fmt.Errorf("strip %d: got name %q, want %q", i, name, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestLateReplyIsDiscarded(t *testing.T) {
+	client, mixer := newTestClient(t, WithTimeout(100*time.Millisecond))
+	const address = "/ch/01/config/name"
+
+	mixer.Set(address, "old")
+	mixer.Delay(address, 150*time.Millisecond)
+	if _, err := client.Strip.Name(1); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got error %v, want %v", err, ErrTimeout)
+	}
+
+	// The reply to the timed out request arrives while the next request for the address is waiting.
+	mixer.Set(address, "new")
+	mixer.Delay(address, 0)
+	name, err := client.Strip.Name(1)
+	if err != nil {
+		t.Fatalf("failed to read name: %v", err)
+	}
+	if name != "new" {
+		t.Errorf("got name %q from the late reply, want %q", name, "new")
+	}
+}
+
+func TestGetterRejectsEmptyReply(t *testing.T) {
+	client, mixer := newTestClient(t)
+	mixer.Empty("/ch/01/mix/fader")
+
+	if _, err := client.Strip.Fader(1); !errors.Is(err, ErrNoValue) {
+		t.Errorf("got error %v, want %v", err, ErrNoValue)
+	}
+}
+
+func TestEnumGetterRejectsOutOfRangeReply(t *testing.T) {
+	client, mixer := newTestClient(t)
+	tests := []struct {
+		address string
+		get     func() error
+	}{
+		{"/ch/01/dyn/mode", func() error { _, err := client.Strip.Comp.Mode(1); return err }},
+		{"/ch/01/dyn/ratio", func() error { _, err := client.Strip.Comp.Ratio(1); return err }},
+		{"/ch/01/eq/mode", func() error { _, err := client.Strip.Eq.Mode(1); return err }},
+		{"/ch/01/eq/1/type", func() error { _, err := client.Strip.Eq.Type(1, 1); return err }},
+		{"/ch/01/gate/mode", func() error { _, err := client.Strip.Gate.Mode(1); return err }},
+		{"/ch/01/mix/01/tap", func() error { _, err := client.Strip.SendTap(1, 1); return err }},
+	}
+	for _, tt := range tests {
+		for _, val := range []int32{-1, 99} {
+			mixer.Set(tt.address, val)
+			if err := tt.get(); !errors.Is(err, ErrUnexpectedType) {
+				t.Errorf("%s %d: got error %v, want %v", tt.address, val, err, ErrUnexpectedType)
+			}
+		}
+	}
+}
+
+// flush waits until the mixer has handled every message sent before it, it answers in order.
+func flush(t *testing.T, c *Client) {
+	t.Helper()
+	if _, err := c.request("/xinfo"); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+}
+
+func TestStaleDatagramsAreDrained(t *testing.T) {
+	mixer := xairtest.NewMixer(t, "XR18")
+	client, err := NewXAirClient(mixer.Host(), mixer.Port(), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	// updates left over from an /xremote subscription of an earlier session, carrying an old value
+	port := client.conn.LocalAddr().(*net.UDPAddr).Port
+	for range 10 {
+		mixer.Send(t, port, "/ch/01/mix/fader", float32(0.1))
+	}
+	mixer.Set("/ch/01/mix/fader", float32(0.75))
+
+	client.StartListening()
+	level, err := client.Strip.Fader(1)
+	if err != nil {
+		t.Fatalf("failed to read fader: %v", err)
+	}
+	if !near(level, 0) {
+		t.Errorf("got fader level %.2f dB from a stale datagram, want 0 dB", level)
+	}
+}
+
+func TestClientDialsTheGivenPort(t *testing.T) {
+	mixer := xairtest.NewMixer(t, "XR18")
+	if mixer.Port() == 10024 {
+		t.Skip("the fake mixer got the default port")
+	}
+	client, err := NewXAirClient(mixer.Host(), mixer.Port(), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	client.StartListening()
+
+	if _, err := client.RequestInfo(); err != nil {
+		t.Fatalf("no reply on port %d: %v", mixer.Port(), err)
+	}
+	if want := fmt.Sprintf("127.0.0.1:%d", mixer.Port()); client.Addr() != want {
+		t.Errorf("got address %s, want %s", client.Addr(), want)
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	tests := []struct {
+		host string
+		port int
+		want string // the resolved address, empty when the client must not be created
+	}{
+		{"127.0.0.1", 10023, "127.0.0.1:10023"},
+		{"::1", 10024, "[::1]:10024"},
+		{"[::1]", 10024, "[::1]:10024"},
+		{"127.0.0.1", 0, ""},
+		{"127.0.0.1", 65536, ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s port %d", tt.host, tt.port), func(t *testing.T) {
+			client, err := NewXAirClient(tt.host, tt.port)
+			if tt.want == "" {
+				if err == nil {
+					client.Close()
+					t.Fatal("client created, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+			if client.Addr() != tt.want {
+				t.Errorf("got address %s, want %s", client.Addr(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipUnchangedSkipsMatchingSets(t *testing.T) {
+	var skipped []string
+	client, mixer := newTestClient(t, WithSkipUnchanged(func(address string) { skipped = append(skipped, address) }))
+	const address = "/ch/01/mix/fader"
+	mixer.Set(address, float32(0.75)) // 0 dB
+
+	if err := client.Strip.SetFader(1, 0); err != nil {
+		t.Fatalf("SetFader failed: %v", err)
+	}
+	flush(t, &client.Client)
+	if n := mixer.Sets(address); n != 0 {
+		t.Errorf("got %d sets of %s at the value it already had, want none", n, address)
+	}
+	if len(skipped) != 1 || skipped[0] != address {
+		t.Errorf("got skipped %v, want [%s]", skipped, address)
+	}
+
+	if err := client.Strip.SetFader(1, -10); err != nil {
+		t.Fatalf("SetFader failed: %v", err)
+	}
+	flush(t, &client.Client)
+	if n := mixer.Sets(address); n != 1 {
+		t.Errorf("got %d sets of %s to a new value, want 1", n, address)
+	}
+}
+
+func TestActionsBypassSetHooks(t *testing.T) {
+	var changes []Change
+	client, mixer := newTestClient(t,
+		WithSkipUnchanged(func(string) {}),
+		WithChangeTracking(func(change Change) { changes = append(changes, change) }),
+	)
+	// a second load of the same snapshot must still be sent, it discards the changes made since the first
+	mixer.Set("/-snap/load", int32(3))
+
+	if err := client.Snapshot.CurrentLoad(3); err != nil {
+		t.Fatalf("CurrentLoad failed: %v", err)
+	}
+	flush(t, &client.Client)
+	if n := mixer.Sets("/-snap/load"); n != 1 {
+		t.Errorf("got %d sets of /-snap/load, want 1", n)
+	}
+	if len(changes) != 0 {
+		t.Errorf("got tracked changes %v for an action, want none", changes)
+	}
+}
+
+func TestSettleWaitsAfterEverySet(t *testing.T) {
+	const settle = 20 * time.Millisecond
+	client, mixer := newTestClient(t, WithSettle(settle))
+
+	start := time.Now()
+	for strip := 1; strip <= 5; strip++ {
+		if err := client.Strip.SetMute(strip, true); err != nil {
+			t.Fatalf("SetMute failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 5*settle {
+		t.Errorf("5 sets took %v, want at least %v", elapsed, 5*settle)
+	}
+	flush(t, &client.Client)
+	for strip := 1; strip <= 5; strip++ {
+		if n := mixer.Sets(fmt.Sprintf("/ch/%02d/mix/on", strip)); n != 1 {
+			t.Errorf("got %d sets of strip %d mute, want 1", n, strip)
+		}
+	}
+
+	// queries carry no arguments and are not held back
+	start = time.Now()
+	for strip := 1; strip <= 5; strip++ {
+		if _, err := client.Strip.Fader(strip); err != nil {
+			t.Fatalf("Fader failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= 5*settle {
+		t.Errorf("5 queries took %v, want them sent without the settle time", elapsed)
+	}
+}
+
+func TestPingMeasuresLatency(t *testing.T) {
+	client, mixer := newTestClient(t)
+	const delay = 50 * time.Millisecond
+	mixer.Delay("/xinfo", delay)
+
+	latency, err := client.Ping()
+	if err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if latency < delay || latency > time.Second {
+		t.Errorf("got latency %v, want at least %v and under the timeout", latency, delay)
+	}
+}
+
+func TestPingTimesOut(t *testing.T) {
+	client, mixer := newTestClient(t, WithTimeout(100*time.Millisecond))
+	mixer.Delay("/xinfo", 150*time.Millisecond)
+	if _, err := client.Ping(); !errors.Is(err, ErrTimeout) {
+		t.Errorf("got error %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestRawValuesHookSeesReplyBeforeConversion(t *testing.T) {
+	var address string
+	var raw []any
+	client, mixer := newTestClient(t, WithRawValues(func(a string, args []any) {
+		address, raw = a, args
+	}))
+	mixer.Set("/ch/01/mix/fader", float32(0.5))
+
+	level, err := client.Strip.Fader(1)
+	if err != nil {
+		t.Fatalf("failed to read fader: %v", err)
+	}
+	if !near(level, -10) {
+		t.Errorf("got level %g, want -10", level)
+	}
+	if address != "/ch/01/mix/fader" || len(raw) != 1 || raw[0] != float32(0.5) {
+		t.Errorf("hook got %s %v, want /ch/01/mix/fader [0.5]", address, raw)
+	}
+}
@@ -1,6 +1,22 @@
 package xair
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
+
+// CompParams holds the parameters of a Compressor.
+// Nil fields are left untouched by SetAll.
+type CompParams struct {
+	Mode      *string
+	Threshold *float64
+	Ratio     *float64
+	Mix       *float64
+	Makeup    *float64
+	Attack    *float64
+	Hold      *float64
+	Release   *float64
+}
 
 // Comp represents the compressor parameters.
 type Comp struct {
@@ -27,18 +43,13 @@ func newComp(c *Client, baseAddress string, opts ...CompOption) *Comp {
 // On retrieves the on/off status of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) On(index int) (bool, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/on"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for Compressor on value")
+		return false, fmt.Errorf("%w for Compressor on value", ErrUnexpectedType)
 	}
 	return val != 0, nil
 }
@@ -56,207 +67,483 @@ func (c *Comp) SetOn(index int, on bool) error {
 // Mode retrieves the current mode of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Mode(index int) (string, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/mode"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return "", err
-	}
-
-	possibleModes := []string{"comp", "exp"}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return "", err
 	}
 	val, ok := msg.Arguments[0].(int32)
-	if !ok {
-		return "", fmt.Errorf("unexpected argument type for Compressor mode value")
+	if !ok || val < 0 || int(val) >= len(compModes) {
+		return "", fmt.Errorf("%w for Compressor mode value", ErrUnexpectedType)
 	}
-	return possibleModes[val], nil
+	return compModes[val], nil
 }
 
 // SetMode sets the mode of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) SetMode(index int, mode string) error {
 	address := c.AddressFunc(c.baseAddress, index) + "/mode"
-	possibleModes := []string{"comp", "exp"}
-	return c.client.SendMessage(address, int32(indexOf(possibleModes, mode)))
+	i, err := choiceIndex("compressor mode", mode, compModes)
+	if err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, int32(i))
 }
 
 // Threshold retrieves the threshold value of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Threshold(index int) (float64, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/thr"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Compressor threshold value")
+		return 0, fmt.Errorf("%w for Compressor threshold value", ErrUnexpectedType)
 	}
-	return linGet(-60, 0, float64(val)), nil
+	return linGet(compThresholdRange.min, compThresholdRange.max, float64(val)), nil
 }
 
 // SetThreshold sets the threshold value of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) SetThreshold(index int, threshold float64) error {
 	address := c.AddressFunc(c.baseAddress, index) + "/thr"
-	return c.client.SendMessage(address, float32(linSet(-60, 0, threshold)))
+	if err := compThresholdRange.check("compressor threshold", threshold); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(linSet(compThresholdRange.min, compThresholdRange.max, threshold)))
 }
 
 // Ratio retrieves the ratio value of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Ratio(index int) (float32, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/ratio"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	possibleValues := []float32{1.1, 1.3, 1.5, 2.0, 2.5, 3.0, 4.0, 5.0, 7.0, 10, 20, 100}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(int32)
-	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Compressor ratio value")
+	if !ok || val < 0 || int(val) >= len(compRatios) {
+		return 0, fmt.Errorf("%w for Compressor ratio value", ErrUnexpectedType)
 	}
 
-	return possibleValues[val], nil
+	return compRatios[val], nil
 }
 
 // SetRatio sets the ratio value of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) SetRatio(index int, ratio float64) error {
 	address := c.AddressFunc(c.baseAddress, index) + "/ratio"
-	possibleValues := []float32{1.1, 1.3, 1.5, 2.0, 2.5, 3.0, 4.0, 5.0, 7.0, 10, 20, 100}
+	i, err := choiceIndex("compressor ratio", float32(ratio), compRatios)
+	if err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, int32(i))
+}
 
-	return c.client.SendMessage(address, int32(indexOf(possibleValues, float32(ratio))))
+// NearestRatio returns the valid compressor ratio closest to ratio, a tie goes to the lower ratio.
+// SetRatio only accepts the valid ratios, see compRatios.
+func NearestRatio(ratio float64) float64 {
+	// The ratios are rounded back to one decimal place so float32 error cannot decide a tie.
+	nearest := toFixed(float64(compRatios[0]), 1)
+	for _, r := range compRatios[1:] {
+		candidate := toFixed(float64(r), 1)
+		if math.Abs(candidate-ratio) < math.Abs(nearest-ratio) {
+			nearest = candidate
+		}
+	}
+	return nearest
 }
 
 // Attack retrieves the attack time of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Attack(index int) (float64, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/attack"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Compressor attack value")
+		return 0, fmt.Errorf("%w for Compressor attack value", ErrUnexpectedType)
 	}
-	return linGet(0, 120, float64(val)), nil
+	return linGet(compAttackRange.min, compAttackRange.max, float64(val)), nil
 }
 
 // SetAttack sets the attack time of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) SetAttack(index int, attack float64) error {
 	address := c.AddressFunc(c.baseAddress, index) + "/attack"
-	return c.client.SendMessage(address, float32(linSet(0, 120, attack)))
+	if err := compAttackRange.check("compressor attack", attack); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(linSet(compAttackRange.min, compAttackRange.max, attack)))
 }
 
 // Hold retrieves the hold time of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Hold(index int) (float64, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/hold"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Compressor hold value")
+		return 0, fmt.Errorf("%w for Compressor hold value", ErrUnexpectedType)
 	}
-	return logGet(0.02, 2000, float64(val)), nil
+	return logGet(compHoldRange.min, compHoldRange.max, float64(val)), nil
 }
 
 // SetHold sets the hold time of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) SetHold(index int, hold float64) error {
 	address := c.AddressFunc(c.baseAddress, index) + "/hold"
-	return c.client.SendMessage(address, float32(logSet(0.02, 2000, hold)))
+	if err := compHoldRange.check("compressor hold", hold); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(logSet(compHoldRange.min, compHoldRange.max, hold)))
 }
 
 // Release retrieves the release time of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Release(index int) (float64, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/release"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Compressor release value")
+		return 0, fmt.Errorf("%w for Compressor release value", ErrUnexpectedType)
 	}
-	return logGet(4, 4000, float64(val)), nil
+	return logGet(compReleaseRange.min, compReleaseRange.max, float64(val)), nil
 }
 
 // SetRelease sets the release time of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) SetRelease(index int, release float64) error {
 	address := c.AddressFunc(c.baseAddress, index) + "/release"
-	return c.client.SendMessage(address, float32(logSet(4, 4000, release)))
+	if err := compReleaseRange.check("compressor release", release); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(logSet(compReleaseRange.min, compReleaseRange.max, release)))
 }
 
 // Makeup retrieves the makeup gain of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Makeup(index int) (float64, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/mgain"
-	err := c.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := c.client.ReceiveMessage()
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Compressor makeup gain value")
+		return 0, fmt.Errorf("%w for Compressor makeup gain value", ErrUnexpectedType)
 	}
-	return linGet(0, 24, float64(val)), nil
+	return linGet(compMakeupRange.min, compMakeupRange.max, float64(val)), nil
 }
 
 // SetMakeup sets the makeup gain of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) SetMakeup(index int, makeup float64) error {
 	address := c.AddressFunc(c.baseAddress, index) + "/mgain"
-	return c.client.SendMessage(address, float32(linSet(0, 24, makeup)))
+	if err := compMakeupRange.check("compressor makeup gain", makeup); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(linSet(compMakeupRange.min, compMakeupRange.max, makeup)))
 }
 
 // Mix retrieves the mix value of the Compressor for a specific strip or bus (1-based indexing).
 func (c *Comp) Mix(index int) (float64, error) {
 	address := c.AddressFunc(c.baseAddress, index) + "/mix"
-	err := c.client.SendMessage(address)
+	msg, err := c.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for Compressor mix value", ErrUnexpectedType)
+	}
+	return linGet(compMixRange.min, compMixRange.max, float64(val)), nil
+}
+
+// SetMix sets the mix value of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) SetMix(index int, mix float64) error {
+	address := c.AddressFunc(c.baseAddress, index) + "/mix"
+	if err := compMixRange.check("compressor mix", mix); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(linSet(compMixRange.min, compMixRange.max, mix)))
+}
+
+// KeySource retrieves the key source of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) KeySource(index int) (string, error) {
+	address := c.AddressFunc(c.baseAddress, index) + "/keysrc"
+	msg, err := c.client.query(address)
+	if err != nil {
+		return "", err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return "", fmt.Errorf("%w for Compressor key source value", ErrUnexpectedType)
+	}
+	return c.client.keySourceName(val)
+}
+
+// SetKeySource sets the key source of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) SetKeySource(index int, source string) error {
+	address := c.AddressFunc(c.baseAddress, index) + "/keysrc"
+	val, err := c.client.keySourceValue(source)
+	if err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, val)
+}
+
+// FilterOn retrieves the on/off status of the Compressor key filter for a specific strip or bus (1-based indexing).
+func (c *Comp) FilterOn(index int) (bool, error) {
+	address := c.AddressFunc(c.baseAddress, index) + "/filter/on"
+	msg, err := c.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for Compressor filter on value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetFilterOn sets the on/off status of the Compressor key filter for a specific strip or bus (1-based indexing).
+func (c *Comp) SetFilterOn(index int, on bool) error {
+	address := c.AddressFunc(c.baseAddress, index) + "/filter/on"
+	var value int32
+	if on {
+		value = 1
+	}
+	return c.client.SendMessage(address, value)
+}
+
+// FilterFreq retrieves the frequency of the Compressor key filter for a specific strip or bus (1-based indexing).
+func (c *Comp) FilterFreq(index int) (float64, error) {
+	address := c.AddressFunc(c.baseAddress, index) + "/filter/f"
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for Compressor filter frequency value", ErrUnexpectedType)
+	}
+	return logGet(compFilterFreqRange.min, compFilterFreqRange.max, float64(val)), nil
+}
+
+// SetFilterFreq sets the frequency of the Compressor key filter for a specific strip or bus (1-based indexing).
+func (c *Comp) SetFilterFreq(index int, frequency float64) error {
+	address := c.AddressFunc(c.baseAddress, index) + "/filter/f"
+	if err := compFilterFreqRange.check("compressor key filter frequency", frequency); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(logSet(compFilterFreqRange.min, compFilterFreqRange.max, frequency)))
+}
 
-	msg, err := c.client.ReceiveMessage()
+// Knee retrieves the knee of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) Knee(index int) (float64, error) {
+	address := c.AddressFunc(c.baseAddress, index) + "/knee"
+	msg, err := c.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Compressor mix value")
+		return 0, fmt.Errorf("%w for Compressor knee value", ErrUnexpectedType)
 	}
-	return linGet(0, 100, float64(val)), nil
+	return linGet(compKneeRange.min, compKneeRange.max, float64(val)), nil
 }
 
-// SetMix sets the mix value of the Compressor for a specific strip or bus (1-based indexing).
-func (c *Comp) SetMix(index int, mix float64) error {
-	address := c.AddressFunc(c.baseAddress, index) + "/mix"
-	return c.client.SendMessage(address, float32(linSet(0, 100, mix)))
+// SetKnee sets the knee of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) SetKnee(index int, knee float64) error {
+	address := c.AddressFunc(c.baseAddress, index) + "/knee"
+	if err := compKneeRange.check("compressor knee", knee); err != nil {
+		return err
+	}
+	return c.client.SendMessage(address, float32(linSet(compKneeRange.min, compKneeRange.max, knee)))
+}
+
+// Auto retrieves the auto gain status of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) Auto(index int) (bool, error) {
+	address := c.AddressFunc(c.baseAddress, index) + "/auto"
+	msg, err := c.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for Compressor auto value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetAuto sets the auto gain status of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) SetAuto(index int, auto bool) error {
+	address := c.AddressFunc(c.baseAddress, index) + "/auto"
+	var value int32
+	if auto {
+		value = 1
+	}
+	return c.client.SendMessage(address, value)
+}
+
+// CompSnapshot holds the settings of a Compressor.
+type CompSnapshot struct {
+	On         bool    `json:"on"`
+	Mode       string  `json:"mode"`
+	Threshold  float64 `json:"threshold"`
+	Ratio      float64 `json:"ratio"`
+	Mix        float64 `json:"mix"`
+	Makeup     float64 `json:"makeup"`
+	Attack     float64 `json:"attack"`
+	Hold       float64 `json:"hold"`
+	Release    float64 `json:"release"`
+	Knee       float64 `json:"knee"`
+	Auto       bool    `json:"auto"`
+	KeySource  string  `json:"keysrc"`
+	FilterOn   bool    `json:"filter_on"`
+	FilterFreq float64 `json:"filter_freq"`
+}
+
+// Snapshot reads all settings of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) Snapshot(index int) (CompSnapshot, error) {
+	var snap CompSnapshot
+	var err error
+	if snap.On, err = c.On(index); err != nil {
+		return snap, err
+	}
+	if snap.Mode, err = c.Mode(index); err != nil {
+		return snap, err
+	}
+	if snap.Threshold, err = c.Threshold(index); err != nil {
+		return snap, err
+	}
+	ratio, err := c.Ratio(index)
+	if err != nil {
+		return snap, err
+	}
+	snap.Ratio = float64(ratio)
+	if snap.Mix, err = c.Mix(index); err != nil {
+		return snap, err
+	}
+	if snap.Makeup, err = c.Makeup(index); err != nil {
+		return snap, err
+	}
+	if snap.Attack, err = c.Attack(index); err != nil {
+		return snap, err
+	}
+	if snap.Hold, err = c.Hold(index); err != nil {
+		return snap, err
+	}
+	if snap.Release, err = c.Release(index); err != nil {
+		return snap, err
+	}
+	if snap.Knee, err = c.Knee(index); err != nil {
+		return snap, err
+	}
+	if snap.Auto, err = c.Auto(index); err != nil {
+		return snap, err
+	}
+	if snap.KeySource, err = c.KeySource(index); err != nil {
+		return snap, err
+	}
+	if snap.FilterOn, err = c.FilterOn(index); err != nil {
+		return snap, err
+	}
+	if snap.FilterFreq, err = c.FilterFreq(index); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
+
+// ApplySnapshot writes all settings from snap to the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) ApplySnapshot(index int, snap CompSnapshot) error {
+	setters := []func() error{
+		func() error { return c.SetMode(index, snap.Mode) },
+		func() error { return c.SetThreshold(index, snap.Threshold) },
+		func() error { return c.SetRatio(index, snap.Ratio) },
+		func() error { return c.SetMix(index, snap.Mix) },
+		func() error { return c.SetMakeup(index, snap.Makeup) },
+		func() error { return c.SetAttack(index, snap.Attack) },
+		func() error { return c.SetHold(index, snap.Hold) },
+		func() error { return c.SetRelease(index, snap.Release) },
+		func() error { return c.SetKnee(index, snap.Knee) },
+		func() error { return c.SetAuto(index, snap.Auto) },
+		func() error { return c.SetKeySource(index, snap.KeySource) },
+		func() error { return c.SetFilterOn(index, snap.FilterOn) },
+		func() error { return c.SetFilterFreq(index, snap.FilterFreq) },
+		func() error { return c.SetOn(index, snap.On) },
+	}
+	for _, set := range setters {
+		if err := set(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultCompSnapshot returns the factory settings of a Compressor.
+func DefaultCompSnapshot() CompSnapshot {
+	return CompSnapshot{
+		On:         false,
+		Mode:       "comp",
+		Threshold:  0,
+		Ratio:      3,
+		Mix:        100,
+		Makeup:     0,
+		Attack:     10,
+		Hold:       10,
+		Release:    151,
+		Knee:       2,
+		Auto:       false,
+		KeySource:  "self",
+		FilterOn:   false,
+		FilterFreq: 1000,
+	}
+}
+
+// Reset restores the factory settings of the Compressor for a specific strip or bus (1-based indexing).
+func (c *Comp) Reset(index int) error {
+	return c.ApplySnapshot(index, DefaultCompSnapshot())
+}
+
+// SetAll applies all provided parameters of the Compressor for a specific strip or bus (1-based indexing), skipping any nil fields.
+func (c *Comp) SetAll(index int, params CompParams) error {
+	if params.Mode != nil {
+		if err := c.SetMode(index, *params.Mode); err != nil {
+			return err
+		}
+	}
+	if params.Threshold != nil {
+		if err := c.SetThreshold(index, *params.Threshold); err != nil {
+			return err
+		}
+	}
+	if params.Ratio != nil {
+		if err := c.SetRatio(index, *params.Ratio); err != nil {
+			return err
+		}
+	}
+	if params.Mix != nil {
+		if err := c.SetMix(index, *params.Mix); err != nil {
+			return err
+		}
+	}
+	if params.Makeup != nil {
+		if err := c.SetMakeup(index, *params.Makeup); err != nil {
+			return err
+		}
+	}
+	if params.Attack != nil {
+		if err := c.SetAttack(index, *params.Attack); err != nil {
+			return err
+		}
+	}
+	if params.Hold != nil {
+		if err := c.SetHold(index, *params.Hold); err != nil {
+			return err
+		}
+	}
+	if params.Release != nil {
+		if err := c.SetRelease(index, *params.Release); err != nil {
+			return err
+		}
+	}
+	return nil
 }
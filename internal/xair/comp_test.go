@@ -0,0 +1,92 @@
+package xair
+
+import (
+	"testing"
+)
+
+func TestCompSetAllSkipsOmittedFields(t *testing.T) {
+	mode, threshold, ratio, mix := "exp", -20.0, 4.0, 50.0
+	makeup, attack, hold, release := 6.0, 10.0, 20.0, 150.0
+	tests := []struct {
+		name   string
+		params CompParams
+		want   []string // the compressor parameters expected to be written
+	}{
+		{"threshold and ratio", CompParams{Threshold: &threshold, Ratio: &ratio}, []string{"thr", "ratio"}},
+		{"times", CompParams{Attack: &attack, Hold: &hold, Release: &release}, []string{"attack", "hold", "release"}},
+		{"mode, mix and makeup", CompParams{Mode: &mode, Mix: &mix, Makeup: &makeup}, []string{"mode", "mix", "mgain"}},
+		{"nothing", CompParams{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t)
+			if err := client.Main.Comp.SetAll(0, tt.params); err != nil {
+				t.Fatalf("SetAll failed: %v", err)
+			}
+			flush(t, &client.Client)
+
+			written := map[string]bool{}
+			for _, p := range tt.want {
+				written[p] = true
+			}
+			for _, p := range []string{"mode", "thr", "ratio", "mix", "mgain", "attack", "hold", "release"} {
+				address := "/lr/dyn/" + p
+				if got := mixer.Value(address) != nil; got != written[p] {
+					t.Errorf("%s written: %t, want %t", address, got, written[p])
+				}
+			}
+		})
+	}
+}
+
+func TestCompSetAllRoundTrip(t *testing.T) {
+	client, _ := newTestClient(t)
+	threshold, ratio, release := -20.0, 4.0, 150.0
+	if err := client.Strip.Comp.SetAll(2, CompParams{Threshold: &threshold, Ratio: &ratio, Release: &release}); err != nil {
+		t.Fatalf("SetAll failed: %v", err)
+	}
+
+	snap, err := client.Strip.Comp.Snapshot(2)
+	if err != nil {
+		t.Fatalf("failed to read the compressor back: %v", err)
+	}
+	if !near(snap.Threshold, threshold) || snap.Ratio != ratio || !near(snap.Release, release) {
+		t.Errorf("got threshold %g, ratio %g, release %g, want %g, %g, %g", snap.Threshold, snap.Ratio, snap.Release, threshold, ratio, release)
+	}
+}
+
+func TestNearestRatio(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  float64
+	}{
+		{4, 4},
+		{4.1, 4},
+		{4.6, 5},
+		{4.5, 4},   // a tie goes to the lower ratio
+		{1.2, 1.1}, // even where float32 error would break the tie the other way
+		{15, 10},
+		{16, 20},
+		{0.5, 1.1},
+		{150, 100},
+	}
+	for _, tt := range tests {
+		if got := NearestRatio(tt.ratio); got != tt.want {
+			t.Errorf("NearestRatio(%g) = %g, want %g", tt.ratio, got, tt.want)
+		}
+	}
+}
+
+func TestSetRatioRejectsInvalidRatios(t *testing.T) {
+	client, mixer := newTestClient(t)
+	if err := client.Strip.Comp.SetRatio(1, 4.1); err == nil {
+		t.Error("SetRatio accepted 4.1")
+	}
+	if err := client.Strip.Comp.SetRatio(1, NearestRatio(4.1)); err != nil {
+		t.Fatalf("SetRatio rejected the nearest ratio: %v", err)
+	}
+	flush(t, &client.Client)
+	if got := mixer.Value("/ch/01/dyn/ratio"); len(got) != 1 || got[0] != int32(6) {
+		t.Errorf("got /ch/01/dyn/ratio %v, want the index of 4.0, 6", got)
+	}
+}
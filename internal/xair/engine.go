@@ -3,6 +3,10 @@ package xair
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -15,18 +19,44 @@ type parser interface {
 
 type engine struct {
 	Kind      mixerKind
+	model     string // reported by RequestInfo, empty until then
 	timeout   time.Duration
 	conn      *net.UDPConn
 	mixerAddr *net.UDPAddr
 
-	parser     parser
-	addressMap map[string]string
-
-	done     chan bool
-	respChan chan *osc.Message
+	parser       parser
+	addressMap   map[string]string
+	keySources   []string
+	inputSources []string
+	tracer       *log.Logger
+	changeHook   func(Change)
+	skipHook     func(string)
+	rawHook      func(string, []any)
+	mirror       bool
+	settle       time.Duration
+	sendMu       sync.Mutex // serialises writes so goroutines, such as concurrent fades, can share one client
+	queryMu      sync.Mutex // serialises exchanges read from respChan, such as /node queries
+
+	done      chan bool
+	respChan  chan *osc.Message
+	listening atomic.Bool // set by StartListening, until then drain reads the socket itself
+
+	waitMu  sync.Mutex                     // guards waiters and late
+	waiters map[string][]chan *osc.Message // getters waiting for a reply, by address, see Client.request
+	late    map[string]int                 // replies still owed to getters that timed out, see Client.abandon
 }
 
 func newEngine(mixerIP string, mixerPort int, kind mixerKind, opts ...EngineOption) (*engine, error) {
+	if mixerPort < 1 || mixerPort > 65535 {
+		return nil, fmt.Errorf("invalid mixer port %d, must be between 1 and 65535", mixerPort)
+	}
+	// Accept IPv6 literals with or without brackets, JoinHostPort adds them back.
+	mixerAddress := net.JoinHostPort(strings.Trim(mixerIP, "[]"), strconv.Itoa(mixerPort))
+	mixerAddr, err := net.ResolveUDPAddr("udp", mixerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mixer address %s: %v", mixerAddress, err)
+	}
+
 	localAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf(":%d", 0))
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve local address: %v", err)
@@ -37,22 +67,21 @@ func newEngine(mixerIP string, mixerPort int, kind mixerKind, opts ...EngineOpti
 		return nil, fmt.Errorf("failed to create UDP connection: %v", err)
 	}
 
-	mixerAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", mixerIP, mixerPort))
-	if err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("failed to resolve mixer address: %v", err)
-	}
-
 	log.Debugf("Local UDP connection: %s	", conn.LocalAddr().String())
 
 	e := &engine{
-		timeout:    100 * time.Millisecond,
-		conn:       conn,
-		mixerAddr:  mixerAddr,
-		parser:     newParser(),
-		addressMap: addressMapFromMixerKind(kind),
-		done:       make(chan bool),
-		respChan:   make(chan *osc.Message, 100),
+		Kind:         kind,
+		timeout:      100 * time.Millisecond,
+		conn:         conn,
+		mixerAddr:    mixerAddr,
+		parser:       newParser(),
+		addressMap:   addressMapFromMixerKind(kind),
+		keySources:   keySourcesFromMixerKind(kind),
+		inputSources: inputSourcesFromMixerKind(kind),
+		waiters:      map[string][]chan *osc.Message{},
+		late:         map[string]int{},
+		done:         make(chan bool),
+		respChan:     make(chan *osc.Message, 100),
 	}
 
 	for _, opt := range opts {
@@ -94,11 +123,44 @@ func (e *engine) receiveLoop() {
 				log.Errorf("Failed to parse OSC message: %v", err)
 				continue
 			}
-			e.respChan <- msg
+			e.dispatch(msg)
 		}
 	}
 }
 
+// dispatch hands msg to the first getter waiting for its address, any other message goes to the receive channel.
+// A reply owed to a getter that timed out is discarded instead.
+func (e *engine) dispatch(msg *osc.Message) {
+	e.waitMu.Lock()
+	if e.late[msg.Address] > 0 {
+		e.late[msg.Address]--
+		if e.late[msg.Address] == 0 {
+			delete(e.late, msg.Address)
+		}
+		e.waitMu.Unlock()
+		log.Debugf("Discarding late reply for %s", msg.Address)
+		return
+	}
+	if waiting := e.waiters[msg.Address]; len(waiting) > 0 {
+		if len(waiting) == 1 {
+			delete(e.waiters, msg.Address)
+		} else {
+			e.waiters[msg.Address] = waiting[1:]
+		}
+		e.waitMu.Unlock()
+		waiting[0] <- msg
+		return
+	}
+	e.waitMu.Unlock()
+
+	// Nobody may be reading the receive channel, drop what does not fit rather than stall every getter.
+	select {
+	case e.respChan <- msg:
+	default:
+		log.Debugf("Receive channel full, dropping message for %s", msg.Address)
+	}
+}
+
 // parseOSCMessage parses raw bytes into an OSC message with improved error handling
 func (e *engine) parseOSCMessage(data []byte) (*osc.Message, error) {
 	msg, err := e.parser.Parse(data)
@@ -133,6 +195,10 @@ func (e *engine) sendToAddress(addr *net.UDPAddr, oscAddress string, args ...any
 		return fmt.Errorf("failed to marshal message: %v", err)
 	}
 
-	_, err = e.conn.WriteToUDP(data, addr)
-	return err
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+	if _, err := e.conn.WriteToUDP(data, addr); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotConnected, err)
+	}
+	return nil
 }
@@ -4,10 +4,20 @@ import (
 	"fmt"
 )
 
+// BandParams holds the parameters of a single EQ band.
+// Nil fields are left untouched by SetBand.
+type BandParams struct {
+	Gain      *float64
+	Frequency *float64
+	Q         *float64
+	Type      *string
+}
+
 // Eq represents the EQ parameters.
 type Eq struct {
 	client      *Client
 	baseAddress string
+	geqAddress  string
 	AddressFunc func(fmtString string, args ...any) string
 }
 
@@ -26,21 +36,24 @@ func newEq(c *Client, baseAddress string, opts ...EqOption) *Eq {
 	return eq
 }
 
+// graphicBands lists the centre frequencies of the 31 graphic EQ bands as they appear in the OSC addresses.
+var graphicBands = []string{
+	"20", "25", "31.5", "40", "50", "63", "80", "100", "125", "160",
+	"200", "250", "315", "400", "500", "630", "800", "1k", "1k25", "1k6",
+	"2k", "2k5", "3k15", "4k", "5k", "6k3", "8k", "10k", "12k5", "16k",
+	"20k",
+}
+
 // On retrieves the on/off status of the EQ for a specific strip or bus (1-based indexing).
 func (e *Eq) On(index int) (bool, error) {
 	address := e.AddressFunc(e.baseAddress, index) + "/on"
-	err := e.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := e.client.ReceiveMessage()
+	msg, err := e.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for EQ on value")
+		return false, fmt.Errorf("%w for EQ on value", ErrUnexpectedType)
 	}
 	return val != 0, nil
 }
@@ -57,129 +70,296 @@ func (e *Eq) SetOn(index int, on bool) error {
 
 func (e *Eq) Mode(index int) (string, error) {
 	address := e.AddressFunc(e.baseAddress, index) + "/mode"
-	err := e.client.SendMessage(address)
-	if err != nil {
-		return "", err
-	}
-
-	possibleModes := []string{"peq", "geq", "teq"}
-
-	msg, err := e.client.ReceiveMessage()
+	msg, err := e.client.query(address)
 	if err != nil {
 		return "", err
 	}
 	val, ok := msg.Arguments[0].(int32)
-	if !ok {
-		return "", fmt.Errorf("unexpected argument type for EQ mode value")
+	if !ok || val < 0 || int(val) >= len(eqModes) {
+		return "", fmt.Errorf("%w for EQ mode value", ErrUnexpectedType)
 	}
-	return possibleModes[val], nil
+	return eqModes[val], nil
 }
 
 func (e *Eq) SetMode(index int, mode string) error {
 	address := e.AddressFunc(e.baseAddress, index) + "/mode"
-	possibleModes := []string{"peq", "geq", "teq"}
-	return e.client.SendMessage(address, int32(indexOf(possibleModes, mode)))
+	i, err := choiceIndex("EQ mode", mode, eqModes)
+	if err != nil {
+		return err
+	}
+	return e.client.SendMessage(address, int32(i))
 }
 
 // Gain retrieves the gain for a specific EQ band on a strip or bus (1-based indexing).
 func (e *Eq) Gain(index int, band int) (float64, error) {
 	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/g", band)
-	err := e.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := e.client.ReceiveMessage()
+	msg, err := e.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for EQ gain value")
+		return 0, fmt.Errorf("%w for EQ gain value", ErrUnexpectedType)
 	}
-	return linGet(-15, 15, float64(val)), nil
+	return linGet(eqGainRange.min, eqGainRange.max, float64(val)), nil
 }
 
 // SetGain sets the gain for a specific EQ band on a strip or bus (1-based indexing).
 func (e *Eq) SetGain(index int, band int, gain float64) error {
 	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/g", band)
-	return e.client.SendMessage(address, float32(linSet(-15, 15, gain)))
+	if err := eqGainRange.check("EQ gain", gain); err != nil {
+		return err
+	}
+	return e.client.SendMessage(address, float32(linSet(eqGainRange.min, eqGainRange.max, gain)))
 }
 
 // Frequency retrieves the frequency for a specific EQ band on a strip or bus (1-based indexing).
 func (e *Eq) Frequency(index int, band int) (float64, error) {
 	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/f", band)
-	err := e.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := e.client.ReceiveMessage()
+	msg, err := e.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for EQ frequency value")
+		return 0, fmt.Errorf("%w for EQ frequency value", ErrUnexpectedType)
 	}
-	return logGet(20, 20000, float64(val)), nil
+	return logGet(eqFreqRange.min, eqFreqRange.max, float64(val)), nil
 }
 
 // SetFrequency sets the frequency for a specific EQ band on a strip or bus (1-based indexing).
 func (e *Eq) SetFrequency(index int, band int, frequency float64) error {
 	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/f", band)
-	return e.client.SendMessage(address, float32(logSet(20, 20000, frequency)))
+	if err := eqFreqRange.check("EQ frequency", frequency); err != nil {
+		return err
+	}
+	return e.client.SendMessage(address, float32(logSet(eqFreqRange.min, eqFreqRange.max, frequency)))
 }
 
 // Q retrieves the Q factor for a specific EQ band on a strip or bus (1-based indexing).
 func (e *Eq) Q(index int, band int) (float64, error) {
 	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/q", band)
-	err := e.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := e.client.ReceiveMessage()
+	msg, err := e.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for EQ Q value")
+		return 0, fmt.Errorf("%w for EQ Q value", ErrUnexpectedType)
 	}
-	return logGet(0.3, 10, 1.0-float64(val)), nil
+	return logGet(eqQRange.min, eqQRange.max, 1.0-float64(val)), nil
 }
 
 // SetQ sets the Q factor for a specific EQ band on a strip or bus (1-based indexing).
 func (e *Eq) SetQ(index int, band int, q float64) error {
 	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/q", band)
-	return e.client.SendMessage(address, float32(1.0-logSet(0.3, 10, q)))
+	if err := eqQRange.check("EQ Q factor", q); err != nil {
+		return err
+	}
+	return e.client.SendMessage(address, float32(1.0-logSet(eqQRange.min, eqQRange.max, q)))
 }
 
 // Type retrieves the type for a specific EQ band on a strip or bus (1-based indexing).
 func (e *Eq) Type(index int, band int) (string, error) {
 	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/type", band)
-	err := e.client.SendMessage(address)
+	msg, err := e.client.query(address)
 	if err != nil {
 		return "", err
 	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok || val < 0 || int(val) >= len(eqTypes) {
+		return "", fmt.Errorf("%w for EQ type value", ErrUnexpectedType)
+	}
+	return eqTypes[val], nil
+}
 
-	possibleTypes := []string{"lcut", "lshv", "peq", "veq", "hshv", "hcut"}
+// eqTypeAliases maps the descriptive EQ type names accepted by SetType to the names Type returns.
+var eqTypeAliases = map[string]string{
+	"low_cut":    "lcut",
+	"high_pass":  "lcut",
+	"low_shelf":  "lshv",
+	"peaking":    "peq",
+	"vintage":    "veq",
+	"high_shelf": "hshv",
+	"high_cut":   "hcut",
+	"low_pass":   "hcut",
+}
 
-	msg, err := e.client.ReceiveMessage()
+// SetType sets the type for a specific EQ band on a strip or bus (1-based indexing).
+// Besides the names Type returns, the descriptive names of eqTypeAliases are accepted, e.g. "peaking" for "peq".
+func (e *Eq) SetType(index int, band int, eqType string) error {
+	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/type", band)
+	if name, ok := eqTypeAliases[eqType]; ok {
+		eqType = name
+	}
+	i, err := choiceIndex("EQ type", eqType, eqTypes)
 	if err != nil {
-		return "", err
+		return err
 	}
-	val, ok := msg.Arguments[0].(int32)
+	return e.client.SendMessage(address, int32(i))
+}
+
+// SetBand applies all provided parameters of a specific EQ band on a strip or bus (1-based indexing), skipping any nil fields.
+func (e *Eq) SetBand(index int, band int, params BandParams) error {
+	if params.Type != nil {
+		if err := e.SetType(index, band, *params.Type); err != nil {
+			return err
+		}
+	}
+	if params.Frequency != nil {
+		if err := e.SetFrequency(index, band, *params.Frequency); err != nil {
+			return err
+		}
+	}
+	if params.Gain != nil {
+		if err := e.SetGain(index, band, *params.Gain); err != nil {
+			return err
+		}
+	}
+	if params.Q != nil {
+		if err := e.SetQ(index, band, *params.Q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EqBandSnapshot holds the settings of a single EQ band.
+type EqBandSnapshot struct {
+	Type      string  `json:"type"`
+	Frequency float64 `json:"frequency"`
+	Gain      float64 `json:"gain"`
+	Q         float64 `json:"q"`
+}
+
+// EqSnapshot holds the settings of an EQ.
+type EqSnapshot struct {
+	On    bool             `json:"on"`
+	Bands []EqBandSnapshot `json:"bands"`
+}
+
+// Snapshot reads the on/off status and the first bands bands of the EQ for a specific strip or bus (1-based indexing).
+func (e *Eq) Snapshot(index int, bands int) (EqSnapshot, error) {
+	var snap EqSnapshot
+	var err error
+	if snap.On, err = e.On(index); err != nil {
+		return snap, err
+	}
+
+	snap.Bands = make([]EqBandSnapshot, bands)
+	for i := range snap.Bands {
+		if snap.Bands[i], err = e.Band(index, i+1); err != nil {
+			return snap, err
+		}
+	}
+	return snap, nil
+}
+
+// Band reads the type, frequency, gain and Q of a specific EQ band on a strip or bus (1-based indexing).
+func (e *Eq) Band(index int, band int) (EqBandSnapshot, error) {
+	var snap EqBandSnapshot
+	var err error
+	if snap.Type, err = e.Type(index, band); err != nil {
+		return snap, err
+	}
+	if snap.Frequency, err = e.Frequency(index, band); err != nil {
+		return snap, err
+	}
+	if snap.Gain, err = e.Gain(index, band); err != nil {
+		return snap, err
+	}
+	if snap.Q, err = e.Q(index, band); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
+
+// CopyBand copies the settings of an EQ band to another band, which may belong to a different strip or bus.
+// It returns the settings that were copied.
+func (e *Eq) CopyBand(index int, band int, toIndex int, toBand int) (EqBandSnapshot, error) {
+	snap, err := e.Band(index, band)
+	if err != nil {
+		return snap, err
+	}
+	return snap, e.SetBand(toIndex, toBand, BandParams{
+		Type:      &snap.Type,
+		Frequency: &snap.Frequency,
+		Gain:      &snap.Gain,
+		Q:         &snap.Q,
+	})
+}
+
+// ApplySnapshot writes all settings from snap to the EQ for a specific strip or bus (1-based indexing).
+func (e *Eq) ApplySnapshot(index int, snap EqSnapshot) error {
+	for i, band := range snap.Bands {
+		if err := e.SetBand(index, i+1, BandParams{
+			Type:      &band.Type,
+			Frequency: &band.Frequency,
+			Gain:      &band.Gain,
+			Q:         &band.Q,
+		}); err != nil {
+			return err
+		}
+	}
+	return e.SetOn(index, snap.On)
+}
+
+// GraphicBand retrieves the gain of a graphic EQ band (1-31) for a specific bus, it requires the EQ to be in geq or teq mode.
+func (e *Eq) GraphicBand(index int, band int) (float64, error) {
+	address, err := e.graphicBandAddress(index, band)
+	if err != nil {
+		return 0, err
+	}
+
+	msg, err := e.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return "", fmt.Errorf("unexpected argument type for EQ type value")
+		return 0, fmt.Errorf("%w for graphic EQ band value", ErrUnexpectedType)
 	}
-	return possibleTypes[val], nil
+	return linGet(eqGainRange.min, eqGainRange.max, float64(val)), nil
 }
 
-// SetType sets the type for a specific EQ band on a strip or bus (1-based indexing).
-func (e *Eq) SetType(index int, band int, eqType string) error {
-	address := e.AddressFunc(e.baseAddress, index) + fmt.Sprintf("/%d/type", band)
-	possibleTypes := []string{"lcut", "lshv", "peq", "veq", "hshv", "hcut"}
-	return e.client.SendMessage(address, int32(indexOf(possibleTypes, eqType)))
+// SetGraphicBand sets the gain of a graphic EQ band (1-31) for a specific bus, it requires the EQ to be in geq or teq mode.
+func (e *Eq) SetGraphicBand(index int, band int, gain float64) error {
+	if err := eqGainRange.check("EQ gain", gain); err != nil {
+		return err
+	}
+	address, err := e.graphicBandAddress(index, band)
+	if err != nil {
+		return err
+	}
+	return e.client.SendMessage(address, float32(linSet(eqGainRange.min, eqGainRange.max, gain)))
+}
+
+// graphicBandAddress returns the address of a graphic EQ band after checking the band number and the current EQ mode.
+func (e *Eq) graphicBandAddress(index int, band int) (string, error) {
+	if e.geqAddress == "" {
+		return "", fmt.Errorf("graphic EQ is not supported on this model")
+	}
+	if band < 1 || band > len(graphicBands) {
+		return "", fmt.Errorf("graphic EQ band must be between 1 and %d", len(graphicBands))
+	}
+
+	mode, err := e.Mode(index)
+	if err != nil {
+		return "", err
+	}
+	if mode != "geq" && mode != "teq" {
+		return "", fmt.Errorf("EQ mode is %s, graphic EQ bands are only available in geq or teq mode", mode)
+	}
+	return e.AddressFunc(e.geqAddress, index) + "/" + graphicBands[band-1], nil
+}
+
+// Flatten sets the gain of the first bands bands of the EQ to 0 dB for a specific strip or bus (1-based indexing).
+// Band types, frequencies and Q factors are left untouched.
+func (e *Eq) Flatten(index int, bands int) error {
+	for band := 1; band <= bands; band++ {
+		if err := e.SetGain(index, band, 0); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,174 @@
+package xair
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqSetBandSkipsOmittedFields(t *testing.T) {
+	gain, freq, q, typ := 6.0, 1000.0, 2.0, "peq"
+	tests := []struct {
+		name   string
+		params BandParams
+		want   []string // the band parameters expected to be written
+	}{
+		{"gain only", BandParams{Gain: &gain}, []string{"g"}},
+		{"frequency and q", BandParams{Frequency: &freq, Q: &q}, []string{"f", "q"}},
+		{"type only", BandParams{Type: &typ}, []string{"type"}},
+		{"everything", BandParams{Gain: &gain, Frequency: &freq, Q: &q, Type: &typ}, []string{"g", "f", "q", "type"}},
+		{"nothing", BandParams{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t)
+			if err := client.Strip.Eq.SetBand(3, 2, tt.params); err != nil {
+				t.Fatalf("SetBand failed: %v", err)
+			}
+			flush(t, &client.Client)
+
+			written := map[string]bool{}
+			for _, p := range tt.want {
+				written[p] = true
+			}
+			for _, p := range []string{"g", "f", "q", "type"} {
+				address := "/ch/03/eq/2/" + p
+				if got := mixer.Value(address) != nil; got != written[p] {
+					t.Errorf("%s written: %t, want %t", address, got, written[p])
+				}
+			}
+		})
+	}
+}
+
+func TestEqSetBandScalesValues(t *testing.T) {
+	client, mixer := newTestClient(t)
+	gain, freq, q, typ := 7.5, 1000.0, 2.0, "hshv"
+	if err := client.Bus.Eq.SetBand(1, 6, BandParams{Gain: &gain, Frequency: &freq, Q: &q, Type: &typ}); err != nil {
+		t.Fatalf("SetBand failed: %v", err)
+	}
+
+	snap, err := client.Bus.Eq.Snapshot(1, 6)
+	if err != nil {
+		t.Fatalf("failed to read the band back: %v", err)
+	}
+	band := snap.Bands[5]
+	if band.Type != typ || !near(band.Gain, gain) || !near(band.Frequency, freq) || !near(band.Q, q) {
+		t.Errorf("got band %+v, want type %s, gain %g, frequency %g, q %g", band, typ, gain, freq, q)
+	}
+	if got := mixer.Value("/bus/1/eq/6/g"); len(got) != 1 || got[0] != float32(0.75) {
+		t.Errorf("got raw gain %v, want 0.75", got)
+	}
+}
+
+// near reports whether two values read back from the mixer agree within the precision of a float32.
+func near(a, b float64) bool {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	return d < 1e-3*max(1, b, -b)
+}
+
+func TestGraphicBand(t *testing.T) {
+	client, mixer := newTestClient(t)
+	mixer.Set("/bus/2/eq/mode", int32(1)) // geq
+	if err := client.Bus.Eq.SetGraphicBand(2, 10, -4.5); err != nil {
+		t.Fatalf("SetGraphicBand failed: %v", err)
+	}
+	flush(t, &client.Client)
+	address := "/bus/2/geq/" + graphicBands[9]
+	if got := mixer.Value(address); len(got) != 1 || got[0] != float32(0.35) {
+		t.Errorf("got %s = %v, want [0.35]", address, got)
+	}
+
+	if err := client.Bus.Eq.SetGraphicBand(2, 32, 0); err == nil {
+		t.Error("SetGraphicBand accepted band 32")
+	}
+	mixer.Set("/bus/2/eq/mode", int32(0)) // peq
+	if _, err := client.Bus.Eq.GraphicBand(2, 10); err == nil {
+		t.Error("GraphicBand succeeded in peq mode")
+	}
+}
+
+func TestGraphicBandIsNotSupportedOnX32(t *testing.T) {
+	client, mixer := newTestX32Client(t)
+	mixer.Set("/bus/02/eq/mode", int32(1))
+	if err := client.Bus.Eq.SetGraphicBand(2, 10, 0); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("SetGraphicBand on X32 returned %v, want not supported", err)
+	}
+}
+
+func TestEqTypeAliasesNormalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"lcut", "lcut"},
+		{"low_cut", "lcut"},
+		{"high_pass", "lcut"},
+		{"low_shelf", "lshv"},
+		{"peaking", "peq"},
+		{"peq", "peq"},
+		{"vintage", "veq"},
+		{"high_shelf", "hshv"},
+		{"high_cut", "hcut"},
+		{"low_pass", "hcut"},
+	}
+	client, _ := newTestClient(t)
+	for _, tt := range tests {
+		for _, target := range []struct {
+			name  string
+			eq    *Eq
+			index int
+		}{{"strip", client.Strip.Eq, 1}, {"main", client.Main.Eq, 0}} {
+			if err := target.eq.SetType(target.index, 2, tt.input); err != nil {
+				t.Fatalf("%s SetType(%q) failed: %v", target.name, tt.input, err)
+			}
+			got, err := target.eq.Type(target.index, 2)
+			if err != nil {
+				t.Fatalf("%s Type failed: %v", target.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s type set to %q reads back %q, want %q", target.name, tt.input, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestEqSetTypeRejectsUnknownTypes(t *testing.T) {
+	client, mixer := newTestClient(t)
+	if err := client.Strip.Eq.SetType(1, 2, "bandpass"); err == nil {
+		t.Error("SetType(\"bandpass\") succeeded, want an error")
+	}
+	flush(t, &client.Client)
+	if got := mixer.Value("/ch/01/eq/2/type"); got != nil {
+		t.Errorf("got /ch/01/eq/2/type %v, want it untouched", got)
+	}
+}
+
+func TestEqCopyBandAcrossBands(t *testing.T) {
+	client, mixer := newTestClient(t)
+	gain, freq, q, typ := 6.0, 1000.0, 2.0, "veq"
+	if err := client.Strip.Eq.SetBand(1, 2, BandParams{Gain: &gain, Frequency: &freq, Q: &q, Type: &typ}); err != nil {
+		t.Fatalf("failed to set the source band: %v", err)
+	}
+
+	copied, err := client.Strip.Eq.CopyBand(1, 2, 5, 3)
+	if err != nil {
+		t.Fatalf("CopyBand failed: %v", err)
+	}
+	got, err := client.Strip.Eq.Band(5, 3)
+	if err != nil {
+		t.Fatalf("failed to read the destination band: %v", err)
+	}
+	for _, snap := range []EqBandSnapshot{copied, got} {
+		if snap.Type != typ || !near(snap.Frequency, freq) || !near(snap.Gain, gain) || !near(snap.Q, q) {
+			t.Errorf("got band %+v, want %s, %g Hz, %g dB, Q %g", snap, typ, freq, gain, q)
+		}
+	}
+	for _, p := range []string{"g", "f", "q", "type"} {
+		if address := "/ch/05/eq/2/" + p; mixer.Value(address) != nil {
+			t.Errorf("%s written, want only band 3 of strip 5", address)
+		}
+	}
+}
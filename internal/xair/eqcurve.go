@@ -0,0 +1,74 @@
+package xair
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// eqSampleRate is the sample rate the EQ filters are modelled at.
+const eqSampleRate = 48000.0
+
+// biquad holds the coefficients of a second order filter, normalised so that a0 is 1.
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+}
+
+// bandBiquad returns the filter of an EQ band, following the formulas of the Audio EQ Cookbook.
+// Vintage bands are modelled as parametric ones.
+func bandBiquad(band EqBandSnapshot) biquad {
+	w0 := 2 * math.Pi * band.Frequency / eqSampleRate
+	cos, sin := math.Cos(w0), math.Sin(w0)
+	alpha := sin / (2 * band.Q)
+	a := math.Pow(10, band.Gain/40)
+
+	var b0, b1, b2, a0, a1, a2 float64
+	switch band.Type {
+	case "lcut":
+		b0, b1, b2 = (1+cos)/2, -(1 + cos), (1+cos)/2
+		a0, a1, a2 = 1+alpha, -2*cos, 1-alpha
+	case "hcut":
+		b0, b1, b2 = (1-cos)/2, 1-cos, (1-cos)/2
+		a0, a1, a2 = 1+alpha, -2*cos, 1-alpha
+	case "lshv":
+		sq := 2 * math.Sqrt(a) * alpha
+		b0 = a * ((a + 1) - (a-1)*cos + sq)
+		b1 = 2 * a * ((a - 1) - (a+1)*cos)
+		b2 = a * ((a + 1) - (a-1)*cos - sq)
+		a0 = (a + 1) + (a-1)*cos + sq
+		a1 = -2 * ((a - 1) + (a+1)*cos)
+		a2 = (a + 1) + (a-1)*cos - sq
+	case "hshv":
+		sq := 2 * math.Sqrt(a) * alpha
+		b0 = a * ((a + 1) + (a-1)*cos + sq)
+		b1 = -2 * a * ((a - 1) + (a+1)*cos)
+		b2 = a * ((a + 1) + (a-1)*cos - sq)
+		a0 = (a + 1) - (a-1)*cos + sq
+		a1 = 2 * ((a - 1) - (a+1)*cos)
+		a2 = (a + 1) - (a-1)*cos - sq
+	default: // peq, veq
+		b0, b1, b2 = 1+alpha*a, -2*cos, 1-alpha*a
+		a0, a1, a2 = 1+alpha/a, -2*cos, 1-alpha/a
+	}
+	return biquad{b0 / a0, b1 / a0, b2 / a0, a1 / a0, a2 / a0}
+}
+
+// magnitude returns the gain of the filter at freq in dB.
+func (f biquad) magnitude(freq float64) float64 {
+	z := cmplx.Exp(complex(0, -2*math.Pi*freq/eqSampleRate)) // z^-1
+	num := complex(f.b0, 0) + complex(f.b1, 0)*z + complex(f.b2, 0)*z*z
+	den := 1 + complex(f.a1, 0)*z + complex(f.a2, 0)*z*z
+	return 20 * math.Log10(cmplx.Abs(num/den))
+}
+
+// Response returns the combined gain of all bands of the EQ at freq in dB.
+// An EQ that is off passes everything at 0 dB.
+func (s EqSnapshot) Response(freq float64) float64 {
+	if !s.On {
+		return 0
+	}
+	var gain float64
+	for _, band := range s.Bands {
+		gain += bandBiquad(band).magnitude(freq)
+	}
+	return gain
+}
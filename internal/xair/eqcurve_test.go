@@ -0,0 +1,41 @@
+package xair
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqSnapshotResponse(t *testing.T) {
+	peq := EqBandSnapshot{Type: "peq", Frequency: 1000, Gain: 6, Q: 2}
+	lcut := EqBandSnapshot{Type: "lcut", Frequency: 100, Q: math.Sqrt2 / 2}
+	hcut := EqBandSnapshot{Type: "hcut", Frequency: 10000, Q: math.Sqrt2 / 2}
+	lshv := EqBandSnapshot{Type: "lshv", Frequency: 200, Gain: -9, Q: 0.7}
+	hshv := EqBandSnapshot{Type: "hshv", Frequency: 8000, Gain: 4, Q: 0.7}
+
+	tests := []struct {
+		name string
+		eq   EqSnapshot
+		freq float64
+		want float64
+	}{
+		{"peq at its centre", EqSnapshot{On: true, Bands: []EqBandSnapshot{peq}}, 1000, 6},
+		{"peq two decades below", EqSnapshot{On: true, Bands: []EqBandSnapshot{peq}}, 10, 0},
+		{"peq at the top of the audio band", EqSnapshot{On: true, Bands: []EqBandSnapshot{peq}}, 20000, 0},
+		{"lcut at its corner", EqSnapshot{On: true, Bands: []EqBandSnapshot{lcut}}, 100, -3},
+		{"lcut well above its corner", EqSnapshot{On: true, Bands: []EqBandSnapshot{lcut}}, 5000, 0},
+		{"hcut at its corner", EqSnapshot{On: true, Bands: []EqBandSnapshot{hcut}}, 10000, -3},
+		{"hcut well below its corner", EqSnapshot{On: true, Bands: []EqBandSnapshot{hcut}}, 100, 0},
+		{"lshv below its corner", EqSnapshot{On: true, Bands: []EqBandSnapshot{lshv}}, 10, -9},
+		{"hshv above its corner", EqSnapshot{On: true, Bands: []EqBandSnapshot{hshv}}, 20000, 4},
+		{"bands add up", EqSnapshot{On: true, Bands: []EqBandSnapshot{peq, lshv}}, 1000, 6},
+		{"eq off", EqSnapshot{On: false, Bands: []EqBandSnapshot{peq}}, 1000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// -3 dB is the rounded gain at the corner of a Butterworth filter, 20*log10(1/sqrt(2)) is -3.01
+			if got := tt.eq.Response(tt.freq); math.Abs(got-tt.want) > 0.1 {
+				t.Errorf("Response(%g) = %.2f dB, want %.2f dB", tt.freq, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package xair
+
+import "errors"
+
+// Errors the client wraps its failures in, so callers can tell them apart with errors.Is.
+var (
+	// ErrTimeout is returned when the mixer does not reply within the timeout.
+	ErrTimeout = errors.New("timeout waiting for response")
+	// ErrUnexpectedType is returned when a reply holds an argument of another type than the parameter has.
+	ErrUnexpectedType = errors.New("unexpected argument type")
+	// ErrNoValue is returned when a reply holds no arguments, as the mixer sends for addresses it does not support.
+	ErrNoValue = errors.New("reply holds no value")
+	// ErrNotConnected is returned when a message cannot be sent to the mixer.
+	ErrNotConnected = errors.New("not connected to the mixer")
+)
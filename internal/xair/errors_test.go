@@ -0,0 +1,56 @@
+package xair
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	sentinels := []error{ErrTimeout, ErrUnexpectedType, ErrNoValue, ErrNotConnected}
+	tests := []struct {
+		name string
+		run  func(t *testing.T) error
+		want error
+	}{
+		{"timeout", func(t *testing.T) error {
+			client, mixer := newTestClient(t, WithTimeout(50*time.Millisecond))
+			mixer.Delay("/ch/01/mix/fader", 100*time.Millisecond)
+			_, err := client.Strip.Fader(1)
+			return err
+		}, ErrTimeout},
+		{"unexpected type", func(t *testing.T) error {
+			client, mixer := newTestClient(t)
+			mixer.Set("/ch/01/mix/fader", "loud")
+			_, err := client.Strip.Fader(1)
+			return err
+		}, ErrUnexpectedType},
+		{"no value", func(t *testing.T) error {
+			client, mixer := newTestClient(t)
+			mixer.Empty("/ch/01/mix/fader")
+			_, err := client.Strip.Fader(1)
+			return err
+		}, ErrNoValue},
+		{"not connected", func(t *testing.T) error {
+			mixer := xairtest.NewMixer(t, "XR18")
+			client, err := NewXAirClient(mixer.Host(), mixer.Port(), WithTimeout(time.Second))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			client.Close()
+			return client.Strip.SetFader(1, -10)
+		}, ErrNotConnected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(t)
+			for _, sentinel := range sentinels {
+				if got, want := errors.Is(err, sentinel), sentinel == tt.want; got != want {
+					t.Errorf("errors.Is(%v, %v) = %t, want %t", err, sentinel, got, want)
+				}
+			}
+		})
+	}
+}
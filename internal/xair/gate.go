@@ -2,6 +2,17 @@ package xair
 
 import "fmt"
 
+// GateParams holds the parameters of a Gate.
+// Nil fields are left untouched by SetAll.
+type GateParams struct {
+	Mode      *string
+	Threshold *float64
+	Range     *float64
+	Attack    *float64
+	Hold      *float64
+	Release   *float64
+}
+
 // Gate represents the gate parameters.
 type Gate struct {
 	client      *Client
@@ -27,18 +38,13 @@ func newGate(c *Client, baseAddress string, opts ...GateOption) *Gate {
 // On retrieves the on/off status of the Gate for a specific strip (1-based indexing).
 func (g *Gate) On(index int) (bool, error) {
 	address := g.AddressFunc(g.baseAddress, index) + "/on"
-	err := g.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := g.client.ReceiveMessage()
+	msg, err := g.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for Gate on value")
+		return false, fmt.Errorf("%w for Gate on value", ErrUnexpectedType)
 	}
 	return val != 0, nil
 }
@@ -56,153 +62,317 @@ func (g *Gate) SetOn(index int, on bool) error {
 // Mode retrieves the current mode of the Gate for a specific strip (1-based indexing).
 func (g *Gate) Mode(index int) (string, error) {
 	address := g.AddressFunc(g.baseAddress, index) + "/mode"
-	err := g.client.SendMessage(address)
-	if err != nil {
-		return "", err
-	}
-
-	possibleModes := []string{"exp2", "exp3", "exp4", "gate", "duck"}
-
-	msg, err := g.client.ReceiveMessage()
+	msg, err := g.client.query(address)
 	if err != nil {
 		return "", err
 	}
 	val, ok := msg.Arguments[0].(int32)
-	if !ok {
-		return "", fmt.Errorf("unexpected argument type for Gate mode value")
+	if !ok || val < 0 || int(val) >= len(gateModes) {
+		return "", fmt.Errorf("%w for Gate mode value", ErrUnexpectedType)
 	}
-	return possibleModes[val], nil
+	return gateModes[val], nil
 }
 
 // SetMode sets the mode of the Gate for a specific strip (1-based indexing).
 func (g *Gate) SetMode(index int, mode string) error {
 	address := g.AddressFunc(g.baseAddress, index) + "/mode"
-	possibleModes := []string{"exp2", "exp3", "exp4", "gate", "duck"}
-
-	return g.client.SendMessage(address, int32(indexOf(possibleModes, mode)))
+	i, err := choiceIndex("gate mode", mode, gateModes)
+	if err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, int32(i))
 }
 
 // Threshold retrieves the threshold value of the Gate for a specific strip (1-based indexing).
 func (g *Gate) Threshold(index int) (float64, error) {
 	address := g.AddressFunc(g.baseAddress, index) + "/thr"
-	err := g.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := g.client.ReceiveMessage()
+	msg, err := g.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Gate threshold value")
+		return 0, fmt.Errorf("%w for Gate threshold value", ErrUnexpectedType)
 	}
-	return linGet(-80, 0, float64(val)), nil
+	return linGet(gateThresholdRange.min, gateThresholdRange.max, float64(val)), nil
 }
 
 // SetThreshold sets the threshold value of the Gate for a specific strip (1-based indexing).
 func (g *Gate) SetThreshold(index int, threshold float64) error {
 	address := g.AddressFunc(g.baseAddress, index) + "/thr"
-	return g.client.SendMessage(address, float32(linSet(-80, 0, threshold)))
+	if err := gateThresholdRange.check("gate threshold", threshold); err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, float32(linSet(gateThresholdRange.min, gateThresholdRange.max, threshold)))
 }
 
 // Range retrieves the range value of the Gate for a specific strip (1-based indexing).
 func (g *Gate) Range(index int) (float64, error) {
 	address := g.AddressFunc(g.baseAddress, index) + "/range"
-	err := g.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := g.client.ReceiveMessage()
+	msg, err := g.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Gate range value")
+		return 0, fmt.Errorf("%w for Gate range value", ErrUnexpectedType)
 	}
-	return linGet(3, 60, float64(val)), nil
+	return linGet(gateRangeRange.min, gateRangeRange.max, float64(val)), nil
 }
 
 // SetRange sets the range value of the Gate for a specific strip (1-based indexing).
 func (g *Gate) SetRange(index int, rangeVal float64) error {
 	address := g.AddressFunc(g.baseAddress, index) + "/range"
-	return g.client.SendMessage(address, float32(linSet(3, 60, rangeVal)))
+	if err := gateRangeRange.check("gate range", rangeVal); err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, float32(linSet(gateRangeRange.min, gateRangeRange.max, rangeVal)))
 }
 
 // Attack retrieves the attack time of the Gate for a specific strip (1-based indexing).
 func (g *Gate) Attack(index int) (float64, error) {
 	address := g.AddressFunc(g.baseAddress, index) + "/attack"
-	err := g.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := g.client.ReceiveMessage()
+	msg, err := g.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Gate attack value")
+		return 0, fmt.Errorf("%w for Gate attack value", ErrUnexpectedType)
 	}
-	return linGet(0, 120, float64(val)), nil
+	return linGet(gateAttackRange.min, gateAttackRange.max, float64(val)), nil
 }
 
 // SetAttack sets the attack time of the Gate for a specific strip (1-based indexing).
 func (g *Gate) SetAttack(index int, attack float64) error {
 	address := g.AddressFunc(g.baseAddress, index) + "/attack"
-	return g.client.SendMessage(address, float32(linSet(0, 120, attack)))
+	if err := gateAttackRange.check("gate attack", attack); err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, float32(linSet(gateAttackRange.min, gateAttackRange.max, attack)))
 }
 
 // Hold retrieves the hold time of the Gate for a specific strip (1-based indexing).
 func (g *Gate) Hold(index int) (float64, error) {
 	address := g.AddressFunc(g.baseAddress, index) + "/hold"
-	err := g.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := g.client.ReceiveMessage()
+	msg, err := g.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Gate hold value")
+		return 0, fmt.Errorf("%w for Gate hold value", ErrUnexpectedType)
 	}
-	return logGet(0.02, 2000, float64(val)), nil
+	return logGet(gateHoldRange.min, gateHoldRange.max, float64(val)), nil
 }
 
 // SetHold sets the hold time of the Gate for a specific strip (1-based indexing).
 func (g *Gate) SetHold(index int, hold float64) error {
 	address := g.AddressFunc(g.baseAddress, index) + "/hold"
-	return g.client.SendMessage(address, float32(logSet(0.02, 2000, hold)))
+	if err := gateHoldRange.check("gate hold", hold); err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, float32(logSet(gateHoldRange.min, gateHoldRange.max, hold)))
 }
 
 // Release retrieves the release time of the Gate for a specific strip (1-based indexing).
 func (g *Gate) Release(index int) (float64, error) {
 	address := g.AddressFunc(g.baseAddress, index) + "/release"
-	err := g.client.SendMessage(address)
+	msg, err := g.client.query(address)
 	if err != nil {
 		return 0, err
 	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for Gate release value", ErrUnexpectedType)
+	}
+	return logGet(gateReleaseRange.min, gateReleaseRange.max, float64(val)), nil
+}
+
+// SetRelease sets the release time of the Gate for a specific strip (1-based indexing).
+func (g *Gate) SetRelease(index int, release float64) error {
+	address := g.AddressFunc(g.baseAddress, index) + "/release"
+	if err := gateReleaseRange.check("gate release", release); err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, float32(logSet(gateReleaseRange.min, gateReleaseRange.max, release)))
+}
 
-	msg, err := g.client.ReceiveMessage()
+// KeySource retrieves the key source of the Gate for a specific strip (1-based indexing).
+func (g *Gate) KeySource(index int) (string, error) {
+	address := g.AddressFunc(g.baseAddress, index) + "/keysrc"
+	msg, err := g.client.query(address)
+	if err != nil {
+		return "", err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return "", fmt.Errorf("%w for Gate key source value", ErrUnexpectedType)
+	}
+	return g.client.keySourceName(val)
+}
+
+// SetKeySource sets the key source of the Gate for a specific strip (1-based indexing).
+func (g *Gate) SetKeySource(index int, source string) error {
+	address := g.AddressFunc(g.baseAddress, index) + "/keysrc"
+	val, err := g.client.keySourceValue(source)
+	if err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, val)
+}
+
+// FilterOn retrieves the on/off status of the Gate key filter for a specific strip (1-based indexing).
+func (g *Gate) FilterOn(index int) (bool, error) {
+	address := g.AddressFunc(g.baseAddress, index) + "/filter/on"
+	msg, err := g.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for Gate filter on value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetFilterOn sets the on/off status of the Gate key filter for a specific strip (1-based indexing).
+func (g *Gate) SetFilterOn(index int, on bool) error {
+	address := g.AddressFunc(g.baseAddress, index) + "/filter/on"
+	var value int32
+	if on {
+		value = 1
+	}
+	return g.client.SendMessage(address, value)
+}
+
+// FilterFreq retrieves the frequency of the Gate key filter for a specific strip (1-based indexing).
+func (g *Gate) FilterFreq(index int) (float64, error) {
+	address := g.AddressFunc(g.baseAddress, index) + "/filter/f"
+	msg, err := g.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for Gate release value")
+		return 0, fmt.Errorf("%w for Gate filter frequency value", ErrUnexpectedType)
 	}
-	return logGet(5, 4000, float64(val)), nil
+	return logGet(gateFilterFreqRange.min, gateFilterFreqRange.max, float64(val)), nil
 }
 
-// SetRelease sets the release time of the Gate for a specific strip (1-based indexing).
-func (g *Gate) SetRelease(index int, release float64) error {
-	address := g.AddressFunc(g.baseAddress, index) + "/release"
-	return g.client.SendMessage(address, float32(logSet(5, 4000, release)))
+// SetFilterFreq sets the frequency of the Gate key filter for a specific strip (1-based indexing).
+func (g *Gate) SetFilterFreq(index int, frequency float64) error {
+	address := g.AddressFunc(g.baseAddress, index) + "/filter/f"
+	if err := gateFilterFreqRange.check("gate key filter frequency", frequency); err != nil {
+		return err
+	}
+	return g.client.SendMessage(address, float32(logSet(gateFilterFreqRange.min, gateFilterFreqRange.max, frequency)))
+}
+
+// GateSnapshot holds the settings of a Gate.
+type GateSnapshot struct {
+	On         bool    `json:"on"`
+	Mode       string  `json:"mode"`
+	Threshold  float64 `json:"threshold"`
+	Range      float64 `json:"range"`
+	Attack     float64 `json:"attack"`
+	Hold       float64 `json:"hold"`
+	Release    float64 `json:"release"`
+	KeySource  string  `json:"keysrc"`
+	FilterOn   bool    `json:"filter_on"`
+	FilterFreq float64 `json:"filter_freq"`
+}
+
+// Snapshot reads all settings of the Gate for a specific strip (1-based indexing).
+func (g *Gate) Snapshot(index int) (GateSnapshot, error) {
+	var snap GateSnapshot
+	var err error
+	if snap.On, err = g.On(index); err != nil {
+		return snap, err
+	}
+	if snap.Mode, err = g.Mode(index); err != nil {
+		return snap, err
+	}
+	if snap.Threshold, err = g.Threshold(index); err != nil {
+		return snap, err
+	}
+	if snap.Range, err = g.Range(index); err != nil {
+		return snap, err
+	}
+	if snap.Attack, err = g.Attack(index); err != nil {
+		return snap, err
+	}
+	if snap.Hold, err = g.Hold(index); err != nil {
+		return snap, err
+	}
+	if snap.Release, err = g.Release(index); err != nil {
+		return snap, err
+	}
+	if snap.KeySource, err = g.KeySource(index); err != nil {
+		return snap, err
+	}
+	if snap.FilterOn, err = g.FilterOn(index); err != nil {
+		return snap, err
+	}
+	if snap.FilterFreq, err = g.FilterFreq(index); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
+
+// ApplySnapshot writes all settings from snap to the Gate for a specific strip (1-based indexing).
+func (g *Gate) ApplySnapshot(index int, snap GateSnapshot) error {
+	setters := []func() error{
+		func() error { return g.SetMode(index, snap.Mode) },
+		func() error { return g.SetThreshold(index, snap.Threshold) },
+		func() error { return g.SetRange(index, snap.Range) },
+		func() error { return g.SetAttack(index, snap.Attack) },
+		func() error { return g.SetHold(index, snap.Hold) },
+		func() error { return g.SetRelease(index, snap.Release) },
+		func() error { return g.SetKeySource(index, snap.KeySource) },
+		func() error { return g.SetFilterOn(index, snap.FilterOn) },
+		func() error { return g.SetFilterFreq(index, snap.FilterFreq) },
+		func() error { return g.SetOn(index, snap.On) },
+	}
+	for _, set := range setters {
+		if err := set(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetAll applies all provided parameters of the Gate for a specific strip (1-based indexing), skipping any nil fields.
+func (g *Gate) SetAll(index int, params GateParams) error {
+	if params.Mode != nil {
+		if err := g.SetMode(index, *params.Mode); err != nil {
+			return err
+		}
+	}
+	if params.Threshold != nil {
+		if err := g.SetThreshold(index, *params.Threshold); err != nil {
+			return err
+		}
+	}
+	if params.Range != nil {
+		if err := g.SetRange(index, *params.Range); err != nil {
+			return err
+		}
+	}
+	if params.Attack != nil {
+		if err := g.SetAttack(index, *params.Attack); err != nil {
+			return err
+		}
+	}
+	if params.Hold != nil {
+		if err := g.SetHold(index, *params.Hold); err != nil {
+			return err
+		}
+	}
+	if params.Release != nil {
+		if err := g.SetRelease(index, *params.Release); err != nil {
+			return err
+		}
+	}
+	return nil
 }
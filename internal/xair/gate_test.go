@@ -0,0 +1,56 @@
+package xair
+
+import (
+	"testing"
+)
+
+func TestGateSetAllSkipsOmittedFields(t *testing.T) {
+	mode, threshold, rangeVal := "gate", -40.0, 20.0
+	attack, hold, release := 5.0, 10.0, 200.0
+	tests := []struct {
+		name   string
+		params GateParams
+		want   []string // the gate parameters expected to be written
+	}{
+		{"threshold and range", GateParams{Threshold: &threshold, Range: &rangeVal}, []string{"thr", "range"}},
+		{"times", GateParams{Attack: &attack, Hold: &hold, Release: &release}, []string{"attack", "hold", "release"}},
+		{"mode", GateParams{Mode: &mode}, []string{"mode"}},
+		{"nothing", GateParams{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t)
+			if err := client.Strip.Gate.SetAll(4, tt.params); err != nil {
+				t.Fatalf("SetAll failed: %v", err)
+			}
+			flush(t, &client.Client)
+
+			written := map[string]bool{}
+			for _, p := range tt.want {
+				written[p] = true
+			}
+			for _, p := range []string{"mode", "thr", "range", "attack", "hold", "release"} {
+				address := "/ch/04/gate/" + p
+				if got := mixer.Value(address) != nil; got != written[p] {
+					t.Errorf("%s written: %t, want %t", address, got, written[p])
+				}
+			}
+		})
+	}
+}
+
+func TestGateSetAllRoundTrip(t *testing.T) {
+	client, _ := newTestClient(t)
+	threshold, rangeVal, release := -40.0, 20.0, 200.0
+	if err := client.Strip.Gate.SetAll(2, GateParams{Threshold: &threshold, Range: &rangeVal, Release: &release}); err != nil {
+		t.Fatalf("SetAll failed: %v", err)
+	}
+
+	snap, err := client.Strip.Gate.Snapshot(2)
+	if err != nil {
+		t.Fatalf("failed to read the gate back: %v", err)
+	}
+	if !near(snap.Threshold, threshold) || !near(snap.Range, rangeVal) || !near(snap.Release, release) {
+		t.Errorf("got threshold %g, range %g, release %g, want %g, %g, %g", snap.Threshold, snap.Range, snap.Release, threshold, rangeVal, release)
+	}
+}
@@ -18,44 +18,37 @@ func newHeadAmp(c *Client) *HeadAmp {
 // Gain gets the gain level for the specified headamp index.
 func (h *HeadAmp) Gain(index int) (float64, error) {
 	address := fmt.Sprintf(h.baseAddress, index) + "/gain"
-	err := h.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := h.client.ReceiveMessage()
+	msg, err := h.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for headamp gain value")
+		return 0, fmt.Errorf("%w for headamp gain value", ErrUnexpectedType)
 	}
 
-	return linGet(-12, 60, float64(val)), nil
+	return linGet(headampGainRange.min, headampGainRange.max, float64(val)), nil
 }
 
 // SetGain sets the gain level for the specified headamp index.
 func (h *HeadAmp) SetGain(index int, level float64) error {
 	address := fmt.Sprintf(h.baseAddress, index) + "/gain"
-	return h.client.SendMessage(address, float32(linSet(-12, 60, level)))
+	if err := headampGainRange.check("headamp gain", level); err != nil {
+		return err
+	}
+	return h.client.SendMessage(address, float32(linSet(headampGainRange.min, headampGainRange.max, level)))
 }
 
 // PhantomPower gets the phantom power status for the specified headamp index.
 func (h *HeadAmp) PhantomPower(index int) (bool, error) {
 	address := fmt.Sprintf(h.baseAddress, index) + "/phantom"
-	err := h.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := h.client.ReceiveMessage()
+	msg, err := h.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for phantom power value")
+		return false, fmt.Errorf("%w for phantom power value", ErrUnexpectedType)
 	}
 
 	return val != 0, nil
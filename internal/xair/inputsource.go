@@ -0,0 +1,73 @@
+package xair
+
+import (
+	"fmt"
+	"strings"
+)
+
+// xairInputSources lists the channel input sources of the X-Air mixers in the order the mixer enumerates them.
+var xairInputSources = sourceList(
+	numberedSources("IN %02d", 16),
+	[]string{"AUX L", "AUX R"},
+	numberedSources("USB %02d", 18),
+)
+
+// x32InputSources lists the channel input sources of the X32 mixers in the order the mixer enumerates them.
+var x32InputSources = sourceList(
+	[]string{"OFF"},
+	numberedSources("IN %02d", 32),
+	numberedSources("AUX %d", 6),
+	[]string{"USB L", "USB R"},
+	[]string{"FX 1L", "FX 1R", "FX 2L", "FX 2R", "FX 3L", "FX 3R", "FX 4L", "FX 4R"},
+	numberedSources("BUS %02d", 16),
+)
+
+func inputSourcesFromMixerKind(kind mixerKind) []string {
+	switch kind {
+	case kindX32:
+		return x32InputSources
+	default:
+		return xairInputSources
+	}
+}
+
+// inputSourceName returns the name of the input source at position val.
+func (c *Client) inputSourceName(val int32) (string, error) {
+	if val < 0 || int(val) >= len(c.inputSources) {
+		return "", fmt.Errorf("unknown input source value: %d", val)
+	}
+	return c.inputSources[val], nil
+}
+
+// inputSourceValue returns the position of the named input source.
+// Names are matched case-insensitively and spaces are optional, so "in01" selects "IN 01".
+func (c *Client) inputSourceValue(name string) (int32, error) {
+	normalise := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	}
+	for i, source := range c.inputSources {
+		if normalise(source) == normalise(name) {
+			return int32(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid input source %q, expected one of: %s", name, strings.Join(c.inputSources, ", "))
+}
+
+// SourceHeadamp returns the index of the headamp that feeds the named input source, in the numbering HeadAmp takes.
+// It reports false for sources without a headamp, only the local inputs (IN NN) have one.
+func (c *Client) SourceHeadamp(source string) (int, bool, error) {
+	val, err := c.inputSourceValue(source)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var input int
+	if _, err := fmt.Sscanf(c.inputSources[val], "IN %02d", &input); err != nil {
+		return 0, false, nil
+	}
+	if c.Kind == kindX32 {
+		// X32 headamps count from 000 for IN 01
+		return input - 1, true, nil
+	}
+	return input, true, nil
+}
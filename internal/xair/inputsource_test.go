@@ -0,0 +1,38 @@
+package xair
+
+import (
+	"testing"
+)
+
+func TestSourceHeadamp(t *testing.T) {
+	xr18, _ := newTestClient(t)
+	x32, _ := newTestX32Client(t)
+	tests := []struct {
+		name    string
+		client  *Client
+		source  string
+		want    int
+		wantOK  bool
+		wantErr bool
+	}{
+		{"xair local input", &xr18.Client, "IN 07", 7, true, false},
+		{"xair short name", &xr18.Client, "in16", 16, true, false},
+		{"xair usb return", &xr18.Client, "USB 01", 0, false, false},
+		{"xair aux input", &xr18.Client, "AUX L", 0, false, false},
+		{"x32 counts from zero", &x32.Client, "IN 01", 0, true, false},
+		{"x32 local input", &x32.Client, "in09", 8, true, false},
+		{"x32 aux input", &x32.Client, "AUX 1", 0, false, false},
+		{"unknown source", &xr18.Client, "IN 99", 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := tt.client.SourceHeadamp(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SourceHeadamp(%q) error = %v, want error %t", tt.source, err, tt.wantErr)
+			}
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("SourceHeadamp(%q) = %d, %t, want %d, %t", tt.source, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package xair
+
+import (
+	"fmt"
+	"strings"
+)
+
+// xairKeySources lists the dynamics key sources of the X-Air mixers in the order the mixer enumerates them.
+var xairKeySources = sourceList(
+	[]string{"self"},
+	numberedSources("ch%02d", 16),
+	numberedSources("bus%d", 6),
+)
+
+// x32KeySources lists the dynamics key sources of the X32 mixers in the order the mixer enumerates them.
+var x32KeySources = sourceList(
+	[]string{"self"},
+	numberedSources("ch%02d", 32),
+	numberedSources("aux%d", 8),
+	[]string{"fx1l", "fx1r", "fx2l", "fx2r", "fx3l", "fx3r", "fx4l", "fx4r"},
+	numberedSources("bus%02d", 16),
+)
+
+func keySourcesFromMixerKind(kind mixerKind) []string {
+	switch kind {
+	case kindX32:
+		return x32KeySources
+	default:
+		return xairKeySources
+	}
+}
+
+// numberedSources returns count source names built from a 1-based format string.
+func numberedSources(format string, count int) []string {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf(format, i+1)
+	}
+	return names
+}
+
+// sourceList concatenates groups of source names into a single list.
+func sourceList(groups ...[]string) []string {
+	var names []string
+	for _, group := range groups {
+		names = append(names, group...)
+	}
+	return names
+}
+
+// keySourceName returns the name of the key source at position val.
+func (c *Client) keySourceName(val int32) (string, error) {
+	if val < 0 || int(val) >= len(c.keySources) {
+		return "", fmt.Errorf("unknown key source value: %d", val)
+	}
+	return c.keySources[val], nil
+}
+
+// keySourceValue returns the position of the named key source.
+func (c *Client) keySourceValue(name string) (int32, error) {
+	i := indexOf(c.keySources, name)
+	if i == -1 {
+		return 0, fmt.Errorf("invalid key source %q, expected one of: %s", name, strings.Join(c.keySources, ", "))
+	}
+	return int32(i), nil
+}
@@ -1,8 +1,138 @@
 package xair
 
+import (
+	"fmt"
+	"strings"
+)
+
 type mixerKind string
 
 const (
 	kindXAir mixerKind = "xair"
 	kindX32  mixerKind = "x32"
 )
+
+// busCount returns the number of mix buses on mixers of this kind.
+func (k mixerKind) busCount() int {
+	switch k {
+	case kindX32:
+		return 16
+	default:
+		return 6
+	}
+}
+
+// stripCount returns the number of input strips on mixers of this kind.
+func (k mixerKind) stripCount() int {
+	switch k {
+	case kindX32:
+		return 32
+	default:
+		return 16
+	}
+}
+
+// insertSlotCount returns the number of insert slots a strip can select, excluding off.
+// XAir mixers insert one of the four FX slots, X32 mixers one of FX1L-FX8R or AUX1-6.
+func (k mixerKind) insertSlotCount() int {
+	switch k {
+	case kindX32:
+		return 22
+	default:
+		return 4
+	}
+}
+
+// matrixCount returns the number of matrix outputs on mixers of this kind.
+func (k mixerKind) matrixCount() int {
+	switch k {
+	case kindX32:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// eqBandCount returns the number of parametric EQ bands of target (strip, bus, main, mainmono or matrix)
+// on mixers of this kind, or 0 when the target does not exist on them.
+// Strips have four bands and every output six, the XAir and X32 models agree on this.
+func (k mixerKind) eqBandCount(target string) int {
+	switch target {
+	case "strip":
+		return 4
+	case "bus", "main":
+		return 6
+	case "mainmono", "matrix":
+		if k == kindX32 {
+			return 6
+		}
+	}
+	return 0
+}
+
+// modelCounts holds the number of strips and buses of a specific mixer model.
+type modelCounts struct {
+	strips int
+	buses  int
+}
+
+// xairModelCounts lists the XAir models that have fewer strips or buses than the XR18.
+// X32 models all share the same counts, so they are covered by the kind.
+var xairModelCounts = map[string]modelCounts{
+	"XR12": {strips: 12, buses: 4},
+	"XR16": {strips: 16, buses: 4},
+}
+
+// StripCount returns the number of input strips of the connected mixer.
+// The model reported by RequestInfo takes precedence over the kind.
+func (c *Client) StripCount() int {
+	if counts, ok := xairModelCounts[c.model]; ok {
+		return counts.strips
+	}
+	return c.Kind.stripCount()
+}
+
+// BusCount returns the number of mix buses of the connected mixer.
+// The model reported by RequestInfo takes precedence over the kind.
+func (c *Client) BusCount() int {
+	if counts, ok := xairModelCounts[c.model]; ok {
+		return counts.buses
+	}
+	return c.Kind.busCount()
+}
+
+// EqBandCount returns the number of parametric EQ bands of target (strip, bus, main, mainmono or matrix)
+// on the connected mixer.
+func (c *Client) EqBandCount(target string) int {
+	return c.Kind.eqBandCount(target)
+}
+
+// ValidateIndex checks that index is a valid 1-based index of a strip, bus or matrix on the connected mixer.
+// Kinds of the form "<target> EQ band" check the index of a parametric EQ band of that target instead.
+func (c *Client) ValidateIndex(kind string, index int) error {
+	var count int
+	switch kind {
+	case "strip":
+		count = c.StripCount()
+	case "bus":
+		count = c.BusCount()
+	case "matrix":
+		count = c.Kind.matrixCount()
+	case "strip EQ band", "bus EQ band", "main EQ band", "mainmono EQ band", "matrix EQ band":
+		count = c.EqBandCount(strings.TrimSuffix(kind, " EQ band"))
+	default:
+		return fmt.Errorf("unknown index kind %q", kind)
+	}
+
+	model := c.model
+	if model == "" {
+		model = string(c.Kind)
+	}
+	if count == 0 {
+		return fmt.Errorf("%s is not available on %s", kind, model)
+	}
+	if index < 1 || index > count {
+		return fmt.Errorf("%s index %d out of range for %s (1-%d)", kind, index, model, count)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package xair
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+// newModelClient connects a client of the right kind to a fake mixer reporting model, and reads the model.
+func newModelClient(t *testing.T, model string) *Client {
+	t.Helper()
+	mixer := xairtest.NewMixer(t, model)
+	var client *Client
+	if model == "X32" {
+		c, err := NewX32Client(mixer.Host(), mixer.Port(), WithTimeout(time.Second))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		client = &c.Client
+	} else {
+		c, err := NewXAirClient(mixer.Host(), mixer.Port(), WithTimeout(time.Second))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		client = &c.Client
+	}
+	t.Cleanup(client.Close)
+	client.StartListening()
+	if _, err := client.RequestInfo(); err != nil {
+		t.Fatalf("failed to request mixer info: %v", err)
+	}
+	return client
+}
+
+func TestValidateIndex(t *testing.T) {
+	tests := []struct {
+		model string
+		kind  string
+		max   int
+	}{
+		{"XR12", "strip", 12},
+		{"XR12", "bus", 4},
+		{"XR16", "strip", 16},
+		{"XR16", "bus", 4},
+		{"XR18", "strip", 16},
+		{"XR18", "bus", 6},
+		{"XR18", "strip EQ band", 4},
+		{"XR18", "bus EQ band", 6},
+		{"X32", "strip", 32},
+		{"X32", "bus", 16},
+		{"X32", "matrix", 6},
+		{"X32", "matrix EQ band", 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model+"/"+tt.kind, func(t *testing.T) {
+			client := newModelClient(t, tt.model)
+			for _, index := range []int{1, tt.max} {
+				if err := client.ValidateIndex(tt.kind, index); err != nil {
+					t.Errorf("index %d rejected: %v", index, err)
+				}
+			}
+			for _, index := range []int{0, tt.max + 1} {
+				err := client.ValidateIndex(tt.kind, index)
+				want := fmt.Sprintf("%s index %d out of range for %s (1-%d)", tt.kind, index, tt.model, tt.max)
+				if err == nil || err.Error() != want {
+					t.Errorf("got error %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateIndexRejectsMissingKinds(t *testing.T) {
+	client := newModelClient(t, "XR18")
+	if err := client.ValidateIndex("matrix", 1); err == nil || err.Error() != "matrix is not available on XR18" {
+		t.Errorf("got error %v, want matrix is not available on XR18", err)
+	}
+	if err := client.ValidateIndex("headamp", 1); err == nil {
+		t.Error("unknown kind accepted")
+	}
+}
+
+func TestEqBandCount(t *testing.T) {
+	// the band counts of strip, bus, main, mainmono and matrix, 0 where the target does not exist
+	tests := map[string][5]int{
+		"XR12": {4, 6, 6, 0, 0},
+		"XR16": {4, 6, 6, 0, 0},
+		"XR18": {4, 6, 6, 0, 0},
+		"X32":  {4, 6, 6, 6, 6},
+	}
+	for model, want := range tests {
+		client := newModelClient(t, model)
+		for i, target := range []string{"strip", "bus", "main", "mainmono", "matrix"} {
+			if got := client.EqBandCount(target); got != want[i] {
+				t.Errorf("%s %s: got %d EQ bands, want %d", model, target, got, want[i])
+			}
+		}
+	}
+}
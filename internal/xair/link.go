@@ -0,0 +1,63 @@
+package xair
+
+import "fmt"
+
+type Link struct {
+	client *Client
+}
+
+// newLink creates a new Link instance
+func newLink(c *Client) *Link {
+	return &Link{
+		client: c,
+	}
+}
+
+// StripPairs returns the odd/even strip pairs that are currently linked, e.g. [1 2].
+func (l *Link) StripPairs() ([][2]int, error) {
+	return l.pairs(l.client.addressMap["chlink"], l.client.StripCount())
+}
+
+// BusPairs returns the odd/even bus pairs that are currently linked, e.g. [1 2].
+func (l *Link) BusPairs() ([][2]int, error) {
+	return l.pairs(l.client.addressMap["buslink"], l.client.BusCount())
+}
+
+// StripLinked reports whether strip is part of a linked odd/even pair.
+func (l *Link) StripLinked(strip int) (bool, error) {
+	return l.linked(l.client.addressMap["chlink"], strip)
+}
+
+// BusLinked reports whether bus is part of a linked odd/even pair.
+func (l *Link) BusLinked(bus int) (bool, error) {
+	return l.linked(l.client.addressMap["buslink"], bus)
+}
+
+// linked queries the link flag of the odd/even pair containing index.
+func (l *Link) linked(addressFmt string, index int) (bool, error) {
+	first := index - (1 - index%2)
+	msg, err := l.client.query(fmt.Sprintf(addressFmt, first, first+1))
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for link value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// pairs queries the link flag of every odd/even pair up to count and returns the linked ones.
+func (l *Link) pairs(addressFmt string, count int) ([][2]int, error) {
+	var pairs [][2]int
+	for first := 1; first < count; first += 2 {
+		linked, err := l.linked(addressFmt, first)
+		if err != nil {
+			return nil, err
+		}
+		if linked {
+			pairs = append(pairs, [2]int{first, first + 1})
+		}
+	}
+	return pairs, nil
+}
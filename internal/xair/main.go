@@ -3,10 +3,12 @@ package xair
 import "fmt"
 
 type Main struct {
-	client      *Client
-	baseAddress string
-	Eq          *Eq
-	Comp        *Comp
+	client        *Client
+	baseAddress   string
+	dimAddress    string
+	dimAttAddress string
+	Eq            *Eq
+	Comp          *Comp
 }
 
 // newMainStereo creates a new Main instance for stereo main output
@@ -16,10 +18,12 @@ func newMainStereo(c *Client) *Main {
 	}
 
 	return &Main{
-		client:      c,
-		baseAddress: c.addressMap["main"],
-		Eq:          newEq(c, c.addressMap["main"], WithEqAddressFunc(addressFunc)),
-		Comp:        newComp(c, c.addressMap["main"], WithCompAddressFunc(addressFunc)),
+		client:        c,
+		baseAddress:   c.addressMap["main"],
+		dimAddress:    c.addressMap["dim"],
+		dimAttAddress: c.addressMap["dimatt"],
+		Eq:            newEq(c, c.addressMap["main"], WithEqAddressFunc(addressFunc)),
+		Comp:          newComp(c, c.addressMap["main"], WithCompAddressFunc(addressFunc)),
 	}
 }
 
@@ -40,18 +44,13 @@ func newMainMono(c *Client) *Main {
 // Fader requests the current main L/R fader level
 func (m *Main) Fader() (float64, error) {
 	address := m.baseAddress + "/mix/fader"
-	err := m.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := m.client.ReceiveMessage()
+	msg, err := m.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for main LR fader value")
+		return 0, fmt.Errorf("%w for main LR fader value", ErrUnexpectedType)
 	}
 	return mustDbFrom(float64(val)), nil
 }
@@ -65,18 +64,13 @@ func (m *Main) SetFader(level float64) error {
 // Mute requests the current main L/R mute status
 func (m *Main) Mute() (bool, error) {
 	address := m.baseAddress + "/mix/on"
-	err := m.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := m.client.ReceiveMessage()
+	msg, err := m.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for main LR mute value")
+		return false, fmt.Errorf("%w for main LR mute value", ErrUnexpectedType)
 	}
 	return val == 0, nil
 }
@@ -90,3 +84,103 @@ func (m *Main) SetMute(muted bool) error {
 	}
 	return m.client.SendMessage(address, value)
 }
+
+// Dim requests the current dim state of the main output
+func (m *Main) Dim() (bool, error) {
+	if m.dimAddress == "" {
+		return false, fmt.Errorf("dim is not supported on this model")
+	}
+
+	msg, err := m.client.query(m.dimAddress)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for main dim value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetDim sets the dim state of the main output
+func (m *Main) SetDim(dimmed bool) error {
+	if m.dimAddress == "" {
+		return fmt.Errorf("dim is not supported on this model")
+	}
+
+	var value int32
+	if dimmed {
+		value = 1
+	}
+	return m.client.SendMessage(m.dimAddress, value)
+}
+
+// DimLevel requests the attenuation (in dB) applied when dim is engaged
+func (m *Main) DimLevel() (float64, error) {
+	if m.dimAttAddress == "" {
+		return 0, fmt.Errorf("dim is not supported on this model")
+	}
+
+	msg, err := m.client.query(m.dimAttAddress)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for main dim level value", ErrUnexpectedType)
+	}
+	return linGet(dimLevelRange.min, dimLevelRange.max, float64(val)), nil
+}
+
+// SetDimLevel sets the attenuation (in dB) applied when dim is engaged
+func (m *Main) SetDimLevel(level float64) error {
+	if m.dimAttAddress == "" {
+		return fmt.Errorf("dim is not supported on this model")
+	}
+
+	if err := dimLevelRange.check("dim level", level); err != nil {
+		return err
+	}
+	return m.client.SendMessage(m.dimAttAddress, float32(linSet(dimLevelRange.min, dimLevelRange.max, level)))
+}
+
+// MainSnapshot holds the settings of a main output.
+type MainSnapshot struct {
+	Mute  bool         `json:"mute"`
+	Fader float64      `json:"fader"`
+	Eq    EqSnapshot   `json:"eq"`
+	Comp  CompSnapshot `json:"comp"`
+}
+
+// Snapshot reads all settings of the main output.
+func (m *Main) Snapshot() (MainSnapshot, error) {
+	var snap MainSnapshot
+	var err error
+	if snap.Mute, err = m.Mute(); err != nil {
+		return snap, err
+	}
+	if snap.Fader, err = m.Fader(); err != nil {
+		return snap, err
+	}
+	if snap.Eq, err = m.Eq.Snapshot(0, m.client.EqBandCount("main")); err != nil {
+		return snap, err
+	}
+	if snap.Comp, err = m.Comp.Snapshot(0); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
+
+// ApplySnapshot writes all settings from snap to the main output, the fader and mute state last.
+func (m *Main) ApplySnapshot(snap MainSnapshot) error {
+	if err := m.Eq.ApplySnapshot(0, snap.Eq); err != nil {
+		return err
+	}
+	if err := m.Comp.ApplySnapshot(0, snap.Comp); err != nil {
+		return err
+	}
+	if err := m.SetFader(snap.Fader); err != nil {
+		return err
+	}
+	return m.SetMute(snap.Mute)
+}
@@ -0,0 +1,68 @@
+package xair
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMainMonoEqAndCompAddresses(t *testing.T) {
+	client, mixer := newTestX32Client(t)
+	if err := client.MainMono.Eq.SetGain(0, 3, -6); err != nil {
+		t.Fatalf("failed to set EQ gain: %v", err)
+	}
+	if err := client.MainMono.Eq.SetOn(0, true); err != nil {
+		t.Fatalf("failed to set EQ on: %v", err)
+	}
+	if err := client.MainMono.Comp.SetThreshold(0, -20); err != nil {
+		t.Fatalf("failed to set comp threshold: %v", err)
+	}
+	flush(t, &client.Client)
+
+	for _, address := range []string{"/main/m/eq/3/g", "/main/m/eq/on", "/main/m/dyn/thr"} {
+		if mixer.Value(address) == nil {
+			t.Errorf("%s was not written", address)
+		}
+		stereo := strings.Replace(address, "/main/m/", "/main/st/", 1)
+		if mixer.Value(stereo) != nil {
+			t.Errorf("%s was written, want only the Main Mono address", stereo)
+		}
+	}
+
+	gain, err := client.MainMono.Eq.Gain(0, 3)
+	if err != nil {
+		t.Fatalf("failed to read EQ gain: %v", err)
+	}
+	if !near(gain, -6) {
+		t.Errorf("got EQ band 3 gain %g, want -6", gain)
+	}
+}
+
+func TestMainFaderAndMuteAddresses(t *testing.T) {
+	client, mixer := newTestX32Client(t)
+	tests := []struct {
+		name string
+		main *Main
+		base string
+	}{
+		{"main", client.Main, "/main/st"},
+		{"mainmono", client.MainMono, "/main/m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.main.SetFader(-10); err != nil {
+				t.Fatalf("failed to set fader: %v", err)
+			}
+			if err := tt.main.SetMute(true); err != nil {
+				t.Fatalf("failed to set mute: %v", err)
+			}
+			flush(t, &client.Client)
+
+			if got := mixer.Value(tt.base + "/mix/fader"); len(got) != 1 || got[0] != float32(0.5) {
+				t.Errorf("got %s/mix/fader %v, want [0.5]", tt.base, got)
+			}
+			if got := mixer.Value(tt.base + "/mix/on"); len(got) != 1 || got[0] != int32(0) {
+				t.Errorf("got %s/mix/on %v, want [0]", tt.base, got)
+			}
+		})
+	}
+}
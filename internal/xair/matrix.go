@@ -22,18 +22,13 @@ func newMatrix(c *Client) *Matrix {
 // Fader requests the current main L/R fader level
 func (m *Matrix) Fader(index int) (float64, error) {
 	address := fmt.Sprintf(m.baseAddress, index) + "/mix/fader"
-	err := m.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := m.client.ReceiveMessage()
+	msg, err := m.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for matrix fader value")
+		return 0, fmt.Errorf("%w for matrix fader value", ErrUnexpectedType)
 	}
 	return mustDbFrom(float64(val)), nil
 }
@@ -47,18 +42,13 @@ func (m *Matrix) SetFader(index int, level float64) error {
 // Mute requests the current matrix mute status
 func (m *Matrix) Mute(index int) (bool, error) {
 	address := fmt.Sprintf(m.baseAddress, index) + "/mix/on"
-	err := m.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := m.client.ReceiveMessage()
+	msg, err := m.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for matrix mute value")
+		return false, fmt.Errorf("%w for matrix mute value", ErrUnexpectedType)
 	}
 	return val == 0, nil
 }
@@ -72,3 +62,71 @@ func (m *Matrix) SetMute(index int, muted bool) error {
 	}
 	return m.client.SendMessage(address, value)
 }
+
+// Name requests the name of the matrix
+func (m *Matrix) Name(index int) (string, error) {
+	address := fmt.Sprintf(m.baseAddress, index) + "/config/name"
+	msg, err := m.client.query(address)
+	if err != nil {
+		return "", err
+	}
+	val, ok := msg.Arguments[0].(string)
+	if !ok {
+		return "", fmt.Errorf("%w for matrix name value", ErrUnexpectedType)
+	}
+	return val, nil
+}
+
+// SetName sets the name of the matrix, names longer than MaxNameLength are rejected
+func (m *Matrix) SetName(index int, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	address := fmt.Sprintf(m.baseAddress, index) + "/config/name"
+	return m.client.SendMessage(address, name)
+}
+
+// sourceAddress returns the send address that feeds source into matrix.
+// Sources 1 to the bus count are the mix buses, followed by the Main L/R and the Main Mono outputs.
+func (m *Matrix) sourceAddress(matrix int, source int) (string, error) {
+	buses := m.client.BusCount()
+	var base string
+	switch {
+	case source >= 1 && source <= buses:
+		base = fmt.Sprintf(m.client.addressMap["bus"], source)
+	case source == buses+1:
+		base = m.client.addressMap["main"]
+	case source == buses+2:
+		base = m.client.addressMap["mainmono"]
+	default:
+		return "", fmt.Errorf("matrix source must be between 1 and %d", buses+2)
+	}
+	return base + fmt.Sprintf("/mix/%02d/level", matrix), nil
+}
+
+// SourceLevel requests the level at which source feeds the matrix.
+func (m *Matrix) SourceLevel(matrix int, source int) (float64, error) {
+	address, err := m.sourceAddress(matrix, source)
+	if err != nil {
+		return 0, err
+	}
+
+	msg, err := m.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for matrix source level value", ErrUnexpectedType)
+	}
+	return mustDbFrom(float64(val)), nil
+}
+
+// SetSourceLevel sets the level at which source feeds the matrix.
+func (m *Matrix) SetSourceLevel(matrix int, source int, level float64) error {
+	address, err := m.sourceAddress(matrix, source)
+	if err != nil {
+		return err
+	}
+	return m.client.SendMessage(address, float32(mustDbInto(level)))
+}
@@ -0,0 +1,47 @@
+package xair
+
+import (
+	"testing"
+)
+
+func TestMatrixSourceLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  int
+		level   float64
+		address string
+		raw     float32
+	}{
+		{"first bus", 1, 0, "/bus/01/mix/03/level", 0.75},
+		{"last bus", 16, -10, "/bus/16/mix/03/level", 0.5},
+		{"main L/R", 17, 0, "/main/st/mix/03/level", 0.75},
+		{"main mono", 18, -10, "/main/m/mix/03/level", 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestX32Client(t)
+			if err := client.Matrix.SetSourceLevel(3, tt.source, tt.level); err != nil {
+				t.Fatalf("SetSourceLevel failed: %v", err)
+			}
+			got, err := client.Matrix.SourceLevel(3, tt.source)
+			if err != nil {
+				t.Fatalf("SourceLevel failed: %v", err)
+			}
+			if !near(got, tt.level) {
+				t.Errorf("got level %g dB, want %g dB", got, tt.level)
+			}
+			if raw := mixer.Value(tt.address); len(raw) != 1 || raw[0] != tt.raw {
+				t.Errorf("got %s = %v, want [%v]", tt.address, raw, tt.raw)
+			}
+		})
+	}
+}
+
+func TestMatrixSourceLevelRejectsSource(t *testing.T) {
+	client, _ := newTestX32Client(t)
+	for _, source := range []int{0, 19} {
+		if err := client.Matrix.SetSourceLevel(1, source, 0); err == nil {
+			t.Errorf("SetSourceLevel accepted source %d", source)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package xair
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// linkPartner returns the address of the same parameter on the other strip or bus of a linked pair.
+// It reports false when address does not belong to a strip or bus, or when that strip or bus is not linked.
+func (c *Client) linkPartner(address string) (string, bool, error) {
+	elems := strings.Split(address, "/")
+	if len(elems) < 4 || elems[0] != "" {
+		return "", false, nil
+	}
+	index, err := strconv.Atoi(elems[2])
+	if err != nil {
+		return "", false, nil
+	}
+
+	link := newLink(c)
+	var linked bool
+	var count int
+	switch elems[1] {
+	case "ch":
+		linked, err = link.StripLinked(index)
+		count = c.StripCount()
+	case "bus":
+		linked, err = link.BusLinked(index)
+		count = c.BusCount()
+	default:
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read the link state for %s: %w", address, err)
+	}
+
+	partner := index + 1
+	if index%2 == 0 {
+		partner = index - 1
+	}
+	if !linked || partner < 1 || partner > count {
+		return "", false, nil
+	}
+
+	// keep the zero padding of the original address, e.g. /ch/03 becomes /ch/04
+	elems[2] = fmt.Sprintf("%0*d", len(elems[2]), partner)
+	return strings.Join(elems, "/"), true, nil
+}
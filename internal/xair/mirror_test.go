@@ -0,0 +1,73 @@
+package xair
+
+import (
+	"testing"
+)
+
+func TestLinkMirroring(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     func(c *XAirClient) error
+		partner string // the address the set must be repeated on, empty when it must not be
+		other   string // an address the set must never reach
+	}{
+		{
+			"linked strip, odd side",
+			func(c *XAirClient) error { return c.Strip.SetFader(3, -10) },
+			"/ch/04/mix/fader", "/ch/02/mix/fader",
+		},
+		{
+			"linked strip, even side",
+			func(c *XAirClient) error { return c.Strip.SetFader(4, -10) },
+			"/ch/03/mix/fader", "/ch/05/mix/fader",
+		},
+		{
+			"unlinked strip",
+			func(c *XAirClient) error { return c.Strip.SetFader(5, -10) },
+			"", "/ch/06/mix/fader",
+		},
+		{
+			"linked bus",
+			func(c *XAirClient) error { return c.Bus.SetFader(1, -10) },
+			"/bus/2/mix/fader", "/bus/3/mix/fader",
+		},
+		{
+			"main is never mirrored",
+			func(c *XAirClient) error { return c.Main.SetFader(-10) },
+			"", "/ch/01/mix/fader",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t, WithLinkMirroring())
+			mixer.Set("/config/chlink/3-4", int32(1))
+			mixer.Set("/config/buslink/1-2", int32(1))
+
+			if err := tt.set(client); err != nil {
+				t.Fatalf("set failed: %v", err)
+			}
+			flush(t, &client.Client)
+
+			if tt.partner != "" {
+				if got := mixer.Value(tt.partner); len(got) != 1 || got[0] != float32(0.5) {
+					t.Errorf("got %s %v, want the mirrored [0.5]", tt.partner, got)
+				}
+			}
+			if got := mixer.Value(tt.other); got != nil {
+				t.Errorf("%s written %v, want it untouched", tt.other, got)
+			}
+		})
+	}
+}
+
+func TestNoMirroringWithoutOption(t *testing.T) {
+	client, mixer := newTestClient(t)
+	mixer.Set("/config/chlink/3-4", int32(1))
+	if err := client.Strip.SetFader(3, -10); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	flush(t, &client.Client)
+	if got := mixer.Value("/ch/04/mix/fader"); got != nil {
+		t.Errorf("/ch/04/mix/fader written %v, want it untouched", got)
+	}
+}
@@ -5,3 +5,10 @@ type InfoResponse struct {
 	Name  string
 	Model string
 }
+
+// Change describes a single set sent to the mixer, see WithChangeTracking.
+type Change struct {
+	Address  string
+	Previous []any
+	Value    []any
+}
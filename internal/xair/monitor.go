@@ -0,0 +1,104 @@
+package xair
+
+import (
+	"fmt"
+	"strings"
+)
+
+// monitorSources lists the sources of the monitor (solo) bus in the order the mixer enumerates them.
+// A solo overrides the source while it is active.
+var monitorSources = []string{"off", "lr", "lr+c", "lrpfl", "lrafl", "aux56", "aux78"}
+
+// MonitorMono requests whether the monitor (solo) bus is summed to mono.
+func (c *Client) MonitorMono() (bool, error) {
+	address, ok := c.addressMap["monomon"]
+	if !ok {
+		return false, fmt.Errorf("monitor mono is unsupported on this model")
+	}
+
+	msg, err := c.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for monitor mono value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetMonitorMono sets whether the monitor (solo) bus is summed to mono.
+func (c *Client) SetMonitorMono(mono bool) error {
+	address, ok := c.addressMap["monomon"]
+	if !ok {
+		return fmt.Errorf("monitor mono is unsupported on this model")
+	}
+
+	var value int32
+	if mono {
+		value = 1
+	}
+	return c.SendMessage(address, value)
+}
+
+// MonitorLevel requests the level (in dB) of the monitor (solo) bus.
+func (c *Client) MonitorLevel() (float64, error) {
+	address, ok := c.addressMap["monlevel"]
+	if !ok {
+		return 0, fmt.Errorf("monitor level is unsupported on this model")
+	}
+
+	msg, err := c.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for monitor level value", ErrUnexpectedType)
+	}
+	return mustDbFrom(float64(val)), nil
+}
+
+// SetMonitorLevel sets the level (in dB) of the monitor (solo) bus.
+func (c *Client) SetMonitorLevel(level float64) error {
+	address, ok := c.addressMap["monlevel"]
+	if !ok {
+		return fmt.Errorf("monitor level is unsupported on this model")
+	}
+	return c.SendMessage(address, float32(mustDbInto(level)))
+}
+
+// MonitorSource requests the source of the monitor (solo) bus, one of the names in monitorSources.
+func (c *Client) MonitorSource() (string, error) {
+	address, ok := c.addressMap["monsrc"]
+	if !ok {
+		return "", fmt.Errorf("monitor source is unsupported on this model")
+	}
+
+	msg, err := c.query(address)
+	if err != nil {
+		return "", err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return "", fmt.Errorf("%w for monitor source value", ErrUnexpectedType)
+	}
+	if val < 0 || int(val) >= len(monitorSources) {
+		return "", fmt.Errorf("unknown monitor source value: %d", val)
+	}
+	return monitorSources[val], nil
+}
+
+// SetMonitorSource sets the source of the monitor (solo) bus by name, see MonitorSource.
+func (c *Client) SetMonitorSource(source string) error {
+	address, ok := c.addressMap["monsrc"]
+	if !ok {
+		return fmt.Errorf("monitor source is unsupported on this model")
+	}
+
+	i := indexOf(monitorSources, strings.ToLower(source))
+	if i == -1 {
+		return fmt.Errorf("invalid monitor source %q, expected one of: %s", source, strings.Join(monitorSources, ", "))
+	}
+	return c.SendMessage(address, int32(i))
+}
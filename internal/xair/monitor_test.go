@@ -0,0 +1,41 @@
+package xair
+
+import (
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+func TestMonitorMono(t *testing.T) {
+	tests := []struct {
+		model   string
+		connect func(t *testing.T) (*Client, *xairtest.Mixer)
+	}{
+		{"XR18", func(t *testing.T) (*Client, *xairtest.Mixer) {
+			client, mixer := newTestClient(t)
+			return &client.Client, mixer
+		}},
+		{"X32", func(t *testing.T) (*Client, *xairtest.Mixer) {
+			client, mixer := newTestX32Client(t)
+			return &client.Client, mixer
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			client, mixer := tt.connect(t)
+			if err := client.SetMonitorMono(true); err != nil {
+				t.Fatalf("SetMonitorMono failed: %v", err)
+			}
+			mono, err := client.MonitorMono()
+			if err != nil {
+				t.Fatalf("MonitorMono failed: %v", err)
+			}
+			if !mono {
+				t.Error("monitor mono is off after setting it")
+			}
+			if got := mixer.Value("/config/solo/mono"); len(got) != 1 || got[0] != int32(1) {
+				t.Errorf("got /config/solo/mono %v, want 1", got)
+			}
+		})
+	}
+}
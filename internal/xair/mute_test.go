@@ -0,0 +1,63 @@
+package xair
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/onyx-and-iris/xair-cli/internal/xairtest"
+)
+
+func TestMutePolarity(t *testing.T) {
+	client, mixer := newTestClient(t)
+	x32, x32Mixer := newTestX32Client(t)
+
+	tests := []struct {
+		name    string
+		mixer   *xairtest.Mixer
+		address string
+		get     func() (bool, error)
+		set     func(bool) error
+	}{
+		{
+			"strip", mixer, "/ch/01/mix/on",
+			func() (bool, error) { return client.Strip.Mute(1) },
+			func(muted bool) error { return client.Strip.SetMute(1, muted) },
+		},
+		{
+			"bus", mixer, "/bus/2/mix/on",
+			func() (bool, error) { return client.Bus.Mute(2) },
+			func(muted bool) error { return client.Bus.SetMute(2, muted) },
+		},
+		{"main", mixer, "/lr/mix/on", client.Main.Mute, client.Main.SetMute},
+		{"x32 main mono", x32Mixer, "/main/m/mix/on", x32.MainMono.Mute, x32.MainMono.SetMute},
+		{
+			"x32 matrix", x32Mixer, "/mtx/03/mix/on",
+			func() (bool, error) { return x32.Matrix.Mute(3) },
+			func(muted bool) error { return x32.Matrix.SetMute(3, muted) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, muted := range []bool{true, false} {
+				if err := tt.set(muted); err != nil {
+					t.Fatalf("failed to set mute %t: %v", muted, err)
+				}
+				// The mixer answers in order, so the set has been applied once the read returns
+				got, err := tt.get()
+				if err != nil {
+					t.Fatalf("failed to read mute: %v", err)
+				}
+				if got != muted {
+					t.Errorf("got mute %t after setting %t", got, muted)
+				}
+				want := []any{int32(1)}
+				if muted {
+					want = []any{int32(0)}
+				}
+				if value := tt.mixer.Value(tt.address); !reflect.DeepEqual(value, want) {
+					t.Errorf("mute %t sent %v to %s, want %v", muted, value, tt.address, want)
+				}
+			}
+		})
+	}
+}
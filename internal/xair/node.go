@@ -0,0 +1,166 @@
+package xair
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// nodeFields names the values of the /node containers Node understands, in the order the mixer prints them.
+// Numbered path elements are matched by "*".
+var nodeFields = map[string][]string{
+	"ch/*/config":  {"name", "icon", "color", "source"},
+	"ch/*/mix":     {"on", "fader", "st", "pan", "mono", "mlevel"},
+	"bus/*/config": {"name", "icon", "color"},
+	"bus/*/mix":    {"on", "fader", "st", "pan", "mono", "mlevel"},
+}
+
+// nodeColors are the strip colors in the order of their numeric values.
+var nodeColors = []string{
+	"OFF", "RD", "GN", "YE", "BL", "MG", "CY", "WH",
+	"OFFi", "RDi", "GNi", "YEi", "BLi", "MGi", "CYi", "WHi",
+}
+
+// Node requests a whole container of parameters, e.g. "ch/01/mix", in a single round trip (X32 only).
+// The values are keyed by parameter name and formatted as the mixer prints them, e.g. "ON" or "-12.5".
+func (c *Client) Node(path string) (map[string]string, error) {
+	if c.Kind != kindX32 {
+		return nil, fmt.Errorf("/node queries are unsupported on this model")
+	}
+	path = strings.Trim(path, "/")
+	fields, ok := nodeFields[nodePattern(path)]
+	if !ok {
+		return nil, fmt.Errorf("unknown node %s", path)
+	}
+
+	c.queryMu.Lock()
+	defer c.queryMu.Unlock()
+	c.drain()
+
+	// /node is a request, not a set, so it bypasses SendMessage and its change hooks.
+	if c.tracer != nil {
+		c.tracer.Printf("-> /node [%s]", path)
+	}
+	if err := c.sendToAddress(c.mixerAddr, "/node", path); err != nil {
+		return nil, err
+	}
+
+	for {
+		msg, err := c.ReceiveMessage()
+		if err != nil {
+			return nil, err
+		}
+		if strings.Trim(msg.Address, "/") == "node" && len(msg.Arguments) > 0 {
+			if reply, ok := msg.Arguments[0].(string); ok {
+				node, values := parseNode(reply)
+				if node == "/"+path {
+					return nodeValues(fields, values), nil
+				}
+			}
+		}
+		log.Debugf("Discarding reply for %s while waiting for /node %s", msg.Address, path)
+	}
+}
+
+// nodePattern replaces the numbered elements of a node path with "*", e.g. "ch/01/mix" becomes "ch/*/mix".
+func nodePattern(path string) string {
+	elems := strings.Split(path, "/")
+	for i, elem := range elems {
+		if _, err := strconv.Atoi(elem); err == nil {
+			elems[i] = "*"
+		}
+	}
+	return strings.Join(elems, "/")
+}
+
+// parseNode splits a /node reply such as `/ch/01/config "Kick" 1 YE 1` into its path and values.
+// Quoted values may contain spaces, the quotes are removed.
+func parseNode(reply string) (string, []string) {
+	var tokens []string
+	var token strings.Builder
+	var quoted, inToken bool
+	for _, r := range strings.TrimRight(reply, "\n") {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inToken = true
+		case r == ' ' && !quoted:
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+
+	if len(tokens) == 0 {
+		return "", nil
+	}
+	return tokens[0], tokens[1:]
+}
+
+// nodeValues pairs field names with values, fields missing from the reply are left out.
+func nodeValues(fields, values []string) map[string]string {
+	m := make(map[string]string, len(fields))
+	for i, field := range fields {
+		if i >= len(values) {
+			break
+		}
+		m[field] = values[i]
+	}
+	return m
+}
+
+// configMix holds the config and mix values shared by strip and bus snapshots.
+type configMix struct {
+	name  string
+	color int32
+	mute  bool
+	fader float64
+}
+
+// nodeConfigMix reads the name, color, mute state and fader level of a strip or bus with two /node queries.
+// base is the container address, e.g. "/ch/01". The color is left at 0 when the reply does not include it.
+func (c *Client) nodeConfigMix(base string) (configMix, error) {
+	var cm configMix
+	config, err := c.Node(base + "/config")
+	if err != nil {
+		return cm, err
+	}
+	mix, err := c.Node(base + "/mix")
+	if err != nil {
+		return cm, err
+	}
+
+	cm.name = config["name"]
+	if raw, ok := config["color"]; ok {
+		i := indexOf(nodeColors, raw)
+		if i < 0 {
+			return cm, fmt.Errorf("unexpected color %q in /node reply", raw)
+		}
+		cm.color = int32(i)
+	}
+
+	switch mix["on"] {
+	case "ON":
+	case "OFF":
+		cm.mute = true
+	default:
+		return cm, fmt.Errorf("unexpected on state %q in /node reply", mix["on"])
+	}
+
+	if mix["fader"] == "-oo" {
+		cm.fader = -90
+	} else if cm.fader, err = strconv.ParseFloat(mix["fader"], 64); err != nil {
+		return cm, fmt.Errorf("unexpected fader level %q in /node reply", mix["fader"])
+	}
+	return cm, nil
+}
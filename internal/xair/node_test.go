@@ -0,0 +1,57 @@
+package xair
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseNode(t *testing.T) {
+	tests := []struct {
+		name       string
+		reply      string
+		wantPath   string
+		wantValues []string
+	}{
+		{"quoted name", `/ch/01/config "Kick" 1 YE 1` + "\n", "/ch/01/config", []string{"Kick", "1", "YE", "1"}},
+		{"quoted name with spaces", `/ch/02/config "Lead Vox" 12 RDi 2`, "/ch/02/config", []string{"Lead Vox", "12", "RDi", "2"}},
+		{"empty quoted name", `/bus/01/config "" 1 OFF`, "/bus/01/config", []string{"", "1", "OFF"}},
+		{"on state", "/ch/01/mix ON -12.5 ON +0 OFF -oo", "/ch/01/mix", []string{"ON", "-12.5", "ON", "+0", "OFF", "-oo"}},
+		{"off state at -oo", "/bus/03/mix OFF -oo OFF +0 OFF -oo", "/bus/03/mix", []string{"OFF", "-oo", "OFF", "+0", "OFF", "-oo"}},
+		{"empty reply", "", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, values := parseNode(tt.reply)
+			if path != tt.wantPath || !slices.Equal(values, tt.wantValues) {
+				t.Errorf("parseNode(%q) = %q, %q, want %q, %q", tt.reply, path, values, tt.wantPath, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestNodeConfigMix(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		mix    string
+		want   configMix
+	}{
+		{"strip on", `/ch/01/config "Kick" 1 YE 1`, "/ch/01/mix ON -12.5 ON +0 OFF -oo", configMix{name: "Kick", color: 3, fader: -12.5}},
+		{"strip off at -oo", `/ch/01/config "" 1 OFF 1`, "/ch/01/mix OFF -oo ON +0 OFF -oo", configMix{mute: true, fader: -90}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestX32Client(t)
+			mixer.SetNode("ch/01/config", tt.config)
+			mixer.SetNode("ch/01/mix", tt.mix)
+
+			got, err := client.nodeConfigMix("/ch/01")
+			if err != nil {
+				t.Fatalf("nodeConfigMix failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
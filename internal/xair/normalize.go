@@ -0,0 +1,55 @@
+package xair
+
+import (
+	"fmt"
+	"math"
+)
+
+// Normalization holds the levels a strip was normalized to.
+// Clamped is set when the trim ran out of range, the fader then stops short of the target.
+type Normalization struct {
+	Fader   float64 `json:"fader"`
+	Trim    float64 `json:"trim"`
+	Clamped bool    `json:"clamped"`
+}
+
+// Normalize moves the fader of the specified strip (1-based indexing) to target and makes up the difference
+// with the trim, so the strip stays as loud as before. The trim is kept within its range, see Trim.
+func (s *Strip) Normalize(strip int, target float64) (Normalization, error) {
+	fader, err := s.Fader(strip)
+	if err != nil {
+		return Normalization{}, err
+	}
+	if fader <= -90 {
+		return Normalization{}, fmt.Errorf("strip %d fader is at -∞, there is no level to preserve", strip)
+	}
+	trim, err := s.Trim(strip)
+	if err != nil {
+		return Normalization{}, err
+	}
+
+	n := normalizeLevels(fader, trim, target, s.trimRange())
+	// Lower one stage before raising the other, so the strip never gets louder on the way.
+	if n.Trim < trim {
+		if err := s.SetTrim(strip, n.Trim); err != nil {
+			return n, err
+		}
+		return n, s.SetFader(strip, n.Fader)
+	}
+	if err := s.SetFader(strip, n.Fader); err != nil {
+		return n, err
+	}
+	return n, s.SetTrim(strip, n.Trim)
+}
+
+// normalizeLevels shifts the difference between fader and target onto trim, limiting trim to r.
+// When trim is limited only the part of the difference it could take is moved off the fader.
+func normalizeLevels(fader, trim, target float64, r paramRange) Normalization {
+	n := Normalization{Trim: trim + fader - target}
+	if n.Trim < r.min || n.Trim > r.max {
+		n.Trim = math.Max(r.min, math.Min(r.max, n.Trim))
+		n.Clamped = true
+	}
+	n.Fader = fader - (n.Trim - trim)
+	return n
+}
@@ -0,0 +1,65 @@
+package xair
+
+import (
+	"testing"
+)
+
+func TestNormalizeLevels(t *testing.T) {
+	tests := []struct {
+		name                string
+		fader, trim, target float64
+		want                Normalization
+	}{
+		{"fader down, trim lowered", -10, 0, 0, Normalization{Fader: 0, Trim: -10}},
+		{"fader up, trim raised", 5, 0, 0, Normalization{Fader: 0, Trim: 5}},
+		{"other target", -10, 0, -5, Normalization{Fader: -5, Trim: -5}},
+		{"already at target", 0, 6, 0, Normalization{Fader: 0, Trim: 6}},
+		{"trim clamped at the bottom", -10, -12, 0, Normalization{Fader: -4, Trim: -18, Clamped: true}},
+		{"trim clamped at the top", 6, 15, 0, Normalization{Fader: 3, Trim: 18, Clamped: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeLevels(tt.fader, tt.trim, tt.target, trimRange)
+			if !near(got.Fader, tt.want.Fader) || !near(got.Trim, tt.want.Trim) || got.Clamped != tt.want.Clamped {
+				t.Errorf("normalizeLevels(%g, %g, %g) = %+v, want %+v", tt.fader, tt.trim, tt.target, got, tt.want)
+			}
+			// the strip must stay as loud as before
+			if before, after := tt.fader+tt.trim, got.Fader+got.Trim; !near(after, before) {
+				t.Errorf("fader plus trim went from %g to %g", before, after)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	client, mixer := newTestClient(t)
+	mixer.Set("/ch/02/mix/fader", float32(0.5)) // -10 dB
+	mixer.Set("/headamp/02/gain", float32(0.5)) // 24 dB
+
+	n, err := client.Strip.Normalize(2, 0)
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if !near(n.Fader, 0) || !near(n.Trim, 14) || n.Clamped {
+		t.Errorf("got %+v, want fader 0 dB and trim 14 dB", n)
+	}
+	fader, err := client.Strip.Fader(2)
+	if err != nil {
+		t.Fatalf("failed to read fader: %v", err)
+	}
+	trim, err := client.Strip.Trim(2)
+	if err != nil {
+		t.Fatalf("failed to read trim: %v", err)
+	}
+	if !near(fader, 0) || !near(trim, 14) {
+		t.Errorf("read back fader %g dB and trim %g dB, want 0 dB and 14 dB", fader, trim)
+	}
+}
+
+func TestNormalizeRejectsSilentStrip(t *testing.T) {
+	client, mixer := newTestClient(t)
+	mixer.Set("/ch/02/mix/fader", float32(0))
+	if _, err := client.Strip.Normalize(2, 0); err == nil {
+		t.Error("normalizing a strip at -∞ succeeded, want an error")
+	}
+}
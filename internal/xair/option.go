@@ -1,6 +1,11 @@
 package xair
 
-import "time"
+import (
+	"io"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
 
 type EngineOption func(*engine)
 
@@ -11,6 +16,57 @@ func WithTimeout(timeout time.Duration) EngineOption {
 	}
 }
 
+// WithVerbose logs every outgoing OSC message and every reply to w, with timestamps
+func WithVerbose(w io.Writer) EngineOption {
+	return func(e *engine) {
+		e.tracer = log.NewWithOptions(w, log.Options{
+			ReportTimestamp: true,
+			TimeFormat:      "15:04:05.000",
+		})
+	}
+}
+
+// WithChangeTracking calls hook after every successful set with the value the address held beforehand.
+// Reading the previous value costs an extra round trip per set. Actions, such as loading a snapshot, are not tracked.
+func WithChangeTracking(hook func(Change)) EngineOption {
+	return func(e *engine) {
+		e.changeHook = hook
+	}
+}
+
+// WithSkipUnchanged reads the current value before every set and skips the set when it already matches.
+// Floats match within a small tolerance, skip is called with the address of every skipped set.
+// Actions, such as loading a snapshot, are always sent.
+func WithSkipUnchanged(skip func(address string)) EngineOption {
+	return func(e *engine) {
+		e.skipHook = skip
+	}
+}
+
+// WithRawValues calls hook with the address and the raw arguments of every reply read by a getter,
+// before the getter converts them, e.g. to dB.
+func WithRawValues(hook func(address string, args []any)) EngineOption {
+	return func(e *engine) {
+		e.rawHook = hook
+	}
+}
+
+// WithLinkMirroring repeats every set of a linked strip or bus on the other strip or bus of its pair,
+// including the parameters the mixer does not mirror itself. Reading the link state costs an extra round trip per set.
+func WithLinkMirroring() EngineOption {
+	return func(e *engine) {
+		e.mirror = true
+	}
+}
+
+// WithSettle waits for d after every set, giving mixers that drop messages sent in quick succession time
+// to apply each one. Batches of sets, such as sync, preset apply or snapshot copies, slow down accordingly.
+func WithSettle(d time.Duration) EngineOption {
+	return func(e *engine) {
+		e.settle = d
+	}
+}
+
 type CompOption func(*Comp)
 
 // WithCompAddressFunc allows customization of the OSC address formatting for Comp parameters
@@ -29,6 +85,14 @@ func WithEqAddressFunc(f func(fmtString string, args ...any) string) EqOption {
 	}
 }
 
+// WithGraphicEqAddress sets the base address of the graphic EQ bands, without it GraphicBand and SetGraphicBand
+// report that the model has no graphic EQ.
+func WithGraphicEqAddress(address string) EqOption {
+	return func(e *Eq) {
+		e.geqAddress = address
+	}
+}
+
 type GateOption func(*Gate)
 
 // WithGateAddressFunc allows customization of the OSC address formatting for Gate parameters
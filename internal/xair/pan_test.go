@@ -0,0 +1,95 @@
+package xair
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSetPan(t *testing.T) {
+	tests := []struct {
+		name     string
+		linked   bool
+		setPan   func(c *XAirClient) error
+		address  string
+		wantMode string
+	}{
+		{"unlinked strip", false, func(c *XAirClient) error { return c.Strip.SetPan(1, -50) }, "/ch/01/mix/pan", "strip 1 pan applied as pan"},
+		{"linked strip", true, func(c *XAirClient) error { return c.Strip.SetPan(1, -50) }, "/ch/01/mix/pan", "strip 1 pan applied as balance"},
+		{"unlinked bus", false, func(c *XAirClient) error { return c.Bus.SetPan(2, -50) }, "/bus/2/mix/pan", "bus 2 pan applied as pan"},
+		{"linked bus", true, func(c *XAirClient) error { return c.Bus.SetPan(2, -50) }, "/bus/2/mix/pan", "bus 2 pan applied as balance"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var trace bytes.Buffer
+			client, mixer := newTestClient(t, WithVerbose(&trace))
+			if tt.linked {
+				mixer.Set("/config/chlink/1-2", int32(1))
+				mixer.Set("/config/buslink/1-2", int32(1))
+			}
+
+			if err := tt.setPan(client); err != nil {
+				t.Fatalf("failed to set pan: %v", err)
+			}
+			// the mixer handles messages in order, the reply to a request means the set before it arrived
+			if _, err := client.query(tt.address); err != nil {
+				t.Fatalf("failed to read back pan: %v", err)
+			}
+			if got := mixer.Value(tt.address); len(got) != 1 || got[0] != float32(0.25) {
+				t.Errorf("got %s = %v, want [0.25]", tt.address, got)
+			}
+			if !strings.Contains(trace.String(), tt.wantMode) {
+				t.Errorf("trace does not log %q:\n%s", tt.wantMode, trace.String())
+			}
+		})
+	}
+}
+
+func TestSetPanRejectsOutOfRangeBeforeQuerying(t *testing.T) {
+	var trace bytes.Buffer
+	client, _ := newTestClient(t, WithVerbose(&trace))
+	trace.Reset()
+
+	if err := client.Strip.SetPan(1, 150); err == nil {
+		t.Fatal("got no error for pan 150")
+	}
+	if trace.Len() > 0 {
+		t.Errorf("out of range pan reached the mixer:\n%s", trace.String())
+	}
+}
+
+func TestSendPan(t *testing.T) {
+	client, mixer := newTestClient(t)
+	mixer.Set("/config/buslink/3-4", int32(1))
+
+	// either bus of the pair addresses the pan kept on the odd send
+	if err := client.Strip.SetSendPan(2, 4, 50); err != nil {
+		t.Fatalf("SetSendPan failed: %v", err)
+	}
+	flush(t, &client.Client)
+	if got := mixer.Value("/ch/02/mix/03/pan"); len(got) != 1 || got[0] != float32(0.75) {
+		t.Errorf("got /ch/02/mix/03/pan %v, want [0.75]", got)
+	}
+	pan, err := client.Strip.SendPan(2, 3)
+	if err != nil {
+		t.Fatalf("SendPan failed: %v", err)
+	}
+	if !near(pan, 50) {
+		t.Errorf("got send pan %g, want 50", pan)
+	}
+}
+
+func TestSendPanRejectsMonoBus(t *testing.T) {
+	client, mixer := newTestClient(t)
+	err := client.Strip.SetSendPan(2, 5, 50)
+	if err == nil || !strings.Contains(err.Error(), "mono bus") {
+		t.Errorf("got error %v, want a mono bus error", err)
+	}
+	if _, err := client.Strip.SendPan(2, 5); err == nil {
+		t.Error("SendPan of a mono bus succeeded, want an error")
+	}
+	flush(t, &client.Client)
+	if got := mixer.Value("/ch/02/mix/05/pan"); got != nil {
+		t.Errorf("/ch/02/mix/05/pan written %v, want it untouched", got)
+	}
+}
@@ -52,7 +52,8 @@ func (p *xairParser) validateOSCData(data []byte) error {
 	if len(data) < 4 {
 		return fmt.Errorf("data too short for OSC message")
 	}
-	if data[0] != '/' {
+	// X32 replies to /node queries are addressed to "node", without the leading slash.
+	if data[0] != '/' && !bytes.HasPrefix(data, []byte("node\x00")) {
 		return fmt.Errorf("invalid OSC message: does not start with '/'")
 	}
 	return nil
@@ -0,0 +1,91 @@
+package xair
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// paramRange is the valid range of a continuous parameter, in the unit its getter returns and its setter takes.
+type paramRange struct {
+	min, max float64
+	unit     string
+}
+
+// Valid ranges of the continuous parameters, setters reject values outside them rather than letting the mixer clamp.
+var (
+	panRange = paramRange{-100, 100, ""}
+
+	headampGainRange = paramRange{-12, 60, "dB"}
+	trimRange        = paramRange{-18, 18, "dB"}
+	dimLevelRange    = paramRange{-40, 0, "dB"}
+
+	eqGainRange = paramRange{-15, 15, "dB"}
+	eqFreqRange = paramRange{20, 20000, "Hz"}
+	eqQRange    = paramRange{0.3, 10, ""}
+
+	gateThresholdRange  = paramRange{-80, 0, "dB"}
+	gateRangeRange      = paramRange{3, 60, "dB"}
+	gateAttackRange     = paramRange{0, 120, "ms"}
+	gateHoldRange       = paramRange{0.02, 2000, "ms"}
+	gateReleaseRange    = paramRange{5, 4000, "ms"}
+	gateFilterFreqRange = paramRange{20, 20000, "Hz"}
+
+	compThresholdRange  = paramRange{-60, 0, "dB"}
+	compAttackRange     = paramRange{0, 120, "ms"}
+	compHoldRange       = paramRange{0.02, 2000, "ms"}
+	compReleaseRange    = paramRange{4, 4000, "ms"}
+	compMakeupRange     = paramRange{0, 24, "dB"}
+	compMixRange        = paramRange{0, 100, "%"}
+	compKneeRange       = paramRange{0, 5, ""}
+	compFilterFreqRange = paramRange{20, 20000, "Hz"}
+)
+
+// Valid values of the discrete parameters, in the order of their numeric values on the mixer.
+var (
+	eqModes    = []string{"peq", "geq", "teq"}
+	eqTypes    = []string{"lcut", "lshv", "peq", "veq", "hshv", "hcut"}
+	gateModes  = []string{"exp2", "exp3", "exp4", "gate", "duck"}
+	compModes  = []string{"comp", "exp"}
+	compRatios = []float32{1.1, 1.3, 1.5, 2.0, 2.5, 3.0, 4.0, 5.0, 7.0, 10, 20, 100}
+	sendTaps   = []string{"in", "preeq", "posteq", "pre", "post", "grp"}
+)
+
+// rangeTolerance absorbs the rounding in values read back through logGet, so they can be written again as they are.
+const rangeTolerance = 1e-6
+
+// check returns an error naming the parameter when value lies outside the range or is not a finite number.
+func (r paramRange) check(name string, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("%s %g is not a finite number", name, value)
+	}
+	if value < r.min-rangeTolerance || value > r.max+rangeTolerance {
+		return fmt.Errorf("%s %s is out of range, must be between %s and %s", name, r.format(value), r.format(r.min), r.format(r.max))
+	}
+	return nil
+}
+
+// format prints a value of the range with its unit.
+func (r paramRange) format(value float64) string {
+	switch r.unit {
+	case "":
+		return fmt.Sprintf("%g", value)
+	case "%":
+		return fmt.Sprintf("%g%%", value)
+	default:
+		return fmt.Sprintf("%g %s", value, r.unit)
+	}
+}
+
+// choiceIndex returns the position of value in choices, or an error naming the parameter and listing the choices.
+func choiceIndex[T comparable](name string, value T, choices []T) (int, error) {
+	i := indexOf(choices, value)
+	if i < 0 {
+		valid := make([]string, len(choices))
+		for j, choice := range choices {
+			valid[j] = fmt.Sprint(choice)
+		}
+		return 0, fmt.Errorf("invalid %s %v, must be one of: %s", name, value, strings.Join(valid, ", "))
+	}
+	return i, nil
+}
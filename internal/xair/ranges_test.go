@@ -0,0 +1,81 @@
+package xair
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParamRangeCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       paramRange
+		value   float64
+		wantErr string
+	}{
+		{"minimum", gateThresholdRange, -80, ""},
+		{"maximum", gateThresholdRange, 0, ""},
+		{"inside", eqQRange, 2, ""},
+		{"rounding below the minimum", eqQRange, 0.3 - rangeTolerance/2, ""},
+		{"rounding above the maximum", panRange, 100 + rangeTolerance/2, ""},
+		{"below the minimum", gateThresholdRange, -80.1, "threshold -80.1 dB is out of range, must be between -80 dB and 0 dB"},
+		{"above the maximum", compMixRange, 101, "threshold 101% is out of range, must be between 0% and 100%"},
+		{"unitless", panRange, -101, "threshold -101 is out of range, must be between -100 and 100"},
+		{"NaN", compThresholdRange, math.NaN(), "threshold NaN is not a finite number"},
+		{"positive infinity", compThresholdRange, math.Inf(1), "threshold +Inf is not a finite number"},
+		{"negative infinity", eqFreqRange, math.Inf(-1), "threshold -Inf is not a finite number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.check("threshold", tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("check(%g) = %v, want no error", tt.value, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("check(%g) = %v, want %q", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChoiceIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"first", "lcut", 0, false},
+		{"last", "hcut", 5, false},
+		{"middle", "veq", 3, false},
+		{"unknown", "notch", 0, true},
+		{"case sensitive", "LCUT", 0, true},
+		{"empty", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := choiceIndex("EQ type", tt.value, eqTypes)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "must be one of: lcut, lshv, peq, veq, hshv, hcut") {
+					t.Errorf("choiceIndex(%q) = %d, %v, want an error listing the choices", tt.value, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("choiceIndex(%q) = %d, %v, want %d", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestChoiceIndexFloat(t *testing.T) {
+	if got, err := choiceIndex("ratio", float32(4), compRatios); err != nil || got != 6 {
+		t.Errorf("choiceIndex(4) = %d, %v, want 6", got, err)
+	}
+	if _, err := choiceIndex("ratio", float32(3.5), compRatios); err == nil {
+		t.Error("choiceIndex(3.5) gave no error")
+	}
+}
@@ -1,6 +1,11 @@
 package xair
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
 
 type Strip struct {
 	client      *Client
@@ -8,6 +13,7 @@ type Strip struct {
 	Gate        *Gate
 	Eq          *Eq
 	Comp        *Comp
+	link        *Link
 }
 
 // newStrip creates a new Strip instance
@@ -18,24 +24,20 @@ func newStrip(c *Client) *Strip {
 		Gate:        newGate(c, c.addressMap["strip"]),
 		Eq:          newEq(c, c.addressMap["strip"]),
 		Comp:        newComp(c, c.addressMap["strip"]),
+		link:        newLink(c),
 	}
 }
 
 // Mute gets the mute status of the specified strip (1-based indexing).
 func (s *Strip) Mute(index int) (bool, error) {
 	address := fmt.Sprintf(s.baseAddress, index) + "/mix/on"
-	err := s.client.SendMessage(address)
-	if err != nil {
-		return false, err
-	}
-
-	msg, err := s.client.ReceiveMessage()
+	msg, err := s.client.query(address)
 	if err != nil {
 		return false, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return false, fmt.Errorf("unexpected argument type for strip mute value")
+		return false, fmt.Errorf("%w for strip mute value", ErrUnexpectedType)
 	}
 	return val == 0, nil
 }
@@ -53,18 +55,13 @@ func (s *Strip) SetMute(strip int, muted bool) error {
 // Fader gets the fader level of the specified strip (1-based indexing).
 func (s *Strip) Fader(strip int) (float64, error) {
 	address := fmt.Sprintf(s.baseAddress, strip) + "/mix/fader"
-	err := s.client.SendMessage(address)
-	if err != nil {
-		return 0, err
-	}
-
-	msg, err := s.client.ReceiveMessage()
+	msg, err := s.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for fader value")
+		return 0, fmt.Errorf("%w for fader value", ErrUnexpectedType)
 	}
 
 	return mustDbFrom(float64(val)), nil
@@ -76,27 +73,60 @@ func (s *Strip) SetFader(strip int, level float64) error {
 	return s.client.SendMessage(address, float32(mustDbInto(level)))
 }
 
-// Name requests the name for a specific strip
-func (s *Strip) Name(strip int) (string, error) {
-	address := fmt.Sprintf(s.baseAddress, strip) + "/config/name"
-	err := s.client.SendMessage(address)
+// Trim requests the input gain of the specified strip (1-based indexing).
+// On X32 mixers this is the digital trim of the strip. X-Air strips have no trim, so it is the gain of the
+// headamp with the same number, which feeds the strip unless the input routing was changed.
+func (s *Strip) Trim(strip int) (float64, error) {
+	address := fmt.Sprintf(s.client.addressMap["trim"], strip)
+	msg, err := s.client.query(address)
 	if err != nil {
-		return "", fmt.Errorf("failed to send strip name request: %v", err)
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for strip trim value", ErrUnexpectedType)
+	}
+	r := s.trimRange()
+	return linGet(r.min, r.max, float64(val)), nil
+}
+
+// SetTrim sets the input gain of the specified strip (1-based indexing), see Trim.
+func (s *Strip) SetTrim(strip int, level float64) error {
+	address := fmt.Sprintf(s.client.addressMap["trim"], strip)
+	r := s.trimRange()
+	if err := r.check("strip trim", level); err != nil {
+		return err
+	}
+	return s.client.SendMessage(address, float32(linSet(r.min, r.max, level)))
+}
+
+// trimRange returns the range of the input gain that Trim reads on the connected mixer.
+func (s *Strip) trimRange() paramRange {
+	if s.client.Kind == kindX32 {
+		return trimRange
 	}
+	return headampGainRange
+}
 
-	msg, err := s.client.ReceiveMessage()
+// Name requests the name for a specific strip
+func (s *Strip) Name(strip int) (string, error) {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/config/name"
+	msg, err := s.client.query(address)
 	if err != nil {
 		return "", err
 	}
 	val, ok := msg.Arguments[0].(string)
 	if !ok {
-		return "", fmt.Errorf("unexpected argument type for strip name value")
+		return "", fmt.Errorf("%w for strip name value", ErrUnexpectedType)
 	}
 	return val, nil
 }
 
-// SetName sets the name for a specific strip
+// SetName sets the name for a specific strip, names longer than MaxNameLength are rejected
 func (s *Strip) SetName(strip int, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
 	address := fmt.Sprintf(s.baseAddress, strip) + "/config/name"
 	return s.client.SendMessage(address, name)
 }
@@ -104,18 +134,13 @@ func (s *Strip) SetName(strip int, name string) error {
 // Color requests the color for a specific strip
 func (s *Strip) Color(strip int) (int32, error) {
 	address := fmt.Sprintf(s.baseAddress, strip) + "/config/color"
-	err := s.client.SendMessage(address)
-	if err != nil {
-		return 0, fmt.Errorf("failed to send strip color request: %v", err)
-	}
-
-	msg, err := s.client.ReceiveMessage()
+	msg, err := s.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(int32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for strip color value")
+		return 0, fmt.Errorf("%w for strip color value", ErrUnexpectedType)
 	}
 	return val, nil
 }
@@ -129,18 +154,13 @@ func (s *Strip) SetColor(strip int, color int32) error {
 // Sends requests the sends level for a mixbus.
 func (s *Strip) SendLevel(strip int, bus int) (float64, error) {
 	address := fmt.Sprintf(s.baseAddress, strip) + fmt.Sprintf("/mix/%02d/level", bus)
-	err := s.client.SendMessage(address)
-	if err != nil {
-		return 0, fmt.Errorf("failed to send strip send level request: %v", err)
-	}
-
-	msg, err := s.client.ReceiveMessage()
+	msg, err := s.client.query(address)
 	if err != nil {
 		return 0, err
 	}
 	val, ok := msg.Arguments[0].(float32)
 	if !ok {
-		return 0, fmt.Errorf("unexpected argument type for strip send level value")
+		return 0, fmt.Errorf("%w for strip send level value", ErrUnexpectedType)
 	}
 	return mustDbFrom(float64(val)), nil
 }
@@ -150,3 +170,467 @@ func (s *Strip) SetSendLevel(strip int, bus int, level float64) error {
 	address := fmt.Sprintf(s.baseAddress, strip) + fmt.Sprintf("/mix/%02d/level", bus)
 	return s.client.SendMessage(address, float32(mustDbInto(level)))
 }
+
+// SendOn requests the on/off status of the send to a mixbus (X32 only).
+func (s *Strip) SendOn(strip int, bus int) (bool, error) {
+	address := fmt.Sprintf(s.baseAddress, strip) + fmt.Sprintf("/mix/%02d/on", bus)
+	msg, err := s.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for strip send on value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetSendOn sets the on/off status of the send to a mixbus (X32 only).
+func (s *Strip) SetSendOn(strip int, bus int, on bool) error {
+	address := fmt.Sprintf(s.baseAddress, strip) + fmt.Sprintf("/mix/%02d/on", bus)
+	var value int32
+	if on {
+		value = 1
+	}
+	return s.client.SendMessage(address, value)
+}
+
+// sendPanAddress returns the address of the pan of the send to a stereo bus pair, which the mixer keeps on the odd bus.
+// Mono buses have no send pan.
+func (s *Strip) sendPanAddress(strip int, bus int) (string, error) {
+	linked, err := s.link.BusLinked(bus)
+	if err != nil {
+		return "", err
+	}
+	if !linked {
+		return "", fmt.Errorf("bus %d is a mono bus, only sends to linked stereo buses have a pan", bus)
+	}
+	first := bus - (1 - bus%2)
+	return fmt.Sprintf(s.baseAddress, strip) + fmt.Sprintf("/mix/%02d/pan", first), nil
+}
+
+// SendPan requests the pan of the send to a stereo bus pair (-100 to 100).
+func (s *Strip) SendPan(strip int, bus int) (float64, error) {
+	address, err := s.sendPanAddress(strip, bus)
+	if err != nil {
+		return 0, err
+	}
+	msg, err := s.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for strip send pan value", ErrUnexpectedType)
+	}
+	return linGet(panRange.min, panRange.max, float64(val)), nil
+}
+
+// SetSendPan sets the pan of the send to a stereo bus pair (-100 to 100).
+func (s *Strip) SetSendPan(strip int, bus int, pan float64) error {
+	if err := panRange.check("send pan", pan); err != nil {
+		return err
+	}
+	address, err := s.sendPanAddress(strip, bus)
+	if err != nil {
+		return err
+	}
+	return s.client.SendMessage(address, float32(linSet(panRange.min, panRange.max, pan)))
+}
+
+// SendTap requests where the send to a mixbus taps the strip signal: in, preeq, posteq, pre, post or grp.
+// Sends tapped at post or grp follow the strip fader. X32 mixers share the tap between an odd/even pair of sends
+// and keep it on the odd one.
+func (s *Strip) SendTap(strip int, bus int) (string, error) {
+	if s.client.Kind == kindX32 {
+		bus -= 1 - bus%2
+	}
+	address := fmt.Sprintf(s.client.addressMap["sendtap"], strip, bus)
+	msg, err := s.client.query(address)
+	if err != nil {
+		return "", err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok || val < 0 || int(val) >= len(sendTaps) {
+		return "", fmt.Errorf("%w for strip send tap value", ErrUnexpectedType)
+	}
+	return sendTaps[val], nil
+}
+
+// Send holds the level and on/off status of a strip's send to a mixbus.
+// On is nil on XAir mixers, which have no per-send switch.
+type Send struct {
+	Bus   int     `json:"bus"`
+	Level float64 `json:"level"`
+	On    *bool   `json:"on,omitempty"`
+}
+
+// AllSends requests the sends to every mixbus for the specified strip (1-based indexing).
+func (s *Strip) AllSends(strip int) ([]Send, error) {
+	sends := make([]Send, s.client.BusCount())
+	for i := range sends {
+		send := &sends[i]
+		send.Bus = i + 1
+
+		var err error
+		if send.Level, err = s.SendLevel(strip, send.Bus); err != nil {
+			return nil, err
+		}
+		if s.client.Kind == kindX32 {
+			on, err := s.SendOn(strip, send.Bus)
+			if err != nil {
+				return nil, err
+			}
+			send.On = &on
+		}
+	}
+	return sends, nil
+}
+
+// InputSource requests the input source for a specific strip.
+// The value is the mixer's raw source number, it is not part of a StripSnapshot.
+func (s *Strip) InputSource(strip int) (int32, error) {
+	address := fmt.Sprintf(s.client.addressMap["insrc"], strip)
+	msg, err := s.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return 0, fmt.Errorf("%w for strip input source value", ErrUnexpectedType)
+	}
+	return val, nil
+}
+
+// SetInputSource sets the input source for a specific strip.
+func (s *Strip) SetInputSource(strip int, source int32) error {
+	address := fmt.Sprintf(s.client.addressMap["insrc"], strip)
+	return s.client.SendMessage(address, source)
+}
+
+// Source requests the input source for a specific strip as a readable name such as "IN 01" or "USB 01".
+func (s *Strip) Source(strip int) (string, error) {
+	val, err := s.InputSource(strip)
+	if err != nil {
+		return "", err
+	}
+	return s.client.inputSourceName(val)
+}
+
+// SetSource sets the input source for a specific strip by name.
+func (s *Strip) SetSource(strip int, source string) error {
+	val, err := s.client.inputSourceValue(source)
+	if err != nil {
+		return err
+	}
+	return s.SetInputSource(strip, val)
+}
+
+// StripSnapshot holds the settings of a strip, excluding its input routing.
+type StripSnapshot struct {
+	Name  string       `json:"name"`
+	Color int32        `json:"color"`
+	Mute  bool         `json:"mute"`
+	Fader float64      `json:"fader"`
+	Sends []float64    `json:"sends"`
+	Gate  GateSnapshot `json:"gate"`
+	Eq    EqSnapshot   `json:"eq"`
+	Comp  CompSnapshot `json:"comp"`
+}
+
+// Snapshot reads all settings of the specified strip (1-based indexing).
+func (s *Strip) Snapshot(strip int) (StripSnapshot, error) {
+	var snap StripSnapshot
+	var err error
+	if !s.configMixFromNode(strip, &snap) {
+		if snap.Name, err = s.Name(strip); err != nil {
+			return snap, err
+		}
+		if snap.Color, err = s.Color(strip); err != nil {
+			return snap, err
+		}
+		if snap.Mute, err = s.Mute(strip); err != nil {
+			return snap, err
+		}
+		if snap.Fader, err = s.Fader(strip); err != nil {
+			return snap, err
+		}
+	}
+
+	snap.Sends = make([]float64, s.client.BusCount())
+	for i := range snap.Sends {
+		if snap.Sends[i], err = s.SendLevel(strip, i+1); err != nil {
+			return snap, err
+		}
+	}
+
+	if snap.Gate, err = s.Gate.Snapshot(strip); err != nil {
+		return snap, err
+	}
+	if snap.Eq, err = s.Eq.Snapshot(strip, s.client.EqBandCount("strip")); err != nil {
+		return snap, err
+	}
+	if snap.Comp, err = s.Comp.Snapshot(strip); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
+
+// configMixFromNode fills the name, color, mute state and fader level of snap with /node queries where the mixer supports them.
+// It reports false when the values must be read one parameter at a time instead.
+func (s *Strip) configMixFromNode(strip int, snap *StripSnapshot) bool {
+	if s.client.Kind != kindX32 {
+		return false
+	}
+	base := fmt.Sprintf(s.baseAddress, strip)
+	cm, err := s.client.nodeConfigMix(base)
+	if err != nil {
+		log.Debugf("/node query for %s failed, reading single parameters: %v", base, err)
+		return false
+	}
+	snap.Name, snap.Color, snap.Mute, snap.Fader = cm.name, cm.color, cm.mute, cm.fader
+	return true
+}
+
+// StripSections lists the sections of a StripSnapshot in the order ApplySections writes them.
+// "config" is the name and color, "mix" is the fader and mute state.
+var StripSections = []string{"config", "sends", "gate", "eq", "comp", "mix"}
+
+// ApplySnapshot writes all settings from snap to the specified strip (1-based indexing).
+func (s *Strip) ApplySnapshot(strip int, snap StripSnapshot) error {
+	return s.ApplySections(strip, snap, StripSections...)
+}
+
+// ApplySections writes the given sections of snap to the specified strip (1-based indexing), leaving the others untouched.
+func (s *Strip) ApplySections(strip int, snap StripSnapshot, sections ...string) error {
+	for _, section := range sections {
+		if indexOf(StripSections, section) < 0 {
+			return fmt.Errorf("unknown strip section %q, must be one of: %s", section, strings.Join(StripSections, ", "))
+		}
+	}
+
+	for _, section := range StripSections {
+		if indexOf(sections, section) < 0 {
+			continue
+		}
+
+		var err error
+		switch section {
+		case "config":
+			if err = s.SetName(strip, snap.Name); err == nil {
+				err = s.SetColor(strip, snap.Color)
+			}
+		case "sends":
+			for i, level := range snap.Sends {
+				if err = s.SetSendLevel(strip, i+1, level); err != nil {
+					break
+				}
+			}
+		case "gate":
+			err = s.Gate.ApplySnapshot(strip, snap.Gate)
+		case "eq":
+			err = s.Eq.ApplySnapshot(strip, snap.Eq)
+		case "comp":
+			err = s.Comp.ApplySnapshot(strip, snap.Comp)
+		case "mix":
+			if err = s.SetFader(strip, snap.Fader); err == nil {
+				err = s.SetMute(strip, snap.Mute)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MainAssign requests whether the specified strip is assigned to the main L/R bus.
+// Unlike the mute state it leaves the sends to the mixbuses alone.
+func (s *Strip) MainAssign(strip int) (bool, error) {
+	address := fmt.Sprintf(s.client.addressMap["mainasgn"], strip)
+	msg, err := s.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for strip main assign value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetMainAssign sets whether the specified strip is assigned to the main L/R bus.
+func (s *Strip) SetMainAssign(strip int, on bool) error {
+	address := fmt.Sprintf(s.client.addressMap["mainasgn"], strip)
+	var value int32
+	if on {
+		value = 1
+	}
+	return s.client.SendMessage(address, value)
+}
+
+// MonoSend requests whether the specified strip is sent to the mono/center bus (X32 only).
+func (s *Strip) MonoSend(strip int) (bool, error) {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/mix/mono"
+	msg, err := s.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for strip mono send value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetMonoSend sets whether the specified strip is sent to the mono/center bus (X32 only).
+func (s *Strip) SetMonoSend(strip int, on bool) error {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/mix/mono"
+	var value int32
+	if on {
+		value = 1
+	}
+	return s.client.SendMessage(address, value)
+}
+
+// MonoLevel requests the mono/center bus send level of the specified strip (X32 only).
+func (s *Strip) MonoLevel(strip int) (float64, error) {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/mix/mlevel"
+	msg, err := s.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for strip mono level value", ErrUnexpectedType)
+	}
+	return mustDbFrom(float64(val)), nil
+}
+
+// SetMonoLevel sets the mono/center bus send level of the specified strip (X32 only).
+func (s *Strip) SetMonoLevel(strip int, level float64) error {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/mix/mlevel"
+	return s.client.SendMessage(address, float32(mustDbInto(level)))
+}
+
+// Pan requests the pan position of the specified strip (-100 to 100).
+func (s *Strip) Pan(strip int) (float64, error) {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/mix/pan"
+	msg, err := s.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(float32)
+	if !ok {
+		return 0, fmt.Errorf("%w for strip pan value", ErrUnexpectedType)
+	}
+	return linGet(panRange.min, panRange.max, float64(val)), nil
+}
+
+// PanMode reports how the pan parameter of the specified strip behaves: "balance" when it is part of a linked pair, otherwise "pan".
+func (s *Strip) PanMode(strip int) (string, error) {
+	linked, err := s.link.StripLinked(strip)
+	if err != nil {
+		return "", err
+	}
+	if linked {
+		return "balance", nil
+	}
+	return "pan", nil
+}
+
+// SetPan sets the pan position of the specified strip (-100 to 100).
+// For a linked pair the mixer treats the same parameter as the balance of the pair, in verbose mode the mode in use is logged.
+func (s *Strip) SetPan(strip int, pan float64) error {
+	if err := panRange.check("pan", pan); err != nil {
+		return err
+	}
+	if s.client.tracer != nil {
+		mode, err := s.PanMode(strip)
+		if err != nil {
+			return err
+		}
+		s.client.tracer.Printf("strip %d pan applied as %s", strip, mode)
+	}
+
+	address := fmt.Sprintf(s.baseAddress, strip) + "/mix/pan"
+	return s.client.SendMessage(address, float32(linSet(panRange.min, panRange.max, pan)))
+}
+
+// InsertOn requests whether the insert of the specified strip is engaged.
+func (s *Strip) InsertOn(strip int) (bool, error) {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/insert/on"
+	msg, err := s.client.query(address)
+	if err != nil {
+		return false, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return false, fmt.Errorf("%w for strip insert on value", ErrUnexpectedType)
+	}
+	return val != 0, nil
+}
+
+// SetInsertOn sets whether the insert of the specified strip is engaged.
+func (s *Strip) SetInsertOn(strip int, on bool) error {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/insert/on"
+	var value int32
+	if on {
+		value = 1
+	}
+	return s.client.SendMessage(address, value)
+}
+
+// InsertPos requests the position of the insert of the specified strip, either "pre" or "post" (X32 only).
+func (s *Strip) InsertPos(strip int) (string, error) {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/insert/pos"
+	possiblePositions := []string{"pre", "post"}
+
+	msg, err := s.client.query(address)
+	if err != nil {
+		return "", err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return "", fmt.Errorf("%w for strip insert position value", ErrUnexpectedType)
+	}
+	if val < 0 || int(val) >= len(possiblePositions) {
+		return "", fmt.Errorf("unknown strip insert position value: %d", val)
+	}
+	return possiblePositions[val], nil
+}
+
+// SetInsertPos sets the position of the insert of the specified strip, either "pre" or "post" (X32 only).
+func (s *Strip) SetInsertPos(strip int, pos string) error {
+	address := fmt.Sprintf(s.baseAddress, strip) + "/insert/pos"
+	possiblePositions := []string{"pre", "post"}
+
+	i := indexOf(possiblePositions, pos)
+	if i < 0 {
+		return fmt.Errorf("invalid insert position %q, must be one of: pre, post", pos)
+	}
+	return s.client.SendMessage(address, int32(i))
+}
+
+// InsertSlot requests the insert slot selected on the specified strip, 0 means no slot is selected.
+func (s *Strip) InsertSlot(strip int) (int, error) {
+	address := fmt.Sprintf(s.client.addressMap["insslot"], strip)
+	msg, err := s.client.query(address)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := msg.Arguments[0].(int32)
+	if !ok {
+		return 0, fmt.Errorf("%w for strip insert slot value", ErrUnexpectedType)
+	}
+	return int(val), nil
+}
+
+// SetInsertSlot selects the insert slot of the specified strip, 0 deselects it.
+func (s *Strip) SetInsertSlot(strip int, slot int) error {
+	if count := s.client.Kind.insertSlotCount(); slot < 0 || slot > count {
+		return fmt.Errorf("insert slot must be between 0 and %d", count)
+	}
+	address := fmt.Sprintf(s.client.addressMap["insslot"], strip)
+	return s.client.SendMessage(address, int32(slot))
+}
@@ -0,0 +1,43 @@
+package xair
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetNameLength(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"empty clears the name", "", true},
+		{"maximum length", strings.Repeat("a", MaxNameLength), true},
+		{"maximum length in runes", strings.Repeat("é", MaxNameLength), true},
+		{"one over the maximum", strings.Repeat("a", MaxNameLength+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mixer := newTestClient(t)
+			for address, set := range map[string]func() error{
+				"/ch/01/config/name": func() error { return client.Strip.SetName(1, tt.value) },
+				"/bus/1/config/name": func() error { return client.Bus.SetName(1, tt.value) },
+			} {
+				mixer.Set(address, "Old")
+				err := set()
+				if (err == nil) != tt.valid {
+					t.Fatalf("setting %s to %q returned %v, want valid %t", address, tt.value, err, tt.valid)
+				}
+				flush(t, &client.Client)
+
+				want := "Old"
+				if tt.valid {
+					want = tt.value
+				}
+				if got := mixer.Value(address); len(got) != 1 || got[0] != want {
+					t.Errorf("got %s = %v, want %q", address, got, want)
+				}
+			}
+		})
+	}
+}
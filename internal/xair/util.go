@@ -1,6 +1,10 @@
 package xair
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
 
 func linGet(min float64, max float64, value float64) float64 {
 	return min + (max-min)*value
@@ -52,6 +56,16 @@ func mustDbFrom(level float64) float64 {
 	}
 }
 
+// LevelFromPercent converts a position on the fader travel, from 0 to 100 percent, to a level in dB.
+func LevelFromPercent(percent float64) float64 {
+	return mustDbFrom(percent / 100)
+}
+
+// PercentFromLevel converts a level in dB to its position on the fader travel, from 0 to 100 percent.
+func PercentFromLevel(level float64) float64 {
+	return mustDbInto(level) * 100
+}
+
 func toFixed(num float64, precision int) float64 {
 	output := math.Pow(10, float64(precision))
 	return float64(math.Round(num*output)) / output
@@ -66,3 +80,36 @@ func indexOf[T comparable](slice []T, elem T) int {
 	}
 	return -1
 }
+
+// argsEqual reports whether two OSC argument lists hold the same values.
+// Floats are compared within a tolerance finer than any parameter step, so values that round trip through the mixer still match.
+func argsEqual(a, b []any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		af, aok := a[i].(float32)
+		bf, bok := b[i].(float32)
+		if aok && bok {
+			if math.Abs(float64(af-bf)) > 1e-4 {
+				return false
+			}
+			continue
+		}
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxNameLength is the longest strip or bus name the mixers store, longer names are truncated by the hardware.
+const MaxNameLength = 12
+
+// validateName checks that name fits in a strip or bus name.
+func validateName(name string) error {
+	if n := utf8.RuneCountInString(name); n > MaxNameLength {
+		return fmt.Errorf("name %q is %d characters long, the maximum is %d", name, n, MaxNameLength)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package xair
+
+import (
+	"testing"
+)
+
+func TestLevelPercentConversion(t *testing.T) {
+	tests := []struct {
+		percent float64
+		level   float64
+	}{
+		{100, 10},
+		{75, 0},
+		{50, -10},
+		{25, -30},
+	}
+	for _, tt := range tests {
+		if got := LevelFromPercent(tt.percent); !near(got, tt.level) {
+			t.Errorf("LevelFromPercent(%g) = %g, want %g", tt.percent, got, tt.level)
+		}
+		if got := PercentFromLevel(tt.level); !near(got, tt.percent) {
+			t.Errorf("PercentFromLevel(%g) = %g, want %g", tt.level, got, tt.percent)
+		}
+	}
+	if got := LevelFromPercent(0); got > -89 {
+		t.Errorf("LevelFromPercent(0) = %g, want -inf", got)
+	}
+}
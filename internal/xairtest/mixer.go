@@ -0,0 +1,224 @@
+// Package xairtest provides a fake mixer on a loopback UDP socket, for testing the client without hardware.
+package xairtest
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hypebeast/go-osc/osc"
+)
+
+// Mixer is a fake mixer that answers requests with the last value set for an address.
+// Addresses that were never set are answered with a default of the type the client expects,
+// see defaultValue. /xinfo is answered with the model the mixer was created with.
+// Like a real mixer it answers requests in the order they arrive, a delayed reply holds back the ones after it.
+type Mixer struct {
+	conn    *net.UDPConn
+	model   string
+	replies chan reply
+	done    chan struct{}
+
+	mu     sync.Mutex
+	values map[string][]any
+	delays map[string]time.Duration
+	empty  map[string]bool
+	nodes  map[string]string
+	sets   map[string]int
+}
+
+// NewMixer starts a fake mixer reporting model, it is stopped when the test ends.
+func NewMixer(t testing.TB, model string) *Mixer {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("failed to start fake mixer: %v", err)
+	}
+	m := &Mixer{
+		conn:    conn,
+		model:   model,
+		replies: make(chan reply, 256),
+		done:    make(chan struct{}),
+		values:  map[string][]any{},
+		delays:  map[string]time.Duration{},
+		empty:   map[string]bool{},
+		nodes:   map[string]string{},
+		sets:    map[string]int{},
+	}
+	t.Cleanup(func() {
+		close(m.done)
+		conn.Close()
+	})
+	go m.serve()
+	go m.send()
+	return m
+}
+
+// reply is an encoded reply waiting to be sent to the client at due.
+type reply struct {
+	data []byte
+	to   *net.UDPAddr
+	due  time.Time
+}
+
+// Host returns the host the mixer listens on.
+func (m *Mixer) Host() string {
+	return "127.0.0.1"
+}
+
+// Port returns the port the mixer listens on.
+func (m *Mixer) Port() int {
+	return m.conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+// Set stores args as the value of address, as if the client had set it.
+func (m *Mixer) Set(address string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[address] = args
+}
+
+// Value returns the value last set for address, or nil when it was never set.
+func (m *Mixer) Value(address string) []any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[address]
+}
+
+// Sets returns the number of sets of address the client sent.
+func (m *Mixer) Sets(address string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sets[address]
+}
+
+// Delay holds back the replies to address for d, so they arrive after the client gave up on them.
+func (m *Mixer) Delay(address string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.delays[address] = d
+}
+
+// Empty answers address without arguments, as a mixer does for addresses it does not support.
+func (m *Mixer) Empty(address string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.empty[address] = true
+}
+
+// SetNode stores the reply to a /node query for path, e.g. "ch/01/mix", as the text the mixer prints,
+// e.g. `/ch/01/mix ON -12.0 ON 0 OFF -oo`. /node queries for other paths are not answered.
+func (m *Mixer) SetNode(path, reply string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodes[path] = reply
+}
+
+// Send sends an unsolicited message to the client on port of the loopback interface, as a mixer does for the
+// clients subscribed with /xremote.
+func (m *Mixer) Send(t testing.TB, port int, address string, args ...any) {
+	t.Helper()
+	data, err := osc.NewMessage(address, args...).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode %s: %v", address, err)
+	}
+	if _, err := m.conn.WriteToUDP(data, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port}); err != nil {
+		t.Fatalf("failed to send %s: %v", address, err)
+	}
+}
+
+// serve answers requests until the connection is closed.
+func (m *Mixer) serve() {
+	buffer := make([]byte, 4096)
+	for {
+		n, addr, err := m.conn.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		packet, err := osc.ParsePacket(string(buffer[:n]))
+		if err != nil {
+			continue
+		}
+		msg, ok := packet.(*osc.Message)
+		if !ok {
+			continue
+		}
+		m.handle(msg, addr)
+	}
+}
+
+// handle stores a set, or queues the reply to a request.
+func (m *Mixer) handle(msg *osc.Message, from *net.UDPAddr) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out *osc.Message
+	switch {
+	case msg.Address == "/node" && len(msg.Arguments) == 1:
+		path, _ := msg.Arguments[0].(string)
+		text, ok := m.nodes[path]
+		if !ok {
+			return
+		}
+		out = osc.NewMessage("node", text+"\n")
+	case msg.Address == "/xinfo":
+		out = osc.NewMessage(msg.Address, m.Host(), "fake", m.model, "1.0")
+	case len(msg.Arguments) > 0:
+		m.values[msg.Address] = msg.Arguments
+		m.sets[msg.Address]++
+		return
+	case m.empty[msg.Address]:
+		out = osc.NewMessage(msg.Address)
+	default:
+		args, ok := m.values[msg.Address]
+		if !ok {
+			args = defaultValue(msg.Address)
+		}
+		out = osc.NewMessage(msg.Address, args...)
+	}
+
+	data, err := out.MarshalBinary()
+	if err != nil {
+		return
+	}
+	select {
+	case m.replies <- reply{data: data, to: from, due: time.Now().Add(m.delays[msg.Address])}:
+	case <-m.done:
+	}
+}
+
+// send writes the queued replies in order, each no earlier than it is due.
+func (m *Mixer) send() {
+	for {
+		select {
+		case r := <-m.replies:
+			time.Sleep(time.Until(r.due))
+			m.conn.WriteToUDP(r.data, r.to)
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// intParams are the last address elements of the parameters the mixer reports as ints, the others are floats.
+var intParams = map[string]bool{
+	"on": true, "mode": true, "type": true, "ratio": true, "phantom": true, "color": true, "keysrc": true,
+	"auto": true, "mono": true, "dim": true, "insrc": true, "source": true, "pos": true, "sel": true,
+	"st": true, "lr": true, "lrmix": true, "fxslot": true, "grp": true, "tap": true,
+}
+
+// defaultValue returns the value reported for an address that was never set: an empty name, 0 for ints
+// and links, and 0.5 for floats.
+func defaultValue(address string) []any {
+	last := address[strings.LastIndex(address, "/")+1:]
+	switch {
+	case last == "name":
+		return []any{""}
+	case intParams[last] || strings.Contains(address, "link"):
+		return []any{int32(0)}
+	default:
+		return []any{float32(0.5)}
+	}
+}